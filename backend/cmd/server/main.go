@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	db "github.com/DCCXXV/twoplayers/backend/db/sqlc"
 	"github.com/DCCXXV/twoplayers/backend/internal/config"
@@ -39,8 +41,16 @@ func main() {
 	roomService := service.NewRoomService(queries, pool)
 	connectionService := service.NewConnectionService(queries)
 	playerService := service.NewPlayerService(queries)
+	puzzleService := service.NewPuzzleService(queries)
+	gameRecordService := service.NewGameRecordService(queries)
 
-	rtManager, err := realtime.NewManager(cfg, connectionService, roomService, playerService)
+	seedCtx, cancelSeed := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := puzzleService.SeedCuratedPuzzles(seedCtx); err != nil {
+		log.Error("Failed to seed curated puzzles", "error", err)
+	}
+	cancelSeed()
+
+	rtManager, err := realtime.NewManager(cfg, connectionService, roomService, playerService, puzzleService, gameRecordService)
 	if err != nil {
 		log.Error("FATAL: Failed to initialize realtime manager", "error", err)
 		os.Exit(1)
@@ -57,16 +67,20 @@ func main() {
 	router.Use(cors.New(corsConfig))
 	router.Use(cors.New(corsConfig))
 
-	httpHandler := handlers.NewHTTPHandler(roomService, playerService, connectionService)
+	httpHandler := handlers.NewHTTPHandler(roomService, playerService, connectionService, puzzleService, gameRecordService)
 	wsHandler := handlers.NewWebSocketHandler(rtManager)
 
 	apiV1 := router.Group("/api/v1")
 	{
 		apiV1.POST("/rooms", httpHandler.CreateRoom)
 		apiV1.GET("/rooms/:roomId", httpHandler.GetRoom)
+		apiV1.GET("/rooms/:roomId/records", httpHandler.ListRoomGameRecords)
 		apiV1.GET("/rooms", httpHandler.ListPublicRooms)
 		apiV1.GET("/connections", httpHandler.ListActiveConnections)
+		apiV1.GET("/games", httpHandler.ListGames)
 		apiV1.DELETE("/rooms", httpHandler.DeleteRoom)
+		apiV1.GET("/puzzles", httpHandler.ListPuzzles)
+		apiV1.GET("/records/:recordId", httpHandler.GetGameRecord)
 	}
 
 	router.GET("/ws", wsHandler.HandleConnection)
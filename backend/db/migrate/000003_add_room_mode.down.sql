@@ -0,0 +1,2 @@
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS mode;
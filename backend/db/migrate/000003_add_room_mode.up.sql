@@ -0,0 +1,2 @@
+ALTER TABLE rooms
+ADD COLUMN mode VARCHAR(20) NOT NULL DEFAULT 'play' CHECK (mode IN ('play', 'analysis'));
@@ -0,0 +1,2 @@
+ALTER TABLE rooms
+ADD COLUMN hints_enabled BOOLEAN NOT NULL DEFAULT TRUE;
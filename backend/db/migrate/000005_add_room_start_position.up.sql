@@ -0,0 +1,2 @@
+ALTER TABLE rooms
+ADD COLUMN start_position TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE rooms
+DROP COLUMN IF EXISTS puzzle_id;
+
+DROP TABLE IF EXISTS puzzles;
@@ -0,0 +1,23 @@
+-- -----------------------------------------------------
+-- Table `puzzles`
+-- Stores puzzle positions with their solution trees and ratings.
+-- Curated puzzles are identified by slug so that reseeding keeps ratings.
+-- -----------------------------------------------------
+CREATE TABLE puzzles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title VARCHAR(100) NOT NULL,
+    game_type VARCHAR(50) NOT NULL,
+    game_options JSONB,
+    position TEXT NOT NULL DEFAULT '',
+    solution JSONB NOT NULL,
+    rating INTEGER NOT NULL DEFAULT 1500,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    solves INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_puzzles_game_type ON puzzles(game_type);
+
+ALTER TABLE rooms
+ADD COLUMN puzzle_id UUID NULL REFERENCES puzzles(id) ON DELETE SET NULL;
@@ -0,0 +1,20 @@
+-- -----------------------------------------------------
+-- Table `game_records`
+-- Stores finished games with their post-game summary. Rooms are deleted
+-- once everyone has left, so room_id is kept for grouping only.
+-- -----------------------------------------------------
+CREATE TABLE game_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL,
+    game_type VARCHAR(50) NOT NULL,
+    game_options JSONB,
+    start_position TEXT NOT NULL DEFAULT '',
+    moves TEXT NOT NULL DEFAULT '',
+    player_0 VARCHAR(50) NOT NULL,
+    player_1 VARCHAR(50) NOT NULL,
+    winner VARCHAR(50) NOT NULL,
+    summary JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_game_records_room_id ON game_records(room_id);
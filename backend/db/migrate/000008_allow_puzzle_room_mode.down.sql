@@ -0,0 +1,5 @@
+DELETE FROM rooms WHERE mode = 'puzzle';
+ALTER TABLE rooms
+DROP CONSTRAINT IF EXISTS rooms_mode_check;
+ALTER TABLE rooms
+ADD CONSTRAINT rooms_mode_check CHECK (mode IN ('play', 'analysis'));
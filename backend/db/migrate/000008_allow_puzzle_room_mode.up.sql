@@ -0,0 +1,4 @@
+ALTER TABLE rooms
+DROP CONSTRAINT IF EXISTS rooms_mode_check;
+ALTER TABLE rooms
+ADD CONSTRAINT rooms_mode_check CHECK (mode IN ('play', 'analysis', 'puzzle'));
@@ -0,0 +1,2 @@
+ALTER TABLE rooms
+ALTER COLUMN hints_enabled SET DEFAULT FALSE;
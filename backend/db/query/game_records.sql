@@ -0,0 +1,26 @@
+-- name: CreateGameRecord :one
+INSERT INTO game_records (
+    room_id,
+    game_type,
+    game_options,
+    start_position,
+    moves,
+    player_0,
+    player_1,
+    winner,
+    summary
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetGameRecordByID :one
+SELECT * FROM game_records
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListGameRecordsByRoomID :many
+-- Lists the games played in a room, the latest first.
+SELECT * FROM game_records
+WHERE room_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,46 @@
+-- name: UpsertPuzzle :one
+-- Adds a curated puzzle, or updates one with the same slug in place while
+-- keeping its rating and attempt counts.
+INSERT INTO puzzles (
+    slug,
+    title,
+    game_type,
+    game_options,
+    position,
+    solution
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (slug) DO UPDATE SET
+    title = EXCLUDED.title,
+    game_type = EXCLUDED.game_type,
+    game_options = EXCLUDED.game_options,
+    position = EXCLUDED.position,
+    solution = EXCLUDED.solution
+RETURNING *;
+
+-- name: GetPuzzleByID :one
+SELECT * FROM puzzles
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListPuzzles :many
+-- Lists puzzles from the easiest up.
+SELECT * FROM puzzles
+ORDER BY rating ASC, slug ASC
+LIMIT $1 OFFSET $2;
+
+-- name: ListPuzzlesByGameType :many
+SELECT * FROM puzzles
+WHERE game_type = $1
+ORDER BY rating ASC, slug ASC
+LIMIT $2 OFFSET $3;
+
+-- name: RecordPuzzleAttempt :one
+-- Counts a finished attempt and applies its rating change.
+UPDATE puzzles
+SET rating = rating + sqlc.arg(rating_change),
+    attempts = attempts + 1,
+    solves = solves + CASE WHEN sqlc.arg(solved)::boolean THEN 1 ELSE 0 END
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -4,9 +4,13 @@ INSERT INTO rooms (
     game_type,
     host_display_name,
     game_options,
-    is_private
+    is_private,
+    mode,
+    hints_enabled,
+    start_position,
+    puzzle_id
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
 RETURNING *;
 
@@ -41,6 +45,6 @@ SELECT
 FROM rooms r
 LEFT JOIN players p1 ON p1.room_id = r.id AND p1.player_order = 0
 LEFT JOIN players p2 ON p2.room_id = r.id AND p2.player_order = 1
-WHERE r.is_private = FALSE AND r.game_type = $1
+WHERE r.is_private = FALSE AND r.mode = 'play' AND r.game_type = $1
 ORDER BY r.created_at ASC
 LIMIT $2 OFFSET $3;
@@ -15,6 +15,20 @@ type ActiveConnection struct {
 	CurrentRoomID pgtype.UUID        `json:"current_room_id"`
 }
 
+type GameRecord struct {
+	ID            pgtype.UUID        `json:"id"`
+	RoomID        pgtype.UUID        `json:"room_id"`
+	GameType      string             `json:"game_type"`
+	GameOptions   []byte             `json:"game_options"`
+	StartPosition string             `json:"start_position"`
+	Moves         string             `json:"moves"`
+	Player0       string             `json:"player_0"`
+	Player1       string             `json:"player_1"`
+	Winner        string             `json:"winner"`
+	Summary       []byte             `json:"summary"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
 type Player struct {
 	ID                pgtype.UUID        `json:"id"`
 	RoomID            pgtype.UUID        `json:"room_id"`
@@ -23,6 +37,20 @@ type Player struct {
 	JoinedAt          pgtype.Timestamptz `json:"joined_at"`
 }
 
+type Puzzle struct {
+	ID          pgtype.UUID        `json:"id"`
+	Slug        string             `json:"slug"`
+	Title       string             `json:"title"`
+	GameType    string             `json:"game_type"`
+	GameOptions []byte             `json:"game_options"`
+	Position    string             `json:"position"`
+	Solution    []byte             `json:"solution"`
+	Rating      int32              `json:"rating"`
+	Attempts    int32              `json:"attempts"`
+	Solves      int32              `json:"solves"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type Room struct {
 	ID              pgtype.UUID        `json:"id"`
 	Name            string             `json:"name"`
@@ -31,4 +59,8 @@ type Room struct {
 	IsPrivate       bool               `json:"is_private"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	HostDisplayName string             `json:"host_display_name"`
+	Mode            string             `json:"mode"`
+	HintsEnabled    bool               `json:"hints_enabled"`
+	StartPosition   string             `json:"start_position"`
+	PuzzleID        pgtype.UUID        `json:"puzzle_id"`
 }
@@ -14,6 +14,7 @@ type Querier interface {
 	// Registers a new active connection with a unique display name.
 	// Fails if the display_name is already taken (due to PRIMARY KEY constraint).
 	CreateActiveConnection(ctx context.Context, displayName string) (ActiveConnection, error)
+	CreateGameRecord(ctx context.Context, arg CreateGameRecordParams) (GameRecord, error)
 	// Links an active connection (identified by player_display_name) to a specific room (room_id)
 	// with a designated player_order (0 or 1).
 	// Assumes the player_display_name already exists in the active_connections table.
@@ -31,23 +32,35 @@ type Querier interface {
 	FindStaleConnections(ctx context.Context, lastSeen pgtype.Timestamptz) ([]string, error)
 	// Retrieves an active connection by display name.
 	GetActiveConnection(ctx context.Context, displayName string) (ActiveConnection, error)
+	GetGameRecordByID(ctx context.Context, id pgtype.UUID) (GameRecord, error)
 	// Retrieves all players associated with a specific room, ordered by their turn.
 	GetPlayersByRoomID(ctx context.Context, roomID pgtype.UUID) ([]Player, error)
+	GetPuzzleByID(ctx context.Context, id pgtype.UUID) (Puzzle, error)
 	GetRoomByID(ctx context.Context, id pgtype.UUID) (Room, error)
 	// $1 would be a timestamp like NOW() - INTERVAL '5 minutes'
 	// Lists all active connections with their status and game type.
 	ListActiveConnections(ctx context.Context) ([]ListActiveConnectionsRow, error)
 	// Lists users currently in the lobby state.
 	ListActiveLobbyUsers(ctx context.Context) ([]string, error)
+	// Lists the games played in a room, the latest first.
+	ListGameRecordsByRoomID(ctx context.Context, roomID pgtype.UUID) ([]GameRecord, error)
 	ListPublicRooms(ctx context.Context) ([]Room, error)
 	ListPublicRoomsWithPlayers(ctx context.Context, arg ListPublicRoomsWithPlayersParams) ([]ListPublicRoomsWithPlayersRow, error)
+	// Lists puzzles from the easiest up.
+	ListPuzzles(ctx context.Context, arg ListPuzzlesParams) ([]Puzzle, error)
+	ListPuzzlesByGameType(ctx context.Context, arg ListPuzzlesByGameTypeParams) ([]Puzzle, error)
 	ListRoomsByGameType(ctx context.Context, gameType string) ([]Room, error)
+	// Counts a finished attempt and applies its rating change.
+	RecordPuzzleAttempt(ctx context.Context, arg RecordPuzzleAttemptParams) (Puzzle, error)
 	UpdateActiveConnectionName(ctx context.Context, arg UpdateActiveConnectionNameParams) (int64, error)
 	// Updates the last_seen timestamp for a connection (heartbeat).
 	UpdateConnectionLastSeen(ctx context.Context, displayName string) error
 	// Updates the status and current_room_id for an active connection.
 	// e.g: when a player joins or leaves a room.
 	UpdateConnectionStatusAndRoom(ctx context.Context, arg UpdateConnectionStatusAndRoomParams) (ActiveConnection, error)
+	// Adds a curated puzzle, or updates one with the same slug in place while
+	// keeping its rating and attempt counts.
+	UpsertPuzzle(ctx context.Context, arg UpsertPuzzleParams) (Puzzle, error)
 }
 
 var _ Querier = (*Queries)(nil)
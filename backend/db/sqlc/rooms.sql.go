@@ -17,19 +17,27 @@ INSERT INTO rooms (
     game_type,
     host_display_name,
     game_options,
-    is_private
+    is_private,
+    mode,
+    hints_enabled,
+    start_position,
+    puzzle_id
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING id, name, game_type, game_options, is_private, created_at, host_display_name
+RETURNING id, name, game_type, game_options, is_private, created_at, host_display_name, mode, hints_enabled, start_position, puzzle_id
 `
 
 type CreateRoomParams struct {
-	Name            string `json:"name"`
-	GameType        string `json:"game_type"`
-	HostDisplayName string `json:"host_display_name"`
-	GameOptions     []byte `json:"game_options"`
-	IsPrivate       bool   `json:"is_private"`
+	Name            string      `json:"name"`
+	GameType        string      `json:"game_type"`
+	HostDisplayName string      `json:"host_display_name"`
+	GameOptions     []byte      `json:"game_options"`
+	IsPrivate       bool        `json:"is_private"`
+	Mode            string      `json:"mode"`
+	HintsEnabled    bool        `json:"hints_enabled"`
+	StartPosition   string      `json:"start_position"`
+	PuzzleID        pgtype.UUID `json:"puzzle_id"`
 }
 
 func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error) {
@@ -39,6 +47,10 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 		arg.HostDisplayName,
 		arg.GameOptions,
 		arg.IsPrivate,
+		arg.Mode,
+		arg.HintsEnabled,
+		arg.StartPosition,
+		arg.PuzzleID,
 	)
 	var i Room
 	err := row.Scan(
@@ -49,6 +61,10 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, e
 		&i.IsPrivate,
 		&i.CreatedAt,
 		&i.HostDisplayName,
+		&i.Mode,
+		&i.HintsEnabled,
+		&i.StartPosition,
+		&i.PuzzleID,
 	)
 	return i, err
 }
@@ -64,7 +80,7 @@ func (q *Queries) DeleteRoom(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getRoomByID = `-- name: GetRoomByID :one
-SELECT id, name, game_type, game_options, is_private, created_at, host_display_name FROM rooms
+SELECT id, name, game_type, game_options, is_private, created_at, host_display_name, mode, hints_enabled, start_position, puzzle_id FROM rooms
 WHERE id = $1
 LIMIT 1
 `
@@ -80,12 +96,16 @@ func (q *Queries) GetRoomByID(ctx context.Context, id pgtype.UUID) (Room, error)
 		&i.IsPrivate,
 		&i.CreatedAt,
 		&i.HostDisplayName,
+		&i.Mode,
+		&i.HintsEnabled,
+		&i.StartPosition,
+		&i.PuzzleID,
 	)
 	return i, err
 }
 
 const listPublicRooms = `-- name: ListPublicRooms :many
-SELECT id, name, game_type, game_options, is_private, created_at, host_display_name FROM rooms
+SELECT id, name, game_type, game_options, is_private, created_at, host_display_name, mode, hints_enabled, start_position, puzzle_id FROM rooms
 WHERE is_private = FALSE
 ORDER BY created_at DESC
 `
@@ -107,6 +127,10 @@ func (q *Queries) ListPublicRooms(ctx context.Context) ([]Room, error) {
 			&i.IsPrivate,
 			&i.CreatedAt,
 			&i.HostDisplayName,
+			&i.Mode,
+			&i.HintsEnabled,
+			&i.StartPosition,
+			&i.PuzzleID,
 		); err != nil {
 			return nil, err
 		}
@@ -130,7 +154,7 @@ SELECT
 FROM rooms r
 LEFT JOIN players p1 ON p1.room_id = r.id AND p1.player_order = 0
 LEFT JOIN players p2 ON p2.room_id = r.id AND p2.player_order = 1
-WHERE r.is_private = FALSE AND r.game_type = $1
+WHERE r.is_private = FALSE AND r.mode = 'play' AND r.game_type = $1
 ORDER BY r.created_at ASC
 LIMIT $2 OFFSET $3
 `
@@ -180,7 +204,7 @@ func (q *Queries) ListPublicRoomsWithPlayers(ctx context.Context, arg ListPublic
 }
 
 const listRoomsByGameType = `-- name: ListRoomsByGameType :many
-SELECT id, name, game_type, game_options, is_private, created_at, host_display_name FROM rooms
+SELECT id, name, game_type, game_options, is_private, created_at, host_display_name, mode, hints_enabled, start_position, puzzle_id FROM rooms
 WHERE game_type = $1 AND is_private = FALSE
 ORDER BY created_at DESC
 `
@@ -202,6 +226,10 @@ func (q *Queries) ListRoomsByGameType(ctx context.Context, gameType string) ([]R
 			&i.IsPrivate,
 			&i.CreatedAt,
 			&i.HostDisplayName,
+			&i.Mode,
+			&i.HintsEnabled,
+			&i.StartPosition,
+			&i.PuzzleID,
 		); err != nil {
 			return nil, err
 		}
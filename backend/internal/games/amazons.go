@@ -0,0 +1,374 @@
+package games
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const amazonsSize = 10
+
+func init() {
+	RegisterGame("amazons", NewAmazons)
+	RegisterMetadata("amazons", amazonsMetadata)
+}
+
+var amazonsMetadata = Metadata{
+	DisplayName: "Amazons",
+	Description: "Move your amazons like chess queens and shoot arrows to wall off the board. The last player able to move wins.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Each player has four amazons on a 10x10 board",
+		"A turn moves one amazon like a chess queen, then shoots an arrow from where it lands",
+		"Arrows travel like a queen and block their square for the rest of the game",
+		"Amazons and arrows cannot pass through occupied squares",
+		"You lose if you cannot move on your turn",
+	},
+}
+
+var queenDirections = [8][2]int{
+	{-1, -1},
+	{-1, 0},
+	{-1, 1},
+	{0, -1},
+	{0, 1},
+	{1, -1},
+	{1, 0},
+	{1, 1},
+}
+
+// Amazons is played on a 10x10 board. A turn moves one of the player's
+// amazons like a chess queen and then shoots an arrow, also like a queen,
+// from its new square. Arrows are marked "X" and block the square for the
+// rest of the game. The last player able to move wins.
+type Amazons struct {
+	Board         [amazonsSize][amazonsSize]string `json:"board"`
+	CurrentTurn   int                              `json:"currentTurn"`
+	Winner        string                           `json:"winner"`
+	LastMove      [][2]int                         `json:"lastMove"`
+	playerSymbols [2]string
+	moves         int
+}
+
+func NewAmazons() (Game, error) {
+	a := &Amazons{}
+	a.Reset()
+	return a, nil
+}
+
+func (a *Amazons) HandleMove(playerIndex int, move any) error {
+	if a.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != a.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	from, err := parseSquare(moveData["from"])
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := parseSquare(moveData["to"])
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	arrow, err := parseSquare(moveData["arrow"])
+	if err != nil {
+		return fmt.Errorf("arrow: %w", err)
+	}
+
+	for _, square := range [][2]int{from, to, arrow} {
+		if square[0] < 0 || square[0] >= amazonsSize || square[1] < 0 || square[1] >= amazonsSize {
+			return fmt.Errorf("out of bounds")
+		}
+	}
+
+	symbol := a.playerSymbols[playerIndex]
+	if a.Board[from[0]][from[1]] != symbol {
+		return fmt.Errorf("you must move one of your own amazons")
+	}
+
+	if !a.isQueenPathClear(from, to) {
+		return fmt.Errorf("amazon must move in a straight unobstructed line")
+	}
+
+	a.Board[from[0]][from[1]] = ""
+	a.Board[to[0]][to[1]] = symbol
+
+	if !a.isQueenPathClear(to, arrow) {
+		a.Board[to[0]][to[1]] = ""
+		a.Board[from[0]][from[1]] = symbol
+		return fmt.Errorf("arrow must fly in a straight unobstructed line")
+	}
+
+	a.Board[arrow[0]][arrow[1]] = "X"
+	a.LastMove = [][2]int{from, to, arrow}
+	a.moves++
+
+	if a.checkWinner(symbol) {
+		a.Winner = symbol
+	}
+
+	a.CurrentTurn = (a.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+// isQueenPathClear reports whether to can be reached from from in a single
+// orthogonal or diagonal line over empty squares.
+func (a *Amazons) isQueenPathClear(from, to [2]int) bool {
+	dRow, dCol := to[0]-from[0], to[1]-from[1]
+	if dRow == 0 && dCol == 0 {
+		return false
+	}
+	if dRow != 0 && dCol != 0 && abs(dRow) != abs(dCol) {
+		return false
+	}
+
+	stepRow, stepCol := sign(dRow), sign(dCol)
+	for r, c := from[0]+stepRow, from[1]+stepCol; ; r, c = r+stepRow, c+stepCol {
+		if a.Board[r][c] != "" {
+			return false
+		}
+		if r == to[0] && c == to[1] {
+			return true
+		}
+	}
+}
+
+func (a *Amazons) checkWinner(symbol string) bool {
+	otherPlayerSymbol := a.playerSymbols[0]
+	if symbol == a.playerSymbols[0] {
+		otherPlayerSymbol = a.playerSymbols[1]
+	}
+
+	return !a.hasValidMoves(otherPlayerSymbol)
+}
+
+// hasValidMoves reports whether any amazon of symbol can move. An amazon with
+// an empty neighbouring square can always step there and shoot back.
+func (a *Amazons) hasValidMoves(symbol string) bool {
+	for r := range a.Board {
+		for c := range a.Board[r] {
+			if a.Board[r][c] != symbol {
+				continue
+			}
+			for _, dir := range queenDirections {
+				nr, nc := r+dir[0], c+dir[1]
+				if nr >= 0 && nr < amazonsSize && nc >= 0 && nc < amazonsSize && a.Board[nr][nc] == "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (a *Amazons) GetGameState() any {
+	return a
+}
+
+func (a *Amazons) IsGameOver() bool {
+	return a.Winner != ""
+}
+
+func (a *Amazons) GetWinner() string {
+	return a.Winner
+}
+
+func (a *Amazons) Reset() {
+	a.Board = [amazonsSize][amazonsSize]string{}
+	a.playerSymbols = [2]string{"W", "B"}
+	for _, pos := range [][2]int{{6, 0}, {9, 3}, {9, 6}, {6, 9}} {
+		a.Board[pos[0]][pos[1]] = a.playerSymbols[0]
+	}
+	for _, pos := range [][2]int{{3, 0}, {0, 3}, {0, 6}, {3, 9}} {
+		a.Board[pos[0]][pos[1]] = a.playerSymbols[1]
+	}
+	a.CurrentTurn = 0
+	a.Winner = ""
+	a.LastMove = nil
+	a.moves = 0
+}
+
+type amazonsPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+	Moves         int       `json:"moves"`
+}
+
+func (a *Amazons) Clone() Game {
+	cloned := *a
+	cloned.LastMove = slices.Clone(a.LastMove)
+	return &cloned
+}
+
+func (a *Amazons) MarshalState() ([]byte, error) {
+	return marshalState(a, amazonsPrivate{PlayerSymbols: a.playerSymbols, Moves: a.moves})
+}
+
+func (a *Amazons) UnmarshalState(data []byte) error {
+	var restored Amazons
+	var private amazonsPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.moves = private.Moves
+	*a = restored
+	return nil
+}
+
+// ValidatePosition checks that each player keeps their four amazons. Every
+// move fires one arrow, so the arrows also tell whose turn it is.
+// afterSetup recounts the moves from the arrows and ends the game if the
+// player to move has no amazon that can move.
+func (a *Amazons) afterSetup() {
+	a.moves = 0
+	for _, row := range a.Board {
+		a.moves += countPieces(row[:], "X")
+	}
+
+	previous := a.playerSymbols[1-a.CurrentTurn]
+	if a.checkWinner(previous) {
+		a.Winner = previous
+	}
+}
+
+func (a *Amazons) ValidatePosition() error {
+	var cells []string
+	for _, row := range a.Board {
+		cells = append(cells, row[:]...)
+	}
+	if err := checkPieces(cells, a.playerSymbols[0], a.playerSymbols[1], "X"); err != nil {
+		return err
+	}
+	for _, symbol := range a.playerSymbols {
+		if count := countPieces(cells, symbol); count != 4 {
+			return fmt.Errorf("%s must have 4 amazons, but has %d", symbol, count)
+		}
+	}
+	if arrows := countPieces(cells, "X"); arrows%2 != a.CurrentTurn {
+		return fmt.Errorf("player %d cannot be to move after %d arrows", a.CurrentTurn+1, arrows)
+	}
+	return nil
+}
+
+func (a *Amazons) CurrentPlayer() int {
+	return a.CurrentTurn
+}
+
+func (a *Amazons) LegalMoves() []any {
+	if a.IsGameOver() {
+		return nil
+	}
+
+	inBounds := func(square [2]int) bool {
+		return square[0] >= 0 && square[0] < amazonsSize && square[1] >= 0 && square[1] < amazonsSize
+	}
+
+	symbol := a.playerSymbols[a.CurrentTurn]
+	var moves []any
+	for r := range a.Board {
+		for c := range a.Board[r] {
+			if a.Board[r][c] != symbol {
+				continue
+			}
+			from := [2]int{r, c}
+			// The arrow is shot after the amazon has left from.
+			isEmpty := func(square, to [2]int) bool {
+				return square != to && (square == from || a.Board[square[0]][square[1]] == "")
+			}
+
+			for _, moveDir := range queenDirections {
+				for to := from; ; {
+					to = [2]int{to[0] + moveDir[0], to[1] + moveDir[1]}
+					if !inBounds(to) || a.Board[to[0]][to[1]] != "" {
+						break
+					}
+					for _, arrowDir := range queenDirections {
+						for arrow := to; ; {
+							arrow = [2]int{arrow[0] + arrowDir[0], arrow[1] + arrowDir[1]}
+							if !inBounds(arrow) || !isEmpty(arrow, to) {
+								break
+							}
+							moves = append(moves, map[string]any{
+								"from":  squareValue(from),
+								"to":    squareValue(to),
+								"arrow": squareValue(arrow),
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (a *Amazons) PlayerSymbol(playerIndex int) string {
+	return a.playerSymbols[playerIndex]
+}
+
+func (a *Amazons) PositionKey() string {
+	return stateKey(a)
+}
+
+// FormatMove names squares as in chess, with row 1 on player 0's side, and
+// writes the queen's move followed by the arrow, as in "d1-d7/g7".
+func (a *Amazons) FormatMove(move any) (string, error) {
+	from, err := moveSquare(move, "from")
+	if err != nil {
+		return "", err
+	}
+	to, err := moveSquare(move, "to")
+	if err != nil {
+		return "", err
+	}
+	arrow, err := moveSquare(move, "arrow")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s/%s", squareName(from, amazonsSize), squareName(to, amazonsSize), squareName(arrow, amazonsSize)), nil
+}
+
+func (a *Amazons) ParseMove(text string) (any, error) {
+	stepText, arrowText, ok := strings.Cut(strings.ToLower(text), "/")
+	if !ok {
+		return nil, fmt.Errorf("%q is not a move such as d1-d7/g7", text)
+	}
+	from, to, err := parseStep(stepText, amazonsSize)
+	if err != nil {
+		return nil, err
+	}
+	arrow, err := parseSquareName(arrowText, amazonsSize)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"from": squareValue(from), "to": squareValue(to), "arrow": squareValue(arrow)}, nil
+}
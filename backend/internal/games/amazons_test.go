@@ -0,0 +1,71 @@
+package games
+
+import (
+	"testing"
+)
+
+func makeAmazonsMovePayload(from, to, arrow [2]int) any {
+	square := func(sq [2]int) []any { return []any{float64(sq[0]), float64(sq[1])} }
+	return map[string]any{"from": square(from), "to": square(to), "arrow": square(arrow)}
+}
+
+func TestAmazons_HandleMove_ValidMove(t *testing.T) {
+	game, _ := NewAmazons()
+	a := game.(*Amazons)
+
+	err := game.HandleMove(0, makeAmazonsMovePayload([2]int{6, 0}, [2]int{6, 4}, [2]int{6, 0}))
+	if err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+
+	if a.Board[6][0] != "X" || a.Board[6][4] != "W" {
+		t.Errorf("Expected amazon at 6,4 and arrow at 6,0, but got '%s' and '%s'", a.Board[6][4], a.Board[6][0])
+	}
+	if a.CurrentTurn != 1 {
+		t.Errorf("Expected CurrentTurn to be 1, but got %d", a.CurrentTurn)
+	}
+}
+
+func TestAmazons_HandleMove_InvalidMoves(t *testing.T) {
+	game, _ := NewAmazons()
+	a := game.(*Amazons)
+
+	invalid := []struct {
+		name            string
+		from, to, arrow [2]int
+	}{
+		{"knight move", [2]int{6, 0}, [2]int{4, 1}, [2]int{4, 2}},
+		{"through an amazon", [2]int{9, 3}, [2]int{9, 7}, [2]int{8, 7}},
+		{"opponent amazon", [2]int{3, 0}, [2]int{4, 0}, [2]int{5, 0}},
+		{"arrow onto an amazon", [2]int{6, 0}, [2]int{6, 4}, [2]int{6, 9}},
+	}
+
+	for _, move := range invalid {
+		if err := game.HandleMove(0, makeAmazonsMovePayload(move.from, move.to, move.arrow)); err == nil {
+			t.Errorf("Expected an error for %s, but got nil", move.name)
+		}
+	}
+
+	if a.Board[6][0] != "W" || a.Board[6][4] != "" {
+		t.Error("Expected a rejected arrow to leave the amazon where it started")
+	}
+}
+
+func TestAmazons_WinCondition(t *testing.T) {
+	game, _ := NewAmazons()
+	a := game.(*Amazons)
+	a.Board = [amazonsSize][amazonsSize]string{}
+	a.Board[0][0] = "B"
+	a.Board[0][1] = "X"
+	a.Board[1][1] = "X"
+	a.Board[5][5] = "W"
+
+	err := game.HandleMove(0, makeAmazonsMovePayload([2]int{5, 5}, [2]int{5, 0}, [2]int{1, 0}))
+	if err != nil {
+		t.Fatalf("Expected no error for the winning move, but got %v", err)
+	}
+
+	if winner := game.GetWinner(); winner != "W" {
+		t.Errorf("Expected winner to be 'W', but got '%s'", winner)
+	}
+}
@@ -0,0 +1,245 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+const (
+	analysisMaxNodes   = 1000
+	analysisMaxComment = 500
+)
+
+// analysisGlyphs are the usual move annotations, from "!!" for a brilliant
+// move to "??" for a blunder.
+var analysisGlyphs = []string{"", "!!", "!", "!?", "?!", "?", "??"}
+
+// AnalysisNode is a position in an analysis tree. Every node but the root
+// is reached by playing Move from its parent.
+type AnalysisNode struct {
+	ID          int    `json:"id"`
+	Parent      int    `json:"parent"`
+	PlayerIndex int    `json:"playerIndex"`
+	Move        any    `json:"move"`
+	Glyph       string `json:"glyph"`
+	Comment     string `json:"comment"`
+	Children    []int  `json:"children"`
+	state       []byte
+	moveKey     string
+}
+
+// Analysis is a tree of variations explored from a starting position.
+// Moves are checked by the game's own rules, but either side may move at
+// any time and nothing is played for real. The root is a new game or a
+// position set up by hand, and it has ID 0 and Parent -1.
+type Analysis struct {
+	Nodes   map[int]*AnalysisNode `json:"nodes"`
+	Current int                   `json:"current"`
+	game    Game
+	nextID  int
+}
+
+func NewAnalysis(gameType string, options json.RawMessage) (*Analysis, error) {
+	game, err := NewGame(gameType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Analysis{game: game}
+	if err := a.setRoot(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Analysis) setRoot() error {
+	state, err := a.game.MarshalState()
+	if err != nil {
+		return err
+	}
+	a.Nodes = map[int]*AnalysisNode{0: {ID: 0, Parent: -1, PlayerIndex: -1, state: state}}
+	a.Current = 0
+	a.nextID = 1
+	return nil
+}
+
+// Game returns the game at the current node. It must not be changed
+// directly; use Play instead.
+func (a *Analysis) Game() Game {
+	return a.game
+}
+
+// Play makes a move from the current node and moves to the result. A move
+// that was already explored from here is followed rather than added again.
+func (a *Analysis) Play(playerIndex int, move any) (err error) {
+	key, err := json.Marshal(move)
+	if err != nil {
+		return fmt.Errorf("invalid move format")
+	}
+
+	current := a.Nodes[a.Current]
+	for _, id := range current.Children {
+		child := a.Nodes[id]
+		if child.PlayerIndex == playerIndex && child.moveKey == string(key) {
+			return a.Navigate(id)
+		}
+	}
+	if len(a.Nodes) >= analysisMaxNodes {
+		return fmt.Errorf("an analysis can hold at most %d positions", analysisMaxNodes)
+	}
+
+	defer func() {
+		if err != nil {
+			a.game.UnmarshalState(current.state)
+		}
+	}()
+
+	if err := a.game.HandleMove(playerIndex, move); err != nil {
+		return err
+	}
+	state, err := a.game.MarshalState()
+	if err != nil {
+		return err
+	}
+
+	node := &AnalysisNode{
+		ID:          a.nextID,
+		Parent:      current.ID,
+		PlayerIndex: playerIndex,
+		Move:        move,
+		state:       state,
+		moveKey:     string(key),
+	}
+	a.nextID++
+	a.Nodes[node.ID] = node
+	current.Children = append(current.Children, node.ID)
+	a.Current = node.ID
+	return nil
+}
+
+// Navigate moves to any node in the tree.
+func (a *Analysis) Navigate(id int) error {
+	node, ok := a.Nodes[id]
+	if !ok {
+		return fmt.Errorf("position %d does not exist", id)
+	}
+	if err := a.game.UnmarshalState(node.state); err != nil {
+		return err
+	}
+	a.Current = id
+	return nil
+}
+
+// Back moves to the parent of the current node.
+func (a *Analysis) Back() error {
+	if a.Current == 0 {
+		return fmt.Errorf("already at the starting position")
+	}
+	return a.Navigate(a.Nodes[a.Current].Parent)
+}
+
+// Forward moves along the first variation from the current node.
+func (a *Analysis) Forward() error {
+	children := a.Nodes[a.Current].Children
+	if len(children) == 0 {
+		return fmt.Errorf("no moves have been explored from here")
+	}
+	return a.Navigate(children[0])
+}
+
+// Annotate sets the glyph and comment of a node. The root can carry a
+// comment about the position as a whole.
+func (a *Analysis) Annotate(id int, glyph, comment string) error {
+	node, ok := a.Nodes[id]
+	if !ok {
+		return fmt.Errorf("position %d does not exist", id)
+	}
+	if !slices.Contains(analysisGlyphs, glyph) {
+		return fmt.Errorf("glyph must be one of %q", analysisGlyphs[1:])
+	}
+	if id == 0 && glyph != "" {
+		return fmt.Errorf("the starting position has no move to annotate")
+	}
+	if len(comment) > analysisMaxComment {
+		return fmt.Errorf("comments are limited to %d characters", analysisMaxComment)
+	}
+	node.Glyph = glyph
+	node.Comment = comment
+	return nil
+}
+
+// Delete removes a node and every variation after it. If the current node
+// is removed, the analysis moves to the deleted node's parent.
+func (a *Analysis) Delete(id int) error {
+	node, ok := a.Nodes[id]
+	if !ok {
+		return fmt.Errorf("position %d does not exist", id)
+	}
+	if id == 0 {
+		return fmt.Errorf("the starting position cannot be deleted")
+	}
+
+	parent := a.Nodes[node.Parent]
+	parent.Children = slices.DeleteFunc(parent.Children, func(child int) bool { return child == id })
+
+	removed := false
+	stack := []int{id}
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		stack = append(stack, a.Nodes[next].Children...)
+		removed = removed || next == a.Current
+		delete(a.Nodes, next)
+	}
+
+	if removed {
+		return a.Navigate(parent.ID)
+	}
+	return nil
+}
+
+// SetPosition replaces the whole tree with one rooted at a position set up
+// by hand, as described by the package-level SetPosition.
+func (a *Analysis) SetPosition(position json.RawMessage) error {
+	game := a.game.Clone()
+	if err := SetPosition(game, position); err != nil {
+		return err
+	}
+	a.game = game
+	return a.setRoot()
+}
+
+// PlayMoveList plays a list of moves written in the game's notation from
+// the current node, each by the player to move, adding them as a variation.
+// Nothing is added if any move fails or the tree fills up on the way: the
+// moves added so far all follow the first of them, so removing it undoes
+// the whole variation.
+func (a *Analysis) PlayMoveList(text string) (err error) {
+	if err := PlayMoveList(a.game.Clone(), text); err != nil {
+		return err
+	}
+	moves, err := ParseMoveList(a.game, text)
+	if err != nil {
+		return err
+	}
+
+	start, first := a.Current, a.nextID
+	defer func() {
+		if err == nil {
+			return
+		}
+		if _, ok := a.Nodes[first]; ok {
+			a.Delete(first)
+			a.nextID = first
+		}
+		a.Navigate(start)
+	}()
+
+	for _, move := range moves {
+		if err := a.Play(a.game.(MoveGenerator).CurrentPlayer(), move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package games
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func newTicTacToeAnalysis(t *testing.T) *Analysis {
+	t.Helper()
+	a, err := NewAnalysis("tic-tac-toe", nil)
+	if err != nil {
+		t.Fatalf("Expected no error creating an analysis, but got %v", err)
+	}
+	return a
+}
+
+func TestAnalysis_VariationsBranchAndNavigate(t *testing.T) {
+	a := newTicTacToeAnalysis(t)
+	start := mustMarshalState(t, a.Game())
+
+	if err := a.Play(0, makeTicTacToeMovePayload(4)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := a.Play(1, makeTicTacToeMovePayload(0)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	mainLine := a.Current
+
+	// Go back and try another reply.
+	if err := a.Back(); err != nil {
+		t.Fatalf("Expected no error going back, but got %v", err)
+	}
+	if err := a.Play(1, makeTicTacToeMovePayload(8)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(a.Nodes[1].Children) != 2 {
+		t.Fatalf("Expected 2 replies to the first move, but got %d", len(a.Nodes[1].Children))
+	}
+
+	ttt := a.Game().(*TicTacToe)
+	if ttt.Board[8] != "O" || ttt.Board[0] != "" {
+		t.Errorf("Expected only the second variation on the board, but got %v", ttt.Board)
+	}
+
+	if err := a.Navigate(mainLine); err != nil {
+		t.Fatalf("Expected no error navigating, but got %v", err)
+	}
+	ttt = a.Game().(*TicTacToe)
+	if ttt.Board[0] != "O" || ttt.Board[8] != "" {
+		t.Errorf("Expected the main line on the board, but got %v", ttt.Board)
+	}
+
+	// Replaying an explored move follows it instead of adding a node.
+	a.Navigate(1)
+	if err := a.Play(1, makeTicTacToeMovePayload(0)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if a.Current != mainLine || len(a.Nodes) != 4 {
+		t.Errorf("Expected to follow the existing node %d, but got node %d of %d", mainLine, a.Current, len(a.Nodes))
+	}
+
+	a.Navigate(0)
+	if !bytes.Equal(start, mustMarshalState(t, a.Game())) {
+		t.Error("Expected the root to restore the starting position")
+	}
+}
+
+func TestAnalysis_IllegalMoveIsRejected(t *testing.T) {
+	a := newTicTacToeAnalysis(t)
+	a.Play(0, makeTicTacToeMovePayload(4))
+
+	if err := a.Play(0, makeTicTacToeMovePayload(0)); err == nil {
+		t.Fatal("Expected the game's turn order to be enforced, but got nil")
+	}
+	if err := a.Play(1, makeTicTacToeMovePayload(4)); err == nil {
+		t.Fatal("Expected an error for an occupied cell, but got nil")
+	}
+	if len(a.Nodes) != 2 || a.Current != 1 {
+		t.Errorf("Expected rejected moves to leave the tree alone, but got %d nodes at %d", len(a.Nodes), a.Current)
+	}
+}
+
+func TestAnalysis_AnnotateAndDelete(t *testing.T) {
+	a := newTicTacToeAnalysis(t)
+	a.Play(0, makeTicTacToeMovePayload(4))
+	a.Play(1, makeTicTacToeMovePayload(1))
+	a.Play(0, makeTicTacToeMovePayload(0))
+
+	if err := a.Annotate(2, "?", "Edges lose against the centre."); err != nil {
+		t.Fatalf("Expected no error annotating, but got %v", err)
+	}
+	if a.Nodes[2].Glyph != "?" || a.Nodes[2].Comment == "" {
+		t.Errorf("Expected the annotation to be stored, but got %q %q", a.Nodes[2].Glyph, a.Nodes[2].Comment)
+	}
+	if err := a.Annotate(2, "?!?", ""); err == nil {
+		t.Error("Expected an error for an unknown glyph, but got nil")
+	}
+	if err := a.Annotate(0, "!", ""); err == nil {
+		t.Error("Expected an error for a glyph on the starting position, but got nil")
+	}
+
+	if err := a.Delete(2); err != nil {
+		t.Fatalf("Expected no error deleting, but got %v", err)
+	}
+	if len(a.Nodes) != 2 || len(a.Nodes[1].Children) != 0 {
+		t.Errorf("Expected the variation to be removed, but got %d nodes", len(a.Nodes))
+	}
+	if a.Current != 1 || a.Game().(*TicTacToe).Board[1] != "" {
+		t.Errorf("Expected to move back to the deleted node's parent, but got node %d", a.Current)
+	}
+	if err := a.Delete(0); err == nil {
+		t.Error("Expected an error deleting the starting position, but got nil")
+	}
+}
+
+func TestAnalysis_SetPositionStartsANewTree(t *testing.T) {
+	a := newTicTacToeAnalysis(t)
+	a.Play(0, makeTicTacToeMovePayload(4))
+
+	position := json.RawMessage(`{"board": ["X", "X", "", "O", "O", "", "", "", ""], "currentTurn": 0}`)
+	if err := a.SetPosition(position); err != nil {
+		t.Fatalf("Expected no error setting up a position, but got %v", err)
+	}
+	if len(a.Nodes) != 1 || a.Current != 0 {
+		t.Fatalf("Expected a fresh tree, but got %d nodes", len(a.Nodes))
+	}
+
+	if err := a.Play(0, makeTicTacToeMovePayload(2)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if a.Game().GetWinner() != "X" {
+		t.Errorf("Expected X to win from the set-up position, but got %q", a.Game().GetWinner())
+	}
+}
+
+func TestAnalysis_PlayMoveListAddsAVariation(t *testing.T) {
+	a := newTicTacToeAnalysis(t)
+
+	if err := a.PlayMoveList("5 1 9"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if a.Current != 3 || a.Nodes[3].PlayerIndex != 0 {
+		t.Errorf("Expected X to have made the third move at node 3, but got node %d by player %d", a.Current, a.Nodes[a.Current].PlayerIndex)
+	}
+
+	if err := a.PlayMoveList("2 9"); err == nil {
+		t.Fatal("Expected an error for playing an occupied cell, but got nil")
+	}
+	if len(a.Nodes) != 4 || a.Current != 3 {
+		t.Errorf("Expected the tree to be untouched, but got %d nodes at node %d", len(a.Nodes), a.Current)
+	}
+}
+
+func TestAnalysis_PlayMoveListUndoesAPartialVariation(t *testing.T) {
+	a := newTicTacToeAnalysis(t)
+	// Leave room for one more position only.
+	for len(a.Nodes) < analysisMaxNodes-1 {
+		a.Nodes[a.nextID] = &AnalysisNode{ID: a.nextID}
+		a.nextID++
+	}
+	before := a.nextID
+
+	if err := a.PlayMoveList("5 1"); err == nil {
+		t.Fatal("Expected an error once the tree is full, but got nil")
+	}
+	if len(a.Nodes) != analysisMaxNodes-1 || len(a.Nodes[0].Children) != 0 {
+		t.Errorf("Expected the first move to be removed again, but got %d nodes and root children %v", len(a.Nodes), a.Nodes[0].Children)
+	}
+	if a.Current != 0 || a.nextID != before {
+		t.Errorf("Expected to be back at the root, but got node %d", a.Current)
+	}
+	if board := a.Game().(*TicTacToe).Board; board[4] != "" {
+		t.Errorf("Expected the board to be restored, but got %v", board)
+	}
+}
@@ -0,0 +1,369 @@
+package games
+
+import (
+	"fmt"
+	"slices"
+)
+
+const battleshipSize = 10
+
+const (
+	battleshipPhasePlacement = "placement"
+	battleshipPhaseBattle    = "battle"
+)
+
+const (
+	battleshipMiss = "miss"
+	battleshipHit  = "hit"
+	battleshipSunk = "sunk"
+)
+
+func init() {
+	RegisterGame("battleship", NewBattleship)
+	RegisterMetadata("battleship", battleshipMetadata)
+}
+
+var battleshipMetadata = Metadata{
+	DisplayName: "Battleship",
+	Description: "Hide your fleet on a grid and take turns firing at your opponent's. Sink every enemy ship to win.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Both players secretly place a carrier (5), battleship (4), cruiser (3), submarine (3) and destroyer (2)",
+		"Ships may not overlap or leave the grid",
+		"Players then take turns firing one shot at the opponent's grid",
+		"Each shot is reported as a hit or a miss, and you are told when you sink a ship",
+		"The first player to sink the whole enemy fleet wins",
+	},
+}
+
+// battleshipFleet lists the ships each player must place and their lengths.
+var battleshipFleet = map[string]int{
+	"carrier":    5,
+	"battleship": 4,
+	"cruiser":    3,
+	"submarine":  3,
+	"destroyer":  2,
+}
+
+type BattleshipShip struct {
+	Name        string `json:"name"`
+	Row         int    `json:"row"`
+	Col         int    `json:"col"`
+	Orientation string `json:"orientation"`
+}
+
+type BattleshipShot struct {
+	PlayerIndex int    `json:"playerIndex"`
+	Row         int    `json:"row"`
+	Col         int    `json:"col"`
+	Result      string `json:"result"`
+	Ship        string `json:"ship,omitempty"`
+}
+
+// Battleship starts with a placement phase where both players submit their
+// fleets at the same time, followed by alternating shots. Fleets are kept in
+// unexported fields and only reach clients through GetPlayerState, which
+// reveals a player's own fleet, sunk ships, and every fleet once the game is
+// over.
+type Battleship struct {
+	Phase         string
+	CurrentTurn   int
+	Winner        string
+	Ready         [2]bool
+	Shots         [2][battleshipSize][battleshipSize]string
+	LastShot      *BattleshipShot
+	fleets        [2][]BattleshipShip
+	playerSymbols [2]string
+}
+
+// battleshipView is the JSON shape of the state sent to one viewer. Shots[i]
+// holds player i's shots at the opponent's grid and SunkShips[i] the
+// opponent ships player i has sunk.
+type battleshipView struct {
+	Phase       string                                    `json:"phase"`
+	CurrentTurn int                                       `json:"currentTurn"`
+	Winner      string                                    `json:"winner"`
+	Ready       [2]bool                                   `json:"ready"`
+	Shots       [2][battleshipSize][battleshipSize]string `json:"shots"`
+	SunkShips   [2][]BattleshipShip                       `json:"sunkShips"`
+	LastShot    *BattleshipShot                           `json:"lastShot"`
+	Fleets      [2][]BattleshipShip                       `json:"fleets"`
+}
+
+func NewBattleship() (Game, error) {
+	b := &Battleship{}
+	b.Reset()
+	return b, nil
+}
+
+func (b *Battleship) HandleMove(playerIndex int, move any) error {
+	if b.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	moveType, ok := moveData["type"].(string)
+	if !ok {
+		return fmt.Errorf("type must be a string")
+	}
+
+	switch moveType {
+	case "place_fleet":
+		if b.Phase != battleshipPhasePlacement {
+			return fmt.Errorf("fleets can only be placed before the battle")
+		}
+		return b.placeFleet(playerIndex, moveData["ships"])
+
+	case "fire":
+		if b.Phase != battleshipPhaseBattle {
+			return fmt.Errorf("both fleets must be placed before firing")
+		}
+		if playerIndex != b.CurrentTurn {
+			return fmt.Errorf("it's not your turn")
+		}
+		return b.fire(playerIndex, moveData)
+
+	default:
+		return fmt.Errorf("invalid move type: must be 'place_fleet' or 'fire'")
+	}
+}
+
+func (b *Battleship) placeFleet(playerIndex int, value any) error {
+	if b.Ready[playerIndex] {
+		return fmt.Errorf("your fleet is already placed")
+	}
+
+	items, ok := value.([]any)
+	if !ok || len(items) != len(battleshipFleet) {
+		return fmt.Errorf("ships must be a list of %d ships", len(battleshipFleet))
+	}
+
+	var occupied [battleshipSize][battleshipSize]bool
+	seen := make(map[string]bool)
+	fleet := make([]BattleshipShip, 0, len(items))
+
+	for _, item := range items {
+		shipData, ok := item.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid ship format")
+		}
+
+		name, _ := shipData["name"].(string)
+		length, ok := battleshipFleet[name]
+		if !ok {
+			return fmt.Errorf("unknown ship: %s", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("ship %s placed more than once", name)
+		}
+		seen[name] = true
+
+		rowFloat, okRow := shipData["row"].(float64)
+		colFloat, okCol := shipData["col"].(float64)
+		if !okRow || !okCol {
+			return fmt.Errorf("ship row and col must be numbers")
+		}
+
+		orientation, _ := shipData["orientation"].(string)
+		if orientation != "h" && orientation != "v" {
+			return fmt.Errorf("invalid orientation: must be 'h' or 'v'")
+		}
+
+		ship := BattleshipShip{Name: name, Row: int(rowFloat), Col: int(colFloat), Orientation: orientation}
+		for _, cell := range ship.cells(length) {
+			if cell[0] < 0 || cell[0] >= battleshipSize || cell[1] < 0 || cell[1] >= battleshipSize {
+				return fmt.Errorf("ship %s is out of bounds", name)
+			}
+			if occupied[cell[0]][cell[1]] {
+				return fmt.Errorf("ship %s overlaps another ship", name)
+			}
+			occupied[cell[0]][cell[1]] = true
+		}
+		fleet = append(fleet, ship)
+	}
+
+	b.fleets[playerIndex] = fleet
+	b.Ready[playerIndex] = true
+
+	if b.Ready[0] && b.Ready[1] {
+		b.Phase = battleshipPhaseBattle
+		b.CurrentTurn = 0
+	}
+
+	return nil
+}
+
+func (b *Battleship) fire(playerIndex int, moveData map[string]any) error {
+	rowFloat, ok := moveData["row"].(float64)
+	if !ok {
+		return fmt.Errorf("row must be a number")
+	}
+
+	colFloat, ok := moveData["col"].(float64)
+	if !ok {
+		return fmt.Errorf("col must be a number")
+	}
+
+	row := int(rowFloat)
+	col := int(colFloat)
+
+	if row < 0 || row >= battleshipSize || col < 0 || col >= battleshipSize {
+		return fmt.Errorf("out of bounds")
+	}
+
+	if b.Shots[playerIndex][row][col] != "" {
+		return fmt.Errorf("you have already fired at that cell")
+	}
+
+	shot := &BattleshipShot{PlayerIndex: playerIndex, Row: row, Col: col, Result: battleshipMiss}
+	b.Shots[playerIndex][row][col] = battleshipMiss
+
+	opponent := 1 - playerIndex
+	for _, ship := range b.fleets[opponent] {
+		if !ship.covers(row, col) {
+			continue
+		}
+		b.Shots[playerIndex][row][col] = battleshipHit
+		shot.Result = battleshipHit
+		if b.isSunk(playerIndex, ship) {
+			shot.Result = battleshipSunk
+			shot.Ship = ship.Name
+		}
+		break
+	}
+	b.LastShot = shot
+
+	if len(b.sunkShips(playerIndex)) == len(b.fleets[opponent]) {
+		b.Winner = b.playerSymbols[playerIndex]
+		return nil
+	}
+
+	b.CurrentTurn = opponent
+
+	return nil
+}
+
+func (b *Battleship) isSunk(shooter int, ship BattleshipShip) bool {
+	for _, cell := range ship.cells(battleshipFleet[ship.Name]) {
+		if b.Shots[shooter][cell[0]][cell[1]] != battleshipHit {
+			return false
+		}
+	}
+	return true
+}
+
+// sunkShips returns the opponent ships the shooter has sunk so far.
+func (b *Battleship) sunkShips(shooter int) []BattleshipShip {
+	var sunk []BattleshipShip
+	for _, ship := range b.fleets[1-shooter] {
+		if b.isSunk(shooter, ship) {
+			sunk = append(sunk, ship)
+		}
+	}
+	return sunk
+}
+
+func (s BattleshipShip) cells(length int) [][2]int {
+	cells := make([][2]int, length)
+	for i := range cells {
+		if s.Orientation == "h" {
+			cells[i] = [2]int{s.Row, s.Col + i}
+		} else {
+			cells[i] = [2]int{s.Row + i, s.Col}
+		}
+	}
+	return cells
+}
+
+func (s BattleshipShip) covers(row, col int) bool {
+	for _, cell := range s.cells(battleshipFleet[s.Name]) {
+		if cell == [2]int{row, col} {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGameState returns the spectator view, so the full fleets can never be
+// broadcast by accident.
+func (b *Battleship) GetGameState() any {
+	return b.GetPlayerState(-1)
+}
+
+func (b *Battleship) GetPlayerState(playerIndex int) any {
+	view := battleshipView{
+		Phase:       b.Phase,
+		CurrentTurn: b.CurrentTurn,
+		Winner:      b.Winner,
+		Ready:       b.Ready,
+		Shots:       b.Shots,
+		SunkShips:   [2][]BattleshipShip{b.sunkShips(0), b.sunkShips(1)},
+		LastShot:    b.LastShot,
+	}
+
+	for i := range b.fleets {
+		if i == playerIndex || b.IsGameOver() {
+			view.Fleets[i] = append([]BattleshipShip(nil), b.fleets[i]...)
+		}
+	}
+
+	return view
+}
+
+func (b *Battleship) IsGameOver() bool {
+	return b.Winner != ""
+}
+
+func (b *Battleship) GetWinner() string {
+	return b.Winner
+}
+
+func (b *Battleship) Reset() {
+	b.Phase = battleshipPhasePlacement
+	b.CurrentTurn = 0
+	b.Winner = ""
+	b.Ready = [2]bool{}
+	b.Shots = [2][battleshipSize][battleshipSize]string{}
+	b.LastShot = nil
+	b.fleets = [2][]BattleshipShip{}
+	b.playerSymbols = [2]string{"P1", "P2"}
+}
+
+type battleshipPrivate struct {
+	PlayerSymbols [2]string           `json:"playerSymbols"`
+	Fleets        [2][]BattleshipShip `json:"fleets"`
+}
+
+func (b *Battleship) Clone() Game {
+	cloned := *b
+	if b.LastShot != nil {
+		lastShot := *b.LastShot
+		cloned.LastShot = &lastShot
+	}
+	for i := range b.fleets {
+		cloned.fleets[i] = slices.Clone(b.fleets[i])
+	}
+	return &cloned
+}
+
+func (b *Battleship) MarshalState() ([]byte, error) {
+	return marshalState(b, battleshipPrivate{PlayerSymbols: b.playerSymbols, Fleets: b.fleets})
+}
+
+func (b *Battleship) UnmarshalState(data []byte) error {
+	var restored Battleship
+	var private battleshipPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.fleets = private.Fleets
+	*b = restored
+	return nil
+}
@@ -0,0 +1,148 @@
+package games
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// makeBattleshipFleetPayload places every ship horizontally on its own row,
+// starting at the given column.
+func makeBattleshipFleetPayload(col int) any {
+	names := []string{"carrier", "battleship", "cruiser", "submarine", "destroyer"}
+	ships := make([]any, len(names))
+	for i, name := range names {
+		ships[i] = map[string]any{"name": name, "row": float64(i), "col": float64(col), "orientation": "h"}
+	}
+	return map[string]any{"type": "place_fleet", "ships": ships}
+}
+
+func makeBattleshipShotPayload(row int, col int) any {
+	return map[string]any{"type": "fire", "row": float64(row), "col": float64(col)}
+}
+
+func newBattleshipInBattle(t *testing.T) *Battleship {
+	t.Helper()
+	game, _ := NewBattleship()
+	if err := game.HandleMove(1, makeBattleshipFleetPayload(0)); err != nil {
+		t.Fatalf("Expected no error placing fleet, but got %v", err)
+	}
+	if err := game.HandleMove(0, makeBattleshipFleetPayload(5)); err != nil {
+		t.Fatalf("Expected no error placing fleet, but got %v", err)
+	}
+	return game.(*Battleship)
+}
+
+func TestBattleship_PlacementValidation(t *testing.T) {
+	game, _ := NewBattleship()
+
+	outOfBounds := makeBattleshipFleetPayload(6)
+	if err := game.HandleMove(0, outOfBounds); err == nil {
+		t.Error("Expected an error for a carrier past the edge, but got nil")
+	}
+
+	overlapping := makeBattleshipFleetPayload(0).(map[string]any)
+	overlapping["ships"].([]any)[1].(map[string]any)["row"] = float64(0)
+	if err := game.HandleMove(0, overlapping); err == nil {
+		t.Error("Expected an error for overlapping ships, but got nil")
+	}
+
+	missing := makeBattleshipFleetPayload(0).(map[string]any)
+	missing["ships"] = missing["ships"].([]any)[:4]
+	if err := game.HandleMove(0, missing); err == nil {
+		t.Error("Expected an error for an incomplete fleet, but got nil")
+	}
+
+	if err := game.HandleMove(0, makeBattleshipFleetPayload(0)); err != nil {
+		t.Fatalf("Expected no error for a valid fleet, but got %v", err)
+	}
+	if err := game.HandleMove(0, makeBattleshipShotPayload(0, 0)); err == nil {
+		t.Error("Expected an error when firing before both fleets are placed, but got nil")
+	}
+}
+
+func TestBattleship_FleetsStayHidden(t *testing.T) {
+	b := newBattleshipInBattle(t)
+
+	for _, viewer := range []int{-1, 0} {
+		data, err := json.Marshal(b.GetPlayerState(viewer))
+		if err != nil {
+			t.Fatalf("Failed to marshal state: %v", err)
+		}
+		var view battleshipView
+		json.Unmarshal(data, &view)
+		if len(view.Fleets[1]) != 0 {
+			t.Errorf("Expected viewer %d not to see player 1's fleet, but got %v", viewer, view.Fleets[1])
+		}
+	}
+
+	data, _ := json.Marshal(b.GetGameState())
+	if strings.Contains(string(data), "carrier") {
+		t.Errorf("Expected the shared state not to contain any ship, but got %s", data)
+	}
+
+	view := b.GetPlayerState(0).(battleshipView)
+	if len(view.Fleets[0]) != 5 {
+		t.Errorf("Expected player 0 to see their own fleet, but got %v", view.Fleets[0])
+	}
+}
+
+func TestBattleship_ShotResults(t *testing.T) {
+	b := newBattleshipInBattle(t)
+
+	b.HandleMove(0, makeBattleshipShotPayload(9, 9))
+	if b.LastShot.Result != "miss" {
+		t.Errorf("Expected a miss, but got '%s'", b.LastShot.Result)
+	}
+	if b.CurrentTurn != 1 {
+		t.Errorf("Expected CurrentTurn to be 1, but got %d", b.CurrentTurn)
+	}
+
+	b.HandleMove(1, makeBattleshipShotPayload(4, 5))
+	if b.LastShot.Result != "hit" {
+		t.Errorf("Expected a hit, but got '%s'", b.LastShot.Result)
+	}
+
+	b.HandleMove(0, makeBattleshipShotPayload(9, 8))
+	b.HandleMove(1, makeBattleshipShotPayload(4, 6))
+	if b.LastShot.Result != "sunk" || b.LastShot.Ship != "destroyer" {
+		t.Errorf("Expected the destroyer to be sunk, but got %+v", b.LastShot)
+	}
+
+	view := b.GetPlayerState(-1).(battleshipView)
+	if len(view.SunkShips[1]) != 1 {
+		t.Errorf("Expected the sunk destroyer to be revealed, but got %v", view.SunkShips[1])
+	}
+
+	if err := b.HandleMove(0, makeBattleshipShotPayload(9, 9)); err == nil {
+		t.Error("Expected an error when firing at the same cell twice, but got nil")
+	}
+}
+
+func TestBattleship_WinCondition(t *testing.T) {
+	b := newBattleshipInBattle(t)
+
+	lengths := []int{5, 4, 3, 3, 2}
+	miss := 0
+	for row, length := range lengths {
+		for col := 0; col < length; col++ {
+			if err := b.HandleMove(0, makeBattleshipShotPayload(row, col)); err != nil {
+				t.Fatalf("Shot at %d,%d failed: %v", row, col, err)
+			}
+			if b.IsGameOver() {
+				break
+			}
+			b.HandleMove(1, makeBattleshipShotPayload(9-miss/battleshipSize, miss%battleshipSize))
+			miss++
+		}
+	}
+
+	if b.GetWinner() != "P1" {
+		t.Errorf("Expected winner to be 'P1', but got '%s'", b.GetWinner())
+	}
+
+	view := b.GetPlayerState(-1).(battleshipView)
+	if len(view.Fleets[0]) != 5 || len(view.Fleets[1]) != 5 {
+		t.Error("Expected all fleets to be revealed after the game")
+	}
+}
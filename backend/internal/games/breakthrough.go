@@ -0,0 +1,332 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	breakthroughDefaultSize = 8
+	breakthroughMinSize     = 5
+	breakthroughMaxSize     = 12
+)
+
+func init() {
+	RegisterGameWithOptions("breakthrough", NewBreakthrough)
+	RegisterMetadata("breakthrough", breakthroughMetadata)
+}
+
+var breakthroughMetadata = Metadata{
+	DisplayName: "Breakthrough",
+	Description: "Two armies of pawns race to reach the far side of the board, capturing diagonally on the way.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "rows", Label: "Rows", Type: OptionInt, Default: breakthroughDefaultSize, Range: &Range{Min: breakthroughMinSize, Max: breakthroughMaxSize}},
+		{Name: "cols", Label: "Columns", Type: OptionInt, Default: breakthroughDefaultSize, Range: &Range{Min: breakthroughMinSize, Max: breakthroughMaxSize}},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Each player starts with two rows of pawns on their side of the board",
+		"A pawn moves one square straight or diagonally forward onto an empty square",
+		"A pawn captures by moving diagonally forward onto an opposing pawn",
+		"Reach the opponent's home row, or capture every opposing pawn, to win",
+	},
+}
+
+// Breakthrough starts with each player filling their two home rows. Pieces
+// step one square forward, straight onto an empty square or diagonally onto
+// an empty or enemy square. Reaching the far row or capturing every enemy
+// piece wins. Player 0 starts at the bottom.
+type Breakthrough struct {
+	Rows          int        `json:"rows"`
+	Cols          int        `json:"cols"`
+	Board         [][]string `json:"board"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
+	LastMove      [][2]int   `json:"lastMove"`
+	playerSymbols [2]string
+}
+
+type breakthroughOptions struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+}
+
+func NewBreakthrough(options json.RawMessage) (Game, error) {
+	opts := breakthroughOptions{Rows: breakthroughDefaultSize, Cols: breakthroughDefaultSize}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Rows < breakthroughMinSize || opts.Rows > breakthroughMaxSize ||
+		opts.Cols < breakthroughMinSize || opts.Cols > breakthroughMaxSize {
+		return nil, fmt.Errorf("breakthrough rows and cols must be between %d and %d", breakthroughMinSize, breakthroughMaxSize)
+	}
+
+	b := &Breakthrough{Rows: opts.Rows, Cols: opts.Cols}
+	b.Reset()
+	return b, nil
+}
+
+func (b *Breakthrough) HandleMove(playerIndex int, move any) error {
+	if b.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != b.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	from, err := parseSquare(moveData["from"])
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := parseSquare(moveData["to"])
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+
+	if !b.inBounds(from[0], from[1]) || !b.inBounds(to[0], to[1]) {
+		return fmt.Errorf("out of bounds")
+	}
+
+	symbol := b.playerSymbols[playerIndex]
+	if b.Board[from[0]][from[1]] != symbol {
+		return fmt.Errorf("you must move one of your own pieces")
+	}
+
+	if !b.isValidStep(playerIndex, from, to) {
+		return fmt.Errorf("illegal move")
+	}
+
+	b.Board[from[0]][from[1]] = ""
+	b.Board[to[0]][to[1]] = symbol
+	b.LastMove = [][2]int{from, to}
+
+	if b.checkWinner(playerIndex, to) {
+		b.Winner = symbol
+	}
+
+	b.CurrentTurn = (b.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+func (b *Breakthrough) isValidStep(playerIndex int, from, to [2]int) bool {
+	if to[0]-from[0] != b.forward(playerIndex) {
+		return false
+	}
+
+	target := b.Board[to[0]][to[1]]
+	switch to[1] - from[1] {
+	case 0:
+		return target == ""
+	case -1, 1:
+		return target != b.playerSymbols[playerIndex]
+	default:
+		return false
+	}
+}
+
+func (b *Breakthrough) checkWinner(playerIndex int, to [2]int) bool {
+	if to[0] == b.goalRow(playerIndex) {
+		return true
+	}
+	return !b.hasValidMoves(1 - playerIndex)
+}
+
+// hasValidMoves reports whether the player has any legal step, which is false
+// once all of their pieces have been captured.
+func (b *Breakthrough) hasValidMoves(playerIndex int) bool {
+	symbol := b.playerSymbols[playerIndex]
+	for r := range b.Board {
+		for c := range b.Board[r] {
+			if b.Board[r][c] != symbol {
+				continue
+			}
+			for dCol := -1; dCol <= 1; dCol++ {
+				to := [2]int{r + b.forward(playerIndex), c + dCol}
+				if b.inBounds(to[0], to[1]) && b.isValidStep(playerIndex, [2]int{r, c}, to) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (b *Breakthrough) forward(playerIndex int) int {
+	if playerIndex == 0 {
+		return -1
+	}
+	return 1
+}
+
+func (b *Breakthrough) goalRow(playerIndex int) int {
+	if playerIndex == 0 {
+		return 0
+	}
+	return b.Rows - 1
+}
+
+func (b *Breakthrough) inBounds(row, col int) bool {
+	return row >= 0 && row < b.Rows && col >= 0 && col < b.Cols
+}
+
+func (b *Breakthrough) GetGameState() any {
+	return b
+}
+
+func (b *Breakthrough) IsGameOver() bool {
+	return b.Winner != ""
+}
+
+func (b *Breakthrough) GetWinner() string {
+	return b.Winner
+}
+
+func (b *Breakthrough) Reset() {
+	b.playerSymbols = [2]string{"W", "B"}
+	b.Board = make([][]string, b.Rows)
+	for r := range b.Board {
+		b.Board[r] = make([]string, b.Cols)
+		for c := range b.Board[r] {
+			if r < 2 {
+				b.Board[r][c] = b.playerSymbols[1]
+			} else if r >= b.Rows-2 {
+				b.Board[r][c] = b.playerSymbols[0]
+			}
+		}
+	}
+	b.CurrentTurn = 0
+	b.Winner = ""
+	b.LastMove = nil
+}
+
+type breakthroughPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+}
+
+func (b *Breakthrough) Clone() Game {
+	cloned := *b
+	cloned.Board = cloneGrid(b.Board)
+	cloned.LastMove = slices.Clone(b.LastMove)
+	return &cloned
+}
+
+func (b *Breakthrough) MarshalState() ([]byte, error) {
+	return marshalState(b, breakthroughPrivate{PlayerSymbols: b.playerSymbols})
+}
+
+func (b *Breakthrough) UnmarshalState(data []byte) error {
+	var restored Breakthrough
+	var private breakthroughPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	*b = restored
+	return nil
+}
+
+// ValidatePosition requires both players to have pieces left, no more than
+// they start with, and none on the goal row, which would already have won.
+func (b *Breakthrough) ValidatePosition() error {
+	cells := slices.Concat(b.Board...)
+	if err := checkPieces(cells, b.playerSymbols[:]...); err != nil {
+		return err
+	}
+	for i, symbol := range b.playerSymbols {
+		// Only the player to move can have lost every piece, and only the
+		// other player can have just reached the goal row.
+		least := 1
+		if i == b.CurrentTurn {
+			least = 0
+		}
+		count := countPieces(cells, symbol)
+		if count < least || count > 2*b.Cols {
+			return fmt.Errorf("%s must have between %d and %d pieces, but has %d", symbol, least, 2*b.Cols, count)
+		}
+		switch arrived := countPieces(b.Board[b.goalRow(i)], symbol); {
+		case arrived > 0 && i == b.CurrentTurn:
+			return fmt.Errorf("%s cannot be to move with a piece on its goal row", symbol)
+		case arrived > 1:
+			return fmt.Errorf("%s can only have one piece on its goal row", symbol)
+		}
+	}
+	return nil
+}
+
+// afterSetup ends the game if the last player to move reached their goal
+// row or left the player to move without a step.
+func (b *Breakthrough) afterSetup() {
+	previous := 1 - b.CurrentTurn
+	symbol := b.playerSymbols[previous]
+	if slices.Contains(b.Board[b.goalRow(previous)], symbol) || !b.hasValidMoves(b.CurrentTurn) {
+		b.Winner = symbol
+	}
+}
+
+func (b *Breakthrough) CurrentPlayer() int {
+	return b.CurrentTurn
+}
+
+func (b *Breakthrough) LegalMoves() []any {
+	if b.IsGameOver() {
+		return nil
+	}
+	symbol := b.playerSymbols[b.CurrentTurn]
+	var moves []any
+	for r := range b.Board {
+		for c := range b.Board[r] {
+			if b.Board[r][c] != symbol {
+				continue
+			}
+			from := [2]int{r, c}
+			for dCol := -1; dCol <= 1; dCol++ {
+				to := [2]int{r + b.forward(b.CurrentTurn), c + dCol}
+				if b.inBounds(to[0], to[1]) && b.isValidStep(b.CurrentTurn, from, to) {
+					moves = append(moves, map[string]any{"from": squareValue(from), "to": squareValue(to)})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (b *Breakthrough) PlayerSymbol(playerIndex int) string {
+	return b.playerSymbols[playerIndex]
+}
+
+func (b *Breakthrough) PositionKey() string {
+	return stateKey(b)
+}
+
+// FormatMove names squares as in chess, with row 1 on player 0's side, as
+// in "b2-b3".
+func (b *Breakthrough) FormatMove(move any) (string, error) {
+	from, err := moveSquare(move, "from")
+	if err != nil {
+		return "", err
+	}
+	to, err := moveSquare(move, "to")
+	if err != nil {
+		return "", err
+	}
+	return squareName(from, b.Rows) + "-" + squareName(to, b.Rows), nil
+}
+
+func (b *Breakthrough) ParseMove(text string) (any, error) {
+	from, to, err := parseStep(strings.ToLower(text), b.Rows)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"from": squareValue(from), "to": squareValue(to)}, nil
+}
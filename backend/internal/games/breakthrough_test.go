@@ -0,0 +1,185 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeBreakthroughMovePayload(from, to [2]int) any {
+	return map[string]any{
+		"from": []any{float64(from[0]), float64(from[1])},
+		"to":   []any{float64(to[0]), float64(to[1])},
+	}
+}
+
+func newEmptyBreakthrough(t *testing.T, rows, cols int) *Breakthrough {
+	t.Helper()
+	options, _ := json.Marshal(map[string]int{"rows": rows, "cols": cols})
+	game, err := NewBreakthrough(options)
+	if err != nil {
+		t.Fatalf("Expected no error creating breakthrough game, but got %v", err)
+	}
+	b := game.(*Breakthrough)
+	for r := range b.Board {
+		b.Board[r] = make([]string, b.Cols)
+	}
+	return b
+}
+
+func TestBreakthrough_Options(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    string
+		wantErr    bool
+		rows, cols int
+	}{
+		{"default", "", false, 8, 8},
+		{"rectangular", `{"rows": 6, "cols": 9}`, false, 6, 9},
+		{"too small", `{"rows": 4}`, true, 0, 0},
+		{"too large", `{"cols": 13}`, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewBreakthrough(json.RawMessage(tt.options))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error for invalid options, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			b := game.(*Breakthrough)
+			if len(b.Board) != tt.rows || len(b.Board[0]) != tt.cols {
+				t.Errorf("Expected a %dx%d board, but got %dx%d", tt.rows, tt.cols, len(b.Board), len(b.Board[0]))
+			}
+			if b.Board[tt.rows-1][0] != "W" || b.Board[0][0] != "B" || b.Board[2][0] != "" {
+				t.Error("Expected each player to fill their two home rows")
+			}
+		})
+	}
+}
+
+func TestBreakthrough_HandleMove(t *testing.T) {
+	tests := []struct {
+		name        string
+		playerIndex int
+		from, to    [2]int
+		wantErr     bool
+	}{
+		{"straight step", 0, [2]int{6, 3}, [2]int{5, 3}, false},
+		{"diagonal step", 0, [2]int{6, 3}, [2]int{5, 4}, false},
+		{"backwards", 0, [2]int{6, 3}, [2]int{7, 3}, true},
+		{"sideways", 0, [2]int{6, 3}, [2]int{6, 4}, true},
+		{"two squares", 0, [2]int{6, 3}, [2]int{4, 3}, true},
+		{"straight onto own piece", 0, [2]int{7, 3}, [2]int{6, 3}, true},
+		{"opponent piece", 0, [2]int{1, 3}, [2]int{2, 3}, true},
+		{"out of turn", 1, [2]int{1, 3}, [2]int{2, 3}, true},
+		{"off the board", 0, [2]int{6, 7}, [2]int{5, 8}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, _ := NewBreakthrough(nil)
+			b := game.(*Breakthrough)
+
+			err := game.HandleMove(tt.playerIndex, makeBreakthroughMovePayload(tt.from, tt.to))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if b.Board[tt.from[0]][tt.from[1]] != "" || b.Board[tt.to[0]][tt.to[1]] != "W" {
+				t.Error("Expected the piece to move to its destination")
+			}
+			if b.CurrentTurn != 1 {
+				t.Errorf("Expected CurrentTurn to be 1, but got %d", b.CurrentTurn)
+			}
+		})
+	}
+}
+
+func TestBreakthrough_Captures(t *testing.T) {
+	b := newEmptyBreakthrough(t, 8, 8)
+	b.Board[4][4] = "W"
+	b.Board[3][4] = "B"
+	b.Board[3][5] = "B"
+	b.Board[0][0] = "B"
+
+	if err := b.HandleMove(0, makeBreakthroughMovePayload([2]int{4, 4}, [2]int{3, 4})); err == nil {
+		t.Fatal("Expected an error for a straight capture, but got nil")
+	}
+	if err := b.HandleMove(0, makeBreakthroughMovePayload([2]int{4, 4}, [2]int{3, 5})); err != nil {
+		t.Fatalf("Expected no error for a diagonal capture, but got %v", err)
+	}
+	if b.Board[3][5] != "W" {
+		t.Errorf("Expected W to occupy the captured square, but got '%s'", b.Board[3][5])
+	}
+}
+
+func TestBreakthrough_WinConditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		pieces      map[[2]int]string
+		playerIndex int
+		from, to    [2]int
+		want        string
+	}{
+		{
+			name:        "white reaches the far row",
+			pieces:      map[[2]int]string{{1, 2}: "W", {0, 5}: "B"},
+			playerIndex: 0,
+			from:        [2]int{1, 2},
+			to:          [2]int{0, 2},
+			want:        "W",
+		},
+		{
+			name:        "black reaches the far row",
+			pieces:      map[[2]int]string{{6, 2}: "B", {4, 5}: "W"},
+			playerIndex: 1,
+			from:        [2]int{6, 2},
+			to:          [2]int{7, 3},
+			want:        "B",
+		},
+		{
+			name:        "last enemy piece captured",
+			pieces:      map[[2]int]string{{4, 4}: "W", {3, 3}: "B"},
+			playerIndex: 0,
+			from:        [2]int{4, 4},
+			to:          [2]int{3, 3},
+			want:        "W",
+		},
+		{
+			name:        "game continues",
+			pieces:      map[[2]int]string{{4, 4}: "W", {3, 3}: "B", {1, 1}: "B"},
+			playerIndex: 0,
+			from:        [2]int{4, 4},
+			to:          [2]int{3, 3},
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newEmptyBreakthrough(t, 8, 8)
+			for square, symbol := range tt.pieces {
+				b.Board[square[0]][square[1]] = symbol
+			}
+			b.CurrentTurn = tt.playerIndex
+
+			if err := b.HandleMove(tt.playerIndex, makeBreakthroughMovePayload(tt.from, tt.to)); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if winner := b.GetWinner(); winner != tt.want {
+				t.Errorf("Expected winner to be '%s', but got '%s'", tt.want, winner)
+			}
+		})
+	}
+}
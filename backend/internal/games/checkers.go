@@ -0,0 +1,629 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	checkersVariantAmerican      = "american"
+	checkersVariantInternational = "international"
+)
+
+func init() {
+	RegisterGameWithOptions("checkers", NewCheckers)
+	RegisterMetadata("checkers", checkersMetadata)
+}
+
+var checkersMetadata = Metadata{
+	DisplayName: "Checkers",
+	Description: "Jump and capture your opponent's pieces diagonally, crowning kings on the far row. Played as American checkers or International draughts.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "variant", Label: "Variant", Type: OptionChoice, Default: checkersVariantAmerican, Choices: []any{checkersVariantAmerican, checkersVariantInternational}},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Pieces move diagonally forward onto dark squares",
+		"Capturing is compulsory, and a capture continues while more jumps are available",
+		"International draughts requires the longest capture and lets men capture backwards",
+		"A man reaching the far row becomes a king, which moves in both directions",
+		"You lose if you have no pieces or no legal moves left",
+	},
+}
+
+var checkersDiagonals = [4][2]int{
+	{-1, -1},
+	{-1, 1},
+	{1, -1},
+	{1, 1},
+}
+
+// checkersRules holds the differences between the supported variants.
+type checkersRules struct {
+	size          int
+	rowsPerPlayer int
+	// menCaptureBackward allows men to capture in all four directions.
+	menCaptureBackward bool
+	// flyingKings lets kings move and capture along a whole diagonal.
+	flyingKings bool
+	// maximumCapture forces the capture sequence that takes the most pieces.
+	maximumCapture bool
+	// quietMoveLimit is the number of moves without a capture or a man move
+	// after which the game is drawn.
+	quietMoveLimit int
+}
+
+var checkersVariants = map[string]checkersRules{
+	checkersVariantAmerican: {
+		size:           8,
+		rowsPerPlayer:  3,
+		quietMoveLimit: 80,
+	},
+	checkersVariantInternational: {
+		size:               10,
+		rowsPerPlayer:      4,
+		menCaptureBackward: true,
+		flyingKings:        true,
+		maximumCapture:     true,
+		quietMoveLimit:     50,
+	},
+}
+
+// Checkers implements American checkers and International draughts. Men are
+// stored as the player's symbol and kings as the symbol followed by "K".
+// Player 0 starts at the bottom of the board and moves first.
+type Checkers struct {
+	Variant       string     `json:"variant"`
+	Size          int        `json:"size"`
+	Board         [][]string `json:"board"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
+	LastMove      [][2]int   `json:"lastMove"`
+	LastCaptured  [][2]int   `json:"lastCaptured"`
+	rules         checkersRules
+	playerSymbols [2]string
+	quietMoves    int
+	positions     map[string]int
+}
+
+type checkersOptions struct {
+	Variant string `json:"variant"`
+}
+
+// checkersMove is a complete turn: the squares visited by the moving piece and
+// the pieces it jumped over.
+type checkersMove struct {
+	path     [][2]int
+	captured [][2]int
+}
+
+func NewCheckers(options json.RawMessage) (Game, error) {
+	opts := checkersOptions{Variant: checkersVariantAmerican}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	rules, ok := checkersVariants[opts.Variant]
+	if !ok {
+		return nil, fmt.Errorf("unsupported checkers variant: %s", opts.Variant)
+	}
+
+	c := &Checkers{Variant: opts.Variant, Size: rules.size, rules: rules}
+	c.Reset()
+	return c, nil
+}
+
+func (c *Checkers) HandleMove(playerIndex int, move any) error {
+	if c.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != c.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	path, err := parseSquarePath(moveData["path"])
+	if err != nil {
+		return err
+	}
+	if len(path) < 2 {
+		return fmt.Errorf("path must contain at least two squares")
+	}
+	for _, square := range path {
+		if !c.inBounds(square[0], square[1]) {
+			return fmt.Errorf("out of bounds")
+		}
+	}
+
+	from := path[0]
+	if c.owner(c.Board[from[0]][from[1]]) != playerIndex {
+		return fmt.Errorf("you must move one of your own pieces")
+	}
+
+	legalMoves := c.legalMoves(playerIndex)
+	var chosen *checkersMove
+	for i := range legalMoves {
+		if samePath(legalMoves[i].path, path) {
+			chosen = &legalMoves[i]
+			break
+		}
+	}
+	if chosen == nil {
+		if len(legalMoves) > 0 && len(legalMoves[0].captured) > 0 {
+			return fmt.Errorf("illegal move: a capture is mandatory")
+		}
+		return fmt.Errorf("illegal move")
+	}
+
+	c.applyMove(playerIndex, *chosen)
+	c.CurrentTurn = (c.CurrentTurn + 1) % 2
+
+	if len(c.legalMoves(c.CurrentTurn)) == 0 {
+		c.Winner = c.playerSymbols[playerIndex]
+		return nil
+	}
+
+	c.positions[c.positionKey()]++
+	if c.positions[c.positionKey()] >= 3 || c.quietMoves >= c.rules.quietMoveLimit {
+		c.Winner = "draw"
+	}
+
+	return nil
+}
+
+func (c *Checkers) applyMove(playerIndex int, m checkersMove) {
+	from := m.path[0]
+	to := m.path[len(m.path)-1]
+	piece := c.Board[from[0]][from[1]]
+	wasMan := !isCheckersKing(piece)
+
+	c.Board[from[0]][from[1]] = ""
+	for _, square := range m.captured {
+		c.Board[square[0]][square[1]] = ""
+	}
+	if wasMan && to[0] == c.crownRow(playerIndex) {
+		piece = c.playerSymbols[playerIndex] + "K"
+	}
+	c.Board[to[0]][to[1]] = piece
+
+	if wasMan || len(m.captured) > 0 {
+		c.quietMoves = 0
+		c.positions = make(map[string]int)
+	} else {
+		c.quietMoves++
+	}
+
+	c.LastMove = m.path
+	c.LastCaptured = m.captured
+}
+
+// legalMoves lists every move the player may make. When any capture is
+// available only captures are returned, restricted to the longest sequences
+// if the variant requires it.
+func (c *Checkers) legalMoves(playerIndex int) []checkersMove {
+	var captures []checkersMove
+	for r := range c.Board {
+		for col := range c.Board[r] {
+			if c.owner(c.Board[r][col]) != playerIndex {
+				continue
+			}
+			start := [2]int{r, col}
+			c.collectCaptures(playerIndex, start, start, [][2]int{start}, nil, &captures)
+		}
+	}
+
+	if len(captures) > 0 {
+		if !c.rules.maximumCapture {
+			return captures
+		}
+		longest := 0
+		for _, m := range captures {
+			longest = max(longest, len(m.captured))
+		}
+		var best []checkersMove
+		for _, m := range captures {
+			if len(m.captured) == longest {
+				best = append(best, m)
+			}
+		}
+		return best
+	}
+
+	var moves []checkersMove
+	for r := range c.Board {
+		for col := range c.Board[r] {
+			piece := c.Board[r][col]
+			if c.owner(piece) != playerIndex {
+				continue
+			}
+			for _, dir := range checkersDiagonals {
+				if !isCheckersKing(piece) && dir[0] != c.forward(playerIndex) {
+					continue
+				}
+				for step := 1; ; step++ {
+					nr, nc := r+dir[0]*step, col+dir[1]*step
+					if !c.inBounds(nr, nc) || c.Board[nr][nc] != "" {
+						break
+					}
+					moves = append(moves, checkersMove{path: [][2]int{{r, col}, {nr, nc}}})
+					if !isCheckersKing(piece) || !c.rules.flyingKings {
+						break
+					}
+				}
+			}
+		}
+	}
+	return moves
+}
+
+// collectCaptures explores every jump sequence of the piece that started on
+// start and currently stands on pos. Jumped pieces stay on the board until the
+// move is complete, so they block the path and cannot be jumped twice.
+func (c *Checkers) collectCaptures(playerIndex int, start, pos [2]int, path, captured [][2]int, out *[]checkersMove) {
+	piece := c.Board[start[0]][start[1]]
+	king := isCheckersKing(piece)
+	flying := king && c.rules.flyingKings
+	extended := false
+
+	isEmpty := func(r, col int) bool {
+		return c.Board[r][col] == "" || (r == start[0] && col == start[1])
+	}
+	isCaptured := func(r, col int) bool {
+		for _, sq := range captured {
+			if sq[0] == r && sq[1] == col {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, dir := range checkersDiagonals {
+		if !king && !c.rules.menCaptureBackward && dir[0] != c.forward(playerIndex) {
+			continue
+		}
+
+		r, col := pos[0]+dir[0], pos[1]+dir[1]
+		for flying && c.inBounds(r, col) && isEmpty(r, col) {
+			r, col = r+dir[0], col+dir[1]
+		}
+		if !c.inBounds(r, col) || isEmpty(r, col) || isCaptured(r, col) {
+			continue
+		}
+		if c.owner(c.Board[r][col]) != 1-playerIndex {
+			continue
+		}
+		jumped := [2]int{r, col}
+
+		for lr, lc := r+dir[0], col+dir[1]; c.inBounds(lr, lc) && isEmpty(lr, lc); lr, lc = lr+dir[0], lc+dir[1] {
+			landing := [2]int{lr, lc}
+			nextPath := append(append([][2]int(nil), path...), landing)
+			nextCaptured := append(append([][2]int(nil), captured...), jumped)
+			extended = true
+
+			// In American checkers a man that reaches the crown row ends its move.
+			if !king && !c.rules.menCaptureBackward && lr == c.crownRow(playerIndex) {
+				*out = append(*out, checkersMove{path: nextPath, captured: nextCaptured})
+			} else {
+				c.collectCaptures(playerIndex, start, landing, nextPath, nextCaptured, out)
+			}
+
+			if !flying {
+				break
+			}
+		}
+	}
+
+	if !extended && len(captured) > 0 {
+		*out = append(*out, checkersMove{path: path, captured: captured})
+	}
+}
+
+func (c *Checkers) owner(piece string) int {
+	if piece == "" {
+		return -1
+	}
+	for i, symbol := range c.playerSymbols {
+		if strings.TrimSuffix(piece, "K") == symbol {
+			return i
+		}
+	}
+	return -1
+}
+
+func isCheckersKing(piece string) bool {
+	return strings.HasSuffix(piece, "K")
+}
+
+// forward is the row direction in which the player's men move.
+func (c *Checkers) forward(playerIndex int) int {
+	if playerIndex == 0 {
+		return -1
+	}
+	return 1
+}
+
+func (c *Checkers) crownRow(playerIndex int) int {
+	if playerIndex == 0 {
+		return 0
+	}
+	return c.Size - 1
+}
+
+func (c *Checkers) inBounds(row, col int) bool {
+	return row >= 0 && row < c.Size && col >= 0 && col < c.Size
+}
+
+func (c *Checkers) positionKey() string {
+	var sb strings.Builder
+	for r := range c.Board {
+		for col := range c.Board[r] {
+			sb.WriteString(c.Board[r][col])
+			sb.WriteByte(',')
+		}
+	}
+	fmt.Fprintf(&sb, "%d", c.CurrentTurn)
+	return sb.String()
+}
+
+// parseSquarePath converts a JSON list of [row, col] pairs into squares.
+func parseSquarePath(value any) ([][2]int, error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("path must be a list of [row, col] squares")
+	}
+
+	path := make([][2]int, 0, len(items))
+	for _, item := range items {
+		square, err := parseSquare(item)
+		if err != nil {
+			return nil, fmt.Errorf("path must be a list of [row, col] squares")
+		}
+		path = append(path, square)
+	}
+	return path, nil
+}
+
+// parseSquare converts a JSON [row, col] pair into a square.
+func parseSquare(value any) ([2]int, error) {
+	pair, ok := value.([]any)
+	if !ok || len(pair) != 2 {
+		return [2]int{}, fmt.Errorf("square must be a [row, col] pair")
+	}
+	row, okRow := pair[0].(float64)
+	col, okCol := pair[1].(float64)
+	if !okRow || !okCol {
+		return [2]int{}, fmt.Errorf("square must be a [row, col] pair")
+	}
+	return [2]int{int(row), int(col)}, nil
+}
+
+func samePath(a, b [][2]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Checkers) GetGameState() any {
+	return c
+}
+
+func (c *Checkers) IsGameOver() bool {
+	return c.Winner != ""
+}
+
+func (c *Checkers) GetWinner() string {
+	return c.Winner
+}
+
+func (c *Checkers) Reset() {
+	if c.Variant == checkersVariantInternational {
+		c.playerSymbols = [2]string{"W", "B"}
+	} else {
+		c.playerSymbols = [2]string{"B", "W"}
+	}
+
+	c.Board = make([][]string, c.Size)
+	for r := range c.Board {
+		c.Board[r] = make([]string, c.Size)
+		for col := range c.Board[r] {
+			if (r+col)%2 == 0 {
+				continue
+			}
+			if r < c.rules.rowsPerPlayer {
+				c.Board[r][col] = c.playerSymbols[1]
+			} else if r >= c.Size-c.rules.rowsPerPlayer {
+				c.Board[r][col] = c.playerSymbols[0]
+			}
+		}
+	}
+
+	c.CurrentTurn = 0
+	c.Winner = ""
+	c.LastMove = nil
+	c.LastCaptured = nil
+	c.quietMoves = 0
+	c.positions = map[string]int{c.positionKey(): 1}
+}
+
+type checkersPrivate struct {
+	PlayerSymbols [2]string      `json:"playerSymbols"`
+	QuietMoves    int            `json:"quietMoves"`
+	Positions     map[string]int `json:"positions"`
+}
+
+func (c *Checkers) Clone() Game {
+	cloned := *c
+	cloned.Board = cloneGrid(c.Board)
+	cloned.LastMove = slices.Clone(c.LastMove)
+	cloned.LastCaptured = slices.Clone(c.LastCaptured)
+	cloned.positions = maps.Clone(c.positions)
+	return &cloned
+}
+
+func (c *Checkers) MarshalState() ([]byte, error) {
+	return marshalState(c, checkersPrivate{
+		PlayerSymbols: c.playerSymbols,
+		QuietMoves:    c.quietMoves,
+		Positions:     c.positions,
+	})
+}
+
+func (c *Checkers) UnmarshalState(data []byte) error {
+	var restored Checkers
+	var private checkersPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	rules, ok := checkersVariants[restored.Variant]
+	if !ok {
+		return fmt.Errorf("unsupported checkers variant: %s", restored.Variant)
+	}
+	restored.rules = rules
+	restored.playerSymbols = private.PlayerSymbols
+	restored.quietMoves = private.QuietMoves
+	restored.positions = private.Positions
+	if restored.positions == nil {
+		restored.positions = make(map[string]int)
+	}
+	*c = restored
+	return nil
+}
+
+// afterSetup starts the draw counters from the set-up position and ends the
+// game if the player to move has no move left.
+func (c *Checkers) afterSetup() {
+	c.quietMoves = 0
+	c.positions = map[string]int{c.positionKey(): 1}
+
+	if len(c.legalMoves(c.CurrentTurn)) == 0 {
+		c.Winner = c.playerSymbols[1-c.CurrentTurn]
+	}
+}
+
+// ValidatePosition keeps pieces on the dark squares, men off the row where
+// they would have been crowned, and each side to at most its starting
+// number of pieces. The player who moved last must still be able to move,
+// or the game would have ended before the position.
+func (c *Checkers) ValidatePosition() error {
+	var counts [2]int
+	for r := range c.Board {
+		for col, piece := range c.Board[r] {
+			if piece == "" {
+				continue
+			}
+			player := -1
+			for i, symbol := range c.playerSymbols {
+				if piece == symbol || piece == symbol+"K" {
+					player = i
+				}
+			}
+			if player == -1 {
+				return fmt.Errorf("%q is not a piece in this game", piece)
+			}
+			if (r+col)%2 == 0 {
+				return fmt.Errorf("pieces can only stand on dark squares")
+			}
+			if !isCheckersKing(piece) && r == c.crownRow(player) {
+				return fmt.Errorf("a man on the far row must be a king")
+			}
+			counts[player]++
+		}
+	}
+
+	for i, count := range counts {
+		if limit := c.rules.rowsPerPlayer * c.Size / 2; count > limit {
+			return fmt.Errorf("%s can have at most %d pieces, but has %d", c.playerSymbols[i], limit, count)
+		}
+	}
+	if previous := 1 - c.CurrentTurn; len(c.legalMoves(previous)) == 0 {
+		return fmt.Errorf("%s has no move, so the game would already be over", c.playerSymbols[previous])
+	}
+	return nil
+}
+
+func (c *Checkers) CurrentPlayer() int {
+	return c.CurrentTurn
+}
+
+// LegalMoves lists each distinct path once, since HandleMove identifies a
+// move by its path alone.
+func (c *Checkers) LegalMoves() []any {
+	if c.IsGameOver() {
+		return nil
+	}
+	var paths [][][2]int
+	var moves []any
+	for _, m := range c.legalMoves(c.CurrentTurn) {
+		if slices.ContainsFunc(paths, func(path [][2]int) bool { return samePath(path, m.path) }) {
+			continue
+		}
+		paths = append(paths, m.path)
+
+		path := make([]any, len(m.path))
+		for i, square := range m.path {
+			path[i] = squareValue(square)
+		}
+		moves = append(moves, map[string]any{"path": path})
+	}
+	return moves
+}
+
+func (c *Checkers) PlayerSymbol(playerIndex int) string {
+	return c.playerSymbols[playerIndex]
+}
+
+func (c *Checkers) PositionKey() string {
+	return stateKey(c)
+}
+
+// FormatMove names squares as in chess, with row 1 on player 0's side, and
+// joins the squares of the path with "-", as in "c3-e5-c7". Parsing also
+// accepts "x" between squares.
+func (c *Checkers) FormatMove(move any) (string, error) {
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid move format")
+	}
+	path, err := parseSquarePath(moveData["path"])
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, len(path))
+	for i, square := range path {
+		names[i] = squareName(square, c.Size)
+	}
+	return strings.Join(names, "-"), nil
+}
+
+func (c *Checkers) ParseMove(text string) (any, error) {
+	names := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r == '-' || r == 'x'
+	})
+	if len(names) < 2 {
+		return nil, fmt.Errorf("%q is not a move such as c3-d4", text)
+	}
+	path := make([]any, len(names))
+	for i, name := range names {
+		square, err := parseSquareName(name, c.Size)
+		if err != nil {
+			return nil, err
+		}
+		path[i] = squareValue(square)
+	}
+	return map[string]any{"path": path}, nil
+}
@@ -0,0 +1,160 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeCheckersMovePayload(squares ...[2]int) any {
+	path := make([]any, len(squares))
+	for i, sq := range squares {
+		path[i] = []any{float64(sq[0]), float64(sq[1])}
+	}
+	return map[string]any{"path": path}
+}
+
+// newEmptyCheckers returns a game of the given variant with the pieces
+// removed, so tests can set up positions by hand.
+func newEmptyCheckers(t *testing.T, variant string) *Checkers {
+	t.Helper()
+	options, _ := json.Marshal(map[string]string{"variant": variant})
+	game, err := NewCheckers(options)
+	if err != nil {
+		t.Fatalf("Expected no error creating checkers game, but got %v", err)
+	}
+	c := game.(*Checkers)
+	for r := range c.Board {
+		for col := range c.Board[r] {
+			c.Board[r][col] = ""
+		}
+	}
+	c.positions = map[string]int{}
+	return c
+}
+
+func TestCheckers_InitialSetup(t *testing.T) {
+	game, _ := NewCheckers(nil)
+	c := game.(*Checkers)
+
+	if c.Size != 8 {
+		t.Fatalf("Expected an 8x8 board by default, but got %d", c.Size)
+	}
+	if c.Board[5][0] != "B" || c.Board[0][1] != "W" || c.Board[4][1] != "" {
+		t.Errorf("Unexpected initial setup: %v", c.Board)
+	}
+
+	if err := c.HandleMove(0, makeCheckersMovePayload([2]int{5, 0}, [2]int{4, 1})); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+	if c.CurrentTurn != 1 {
+		t.Errorf("Expected CurrentTurn to be 1, but got %d", c.CurrentTurn)
+	}
+
+	err := c.HandleMove(1, makeCheckersMovePayload([2]int{2, 1}, [2]int{1, 0}))
+	if err == nil {
+		t.Fatal("Expected an error when moving a man backwards, but got nil")
+	}
+}
+
+func TestCheckers_MandatoryCapture(t *testing.T) {
+	c := newEmptyCheckers(t, "american")
+	c.Board[5][2] = "B"
+	c.Board[6][7] = "B"
+	c.Board[4][3] = "W"
+	c.Board[0][7] = "W"
+
+	err := c.HandleMove(0, makeCheckersMovePayload([2]int{6, 7}, [2]int{5, 6}))
+	if err == nil {
+		t.Fatal("Expected an error when ignoring an available capture, but got nil")
+	}
+
+	if err := c.HandleMove(0, makeCheckersMovePayload([2]int{5, 2}, [2]int{3, 4})); err != nil {
+		t.Fatalf("Expected no error for the capture, but got %v", err)
+	}
+	if c.Board[4][3] != "" {
+		t.Errorf("Expected captured piece to be removed, but got '%s'", c.Board[4][3])
+	}
+}
+
+func TestCheckers_MultiJump(t *testing.T) {
+	c := newEmptyCheckers(t, "american")
+	c.Board[7][0] = "B"
+	c.Board[6][1] = "W"
+	c.Board[4][3] = "W"
+	c.Board[0][7] = "W"
+
+	err := c.HandleMove(0, makeCheckersMovePayload([2]int{7, 0}, [2]int{5, 2}))
+	if err == nil {
+		t.Fatal("Expected an error when stopping a jump sequence early, but got nil")
+	}
+
+	if err := c.HandleMove(0, makeCheckersMovePayload([2]int{7, 0}, [2]int{5, 2}, [2]int{3, 4})); err != nil {
+		t.Fatalf("Expected no error for the double jump, but got %v", err)
+	}
+	if len(c.LastCaptured) != 2 {
+		t.Errorf("Expected 2 captured pieces, but got %v", c.LastCaptured)
+	}
+	if c.Board[3][4] != "B" {
+		t.Errorf("Expected piece to land on 3,4, but got '%s'", c.Board[3][4])
+	}
+}
+
+func TestCheckers_Kinging(t *testing.T) {
+	c := newEmptyCheckers(t, "american")
+	c.Board[1][2] = "B"
+	c.Board[3][6] = "W"
+
+	if err := c.HandleMove(0, makeCheckersMovePayload([2]int{1, 2}, [2]int{0, 1})); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if c.Board[0][1] != "BK" {
+		t.Errorf("Expected piece to be crowned, but got '%s'", c.Board[0][1])
+	}
+}
+
+func TestCheckers_InternationalMaximumCapture(t *testing.T) {
+	c := newEmptyCheckers(t, "international")
+	c.Board[8][1] = "W"
+	c.Board[6][7] = "W"
+	c.Board[7][2] = "B"
+	c.Board[5][6] = "B"
+	c.Board[3][4] = "B"
+
+	err := c.HandleMove(0, makeCheckersMovePayload([2]int{8, 1}, [2]int{6, 3}))
+	if err == nil {
+		t.Fatal("Expected an error when not taking the maximum capture, but got nil")
+	}
+
+	if err := c.HandleMove(0, makeCheckersMovePayload([2]int{6, 7}, [2]int{4, 5}, [2]int{2, 3})); err != nil {
+		t.Fatalf("Expected no error for the maximum capture, but got %v", err)
+	}
+}
+
+func TestCheckers_DrawByRepetition(t *testing.T) {
+	c := newEmptyCheckers(t, "american")
+	c.Board[7][0] = "BK"
+	c.Board[0][7] = "WK"
+	c.positions[c.positionKey()] = 1
+
+	shuffle := []struct {
+		playerIndex int
+		from, to    [2]int
+	}{
+		{0, [2]int{7, 0}, [2]int{6, 1}},
+		{1, [2]int{0, 7}, [2]int{1, 6}},
+		{0, [2]int{6, 1}, [2]int{7, 0}},
+		{1, [2]int{1, 6}, [2]int{0, 7}},
+	}
+
+	for round := 0; round < 2; round++ {
+		for _, move := range shuffle {
+			if err := c.HandleMove(move.playerIndex, makeCheckersMovePayload(move.from, move.to)); err != nil {
+				t.Fatalf("Move sequence failed in round %d: %v", round, err)
+			}
+		}
+	}
+
+	if c.GetWinner() != "draw" {
+		t.Errorf("Expected a draw by threefold repetition, but got '%s'", c.GetWinner())
+	}
+}
@@ -1,29 +1,125 @@
 package games
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 const (
-	connectFourRows = 6
-	connectFourCols = 7
+	connectFourMinSize    = 4
+	connectFourMaxSize    = 12
+	connectFourMinConnect = 3
+	pop10Target           = 10
 )
 
 func init() {
-	RegisterGame("connect-four", NewConnectFour)
+	RegisterGameWithOptions("connect-four", NewConnectFour)
+	RegisterMetadata("connect-four", connectFourMetadata)
+}
+
+var connectFourMetadata = Metadata{
+	DisplayName: "Connect Four",
+	Description: "Drop colored pieces into a 7x6 grid. Be the first to connect four of your discs in a row: horizontally, vertically, or diagonally!",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "variant", Label: "Variant", Type: OptionChoice, Default: "standard", Choices: []any{"standard", "popout", "pop10", "five-in-a-row"}},
+		{Name: "rows", Label: "Rows", Type: OptionInt, Optional: true, Range: &Range{Min: connectFourMinSize, Max: connectFourMaxSize}},
+		{Name: "cols", Label: "Columns", Type: OptionInt, Optional: true, Range: &Range{Min: connectFourMinSize, Max: connectFourMaxSize}},
+		{Name: "connect", Label: "Line length to win", Type: OptionInt, Optional: true, Range: &Range{Min: connectFourMinConnect, Max: connectFourMaxSize}},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Drop a piece into any column that is not full",
+		"Pieces fall to the lowest available position",
+		"Connect four pieces in a row to win",
+		"Game ends in a draw if the board is full with no winner",
+	},
+}
+
+// connectFourRules describes a variant. PopOut lets a player remove one of
+// their own discs from the bottom row instead of dropping. Pop 10 fills the
+// board row by row first, then players pop their own discs from the bottom,
+// keeping those that were part of a line and dropping the others back in.
+type connectFourRules struct {
+	rows    int
+	cols    int
+	connect int
+	popOut  bool
+	pop10   bool
+}
+
+var connectFourVariants = map[string]connectFourRules{
+	"standard":      {rows: 6, cols: 7, connect: 4},
+	"popout":        {rows: 6, cols: 7, connect: 4, popOut: true},
+	"pop10":         {rows: 6, cols: 7, connect: 4, pop10: true},
+	"five-in-a-row": {rows: 6, cols: 9, connect: 5},
 }
 
 type ConnectFour struct {
-	Board         [connectFourRows][connectFourCols]string `json:"board"`
-	CurrentTurn   int                                      `json:"currentTurn"`
-	Winner        string                                   `json:"winner"`
-	WinningCells  [][2]int                                 `json:"winningCells"`
+	Variant       string     `json:"variant"`
+	Rows          int        `json:"rows"`
+	Cols          int        `json:"cols"`
+	Connect       int        `json:"connect"`
+	Board         [][]string `json:"board"`
+	Phase         string     `json:"phase"`
+	Captured      [2]int     `json:"captured"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
+	WinningCells  [][2]int   `json:"winningCells"`
 	playerSymbols [2]string
-	moves         int
+	rules         connectFourRules
+	positions     map[string]int
 }
 
-func NewConnectFour() (Game, error) {
-	c := &ConnectFour{}
+// connectFourOptions picks a variant; rows, cols and connect override its
+// board size and the line length needed to win.
+type connectFourOptions struct {
+	Variant string `json:"variant"`
+	Rows    int    `json:"rows"`
+	Cols    int    `json:"cols"`
+	Connect int    `json:"connect"`
+}
+
+func NewConnectFour(options json.RawMessage) (Game, error) {
+	opts := connectFourOptions{Variant: "standard"}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+
+	rules, ok := connectFourVariants[opts.Variant]
+	if !ok {
+		return nil, fmt.Errorf("unknown connect four variant: %s", opts.Variant)
+	}
+	if opts.Rows != 0 {
+		rules.rows = opts.Rows
+	}
+	if opts.Cols != 0 {
+		rules.cols = opts.Cols
+	}
+	if opts.Connect != 0 {
+		rules.connect = opts.Connect
+	}
+
+	if rules.rows < connectFourMinSize || rules.rows > connectFourMaxSize ||
+		rules.cols < connectFourMinSize || rules.cols > connectFourMaxSize {
+		return nil, fmt.Errorf("connect four rows and cols must be between %d and %d", connectFourMinSize, connectFourMaxSize)
+	}
+	if rules.connect < connectFourMinConnect || rules.connect > max(rules.rows, rules.cols) {
+		return nil, fmt.Errorf("connect must be between %d and the longest side of the board", connectFourMinConnect)
+	}
+
+	c := &ConnectFour{
+		Variant: opts.Variant,
+		Rows:    rules.rows,
+		Cols:    rules.cols,
+		Connect: rules.connect,
+		rules:   rules,
+	}
 	c.Reset()
 	return c, nil
 }
@@ -48,33 +144,205 @@ func (c *ConnectFour) HandleMove(playerIndex int, move any) error {
 	}
 	col := int(colFloat)
 
-	if col < 0 || col >= connectFourCols {
+	if col < 0 || col >= c.Cols {
 		return fmt.Errorf("column out of bounds")
 	}
 
+	moveType, _ := moveData["type"].(string)
+	if moveType == "" {
+		moveType = "drop"
+	}
+
+	switch {
+	case c.Phase == "pop" && moveType == "pop":
+		return c.handlePop10(playerIndex, col, moveData)
+	case c.Phase == "pop":
+		return fmt.Errorf("discs can only be popped once the board is full")
+	case moveType == "pop" && c.rules.popOut:
+		return c.handlePopOut(playerIndex, col)
+	case moveType == "drop":
+		return c.handleDrop(playerIndex, col)
+	default:
+		return fmt.Errorf("invalid move type for the %s variant", c.Variant)
+	}
+}
+
+func (c *ConnectFour) handleDrop(playerIndex, col int) error {
 	row := c.getLowestEmptyRow(col)
 	if row == -1 {
 		return fmt.Errorf("column is full")
 	}
 
 	symbol := c.playerSymbols[playerIndex]
+
+	if c.Phase == "fill" {
+		if row != c.lowestIncompleteRow() {
+			return fmt.Errorf("the lowest row must be filled first")
+		}
+		c.Board[row][col] = symbol
+		if c.isBoardFull() {
+			c.Phase = "pop"
+		}
+		c.CurrentTurn = (c.CurrentTurn + 1) % 2
+		c.skipTurnWithoutPop()
+		return nil
+	}
+
 	c.Board[row][col] = symbol
-	c.moves++
 
 	if winningCells := c.checkWinner(row, col, symbol); winningCells != nil {
 		c.Winner = symbol
 		c.WinningCells = winningCells
-	} else if c.moves == connectFourRows*connectFourCols {
+	} else if c.isBoardFull() && !c.rules.popOut {
 		c.Winner = "draw"
 	}
 
 	c.CurrentTurn = (c.CurrentTurn + 1) % 2
+	c.checkPopOutEnd()
+
+	return nil
+}
+
+// handlePopOut removes the player's disc from the bottom of col. Popping can
+// complete lines for both players at once, in which case the popper wins.
+func (c *ConnectFour) handlePopOut(playerIndex, col int) error {
+	symbol := c.playerSymbols[playerIndex]
+	if c.Board[c.Rows-1][col] != symbol {
+		return fmt.Errorf("you can only pop your own disc from the bottom row")
+	}
+
+	c.popColumn(col)
+
+	opponent := c.playerSymbols[1-playerIndex]
+	if cells := c.findLine(symbol); cells != nil {
+		c.Winner = symbol
+		c.WinningCells = cells
+	} else if cells := c.findLine(opponent); cells != nil {
+		c.Winner = opponent
+		c.WinningCells = cells
+	}
+
+	c.CurrentTurn = (c.CurrentTurn + 1) % 2
+	c.checkPopOutEnd()
 
 	return nil
 }
 
+// handlePop10 pops the player's disc from the bottom of col. A disc that was
+// part of a line is kept and the player goes again; any other disc is
+// dropped back in through dropColumn, which must differ from col unless no
+// other column has room.
+func (c *ConnectFour) handlePop10(playerIndex, col int, moveData map[string]any) error {
+	symbol := c.playerSymbols[playerIndex]
+	bottom := c.Rows - 1
+	if c.Board[bottom][col] != symbol {
+		return fmt.Errorf("you can only pop your own disc from the bottom row")
+	}
+
+	if c.checkWinner(bottom, col, symbol) != nil {
+		c.popColumn(col)
+		c.Captured[playerIndex]++
+		if c.Captured[playerIndex] >= pop10Target {
+			c.Winner = symbol
+		}
+		c.skipTurnWithoutPop()
+		return nil
+	}
+
+	dropFloat, ok := moveData["dropColumn"].(float64)
+	if !ok {
+		return fmt.Errorf("dropColumn must be a number")
+	}
+	dropCol := int(dropFloat)
+	if dropCol < 0 || dropCol >= c.Cols {
+		return fmt.Errorf("dropColumn out of bounds")
+	}
+
+	otherColumnOpen := false
+	for other := range c.Cols {
+		if other != col && c.getLowestEmptyRow(other) != -1 {
+			otherColumnOpen = true
+		}
+	}
+	if dropCol == col && otherColumnOpen {
+		return fmt.Errorf("the disc must be dropped into a different column")
+	}
+	if dropCol != col && c.getLowestEmptyRow(dropCol) == -1 {
+		return fmt.Errorf("column is full")
+	}
+
+	c.popColumn(col)
+	c.Board[c.getLowestEmptyRow(dropCol)][dropCol] = symbol
+
+	c.CurrentTurn = (c.CurrentTurn + 1) % 2
+	c.skipTurnWithoutPop()
+
+	return nil
+}
+
+// popColumn removes the bottom disc of col and lets the rest fall down.
+func (c *ConnectFour) popColumn(col int) {
+	for row := c.Rows - 1; row > 0; row-- {
+		c.Board[row][col] = c.Board[row-1][col]
+	}
+	c.Board[0][col] = ""
+}
+
+// skipTurnWithoutPop passes the turn in the Pop 10 phase when the player to
+// move has no disc in the bottom row. If neither player can pop the game is
+// drawn.
+func (c *ConnectFour) skipTurnWithoutPop() {
+	if c.Phase != "pop" || c.IsGameOver() || c.hasBottomDisc(c.CurrentTurn) {
+		return
+	}
+	c.CurrentTurn = (c.CurrentTurn + 1) % 2
+	if !c.hasBottomDisc(c.CurrentTurn) {
+		c.Winner = "draw"
+	}
+}
+
+// checkPopOutEnd draws a PopOut game on the third repetition of a position,
+// or when the player to move has nowhere to drop and nothing to pop.
+func (c *ConnectFour) checkPopOutEnd() {
+	if !c.rules.popOut || c.IsGameOver() {
+		return
+	}
+
+	key := c.positionKey()
+	c.positions[key]++
+	if c.positions[key] >= 3 {
+		c.Winner = "draw"
+		return
+	}
+
+	if c.isBoardFull() && !c.hasBottomDisc(c.CurrentTurn) {
+		c.Winner = "draw"
+	}
+}
+
+func (c *ConnectFour) hasBottomDisc(playerIndex int) bool {
+	for col := range c.Cols {
+		if c.Board[c.Rows-1][col] == c.playerSymbols[playerIndex] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ConnectFour) positionKey() string {
+	var sb strings.Builder
+	for _, row := range c.Board {
+		for _, cell := range row {
+			sb.WriteString(cell)
+			sb.WriteByte(',')
+		}
+	}
+	fmt.Fprintf(&sb, "%d", c.CurrentTurn)
+	return sb.String()
+}
+
 func (c *ConnectFour) getLowestEmptyRow(col int) int {
-	for row := connectFourRows - 1; row >= 0; row-- {
+	for row := c.Rows - 1; row >= 0; row-- {
 		if c.Board[row][col] == "" {
 			return row
 		}
@@ -82,6 +350,37 @@ func (c *ConnectFour) getLowestEmptyRow(col int) int {
 	return -1
 }
 
+func (c *ConnectFour) lowestIncompleteRow() int {
+	for row := c.Rows - 1; row >= 0; row-- {
+		for col := range c.Cols {
+			if c.Board[row][col] == "" {
+				return row
+			}
+		}
+	}
+	return -1
+}
+
+func (c *ConnectFour) isBoardFull() bool {
+	return c.lowestIncompleteRow() == -1
+}
+
+// findLine scans the whole board for a line of symbol, which is needed once
+// pops can shift discs anywhere in a column.
+func (c *ConnectFour) findLine(symbol string) [][2]int {
+	for row := range c.Rows {
+		for col := range c.Cols {
+			if c.Board[row][col] != symbol {
+				continue
+			}
+			if cells := c.checkWinner(row, col, symbol); cells != nil {
+				return cells
+			}
+		}
+	}
+	return nil
+}
+
 func (c *ConnectFour) checkWinner(row, col int, symbol string) [][2]int {
 	directions := [][2]int{
 		{0, 1},
@@ -92,7 +391,7 @@ func (c *ConnectFour) checkWinner(row, col int, symbol string) [][2]int {
 
 	for _, dir := range directions {
 		cells := c.countInDirection(row, col, dir[0], dir[1], symbol)
-		if len(cells) >= 4 {
+		if len(cells) >= c.Connect {
 			return cells
 		}
 	}
@@ -102,9 +401,9 @@ func (c *ConnectFour) checkWinner(row, col int, symbol string) [][2]int {
 func (c *ConnectFour) countInDirection(row, col, dRow, dCol int, symbol string) [][2]int {
 	cells := [][2]int{{row, col}}
 
-	for i := 1; i < 4; i++ {
+	for i := 1; i < c.Connect; i++ {
 		r, co := row+dRow*i, col+dCol*i
-		if r < 0 || r >= connectFourRows || co < 0 || co >= connectFourCols {
+		if r < 0 || r >= c.Rows || co < 0 || co >= c.Cols {
 			break
 		}
 		if c.Board[r][co] != symbol {
@@ -113,9 +412,9 @@ func (c *ConnectFour) countInDirection(row, col, dRow, dCol int, symbol string)
 		cells = append(cells, [2]int{r, co})
 	}
 
-	for i := 1; i < 4; i++ {
+	for i := 1; i < c.Connect; i++ {
 		r, co := row-dRow*i, col-dCol*i
-		if r < 0 || r >= connectFourRows || co < 0 || co >= connectFourCols {
+		if r < 0 || r >= c.Rows || co < 0 || co >= c.Cols {
 			break
 		}
 		if c.Board[r][co] != symbol {
@@ -140,10 +439,213 @@ func (c *ConnectFour) GetWinner() string {
 }
 
 func (c *ConnectFour) Reset() {
-	c.Board = [connectFourRows][connectFourCols]string{}
+	c.Board = make([][]string, c.Rows)
+	for row := range c.Board {
+		c.Board[row] = make([]string, c.Cols)
+	}
+	c.Phase = "play"
+	if c.rules.pop10 {
+		c.Phase = "fill"
+	}
+	c.Captured = [2]int{}
 	c.CurrentTurn = 0
 	c.Winner = ""
 	c.WinningCells = nil
 	c.playerSymbols = [2]string{"R", "B"}
-	c.moves = 0
+	c.positions = map[string]int{}
+}
+
+type connectFourPrivate struct {
+	PlayerSymbols [2]string      `json:"playerSymbols"`
+	Positions     map[string]int `json:"positions"`
+}
+
+func (c *ConnectFour) Clone() Game {
+	cloned := *c
+	cloned.Board = cloneGrid(c.Board)
+	cloned.WinningCells = slices.Clone(c.WinningCells)
+	cloned.positions = maps.Clone(c.positions)
+	return &cloned
+}
+
+func (c *ConnectFour) MarshalState() ([]byte, error) {
+	return marshalState(c, connectFourPrivate{PlayerSymbols: c.playerSymbols, Positions: c.positions})
+}
+
+func (c *ConnectFour) UnmarshalState(data []byte) error {
+	var restored ConnectFour
+	var private connectFourPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	rules, ok := connectFourVariants[restored.Variant]
+	if !ok {
+		return fmt.Errorf("unknown connect four variant: %s", restored.Variant)
+	}
+	rules.rows, rules.cols, rules.connect = restored.Rows, restored.Cols, restored.Connect
+	restored.rules = rules
+	restored.playerSymbols = private.PlayerSymbols
+	restored.positions = private.Positions
+	if restored.positions == nil {
+		restored.positions = map[string]int{}
+	}
+	*c = restored
+	return nil
+}
+
+// afterSetup starts the repetition count from the set-up position and
+// judges it as if the last player to move had just moved. In PopOut that
+// move may have been a pop that completed the opponent's line. A full board
+// in the Pop 10 fill phase starts the popping.
+func (c *ConnectFour) afterSetup() {
+	c.positions = map[string]int{}
+
+	if c.rules.pop10 {
+		if c.isBoardFull() {
+			c.Phase = "pop"
+			c.skipTurnWithoutPop()
+		}
+		return
+	}
+
+	previous, next := c.playerSymbols[1-c.CurrentTurn], c.playerSymbols[c.CurrentTurn]
+	if cells := c.findLine(previous); cells != nil {
+		c.Winner = previous
+		c.WinningCells = cells
+	} else if cells := c.findLine(next); cells != nil {
+		c.Winner = next
+		c.WinningCells = cells
+	} else if c.isBoardFull() && !c.rules.popOut {
+		c.Winner = "draw"
+	}
+	c.checkPopOutEnd()
+}
+
+// ValidatePosition checks that every disc rests on the bottom or on another
+// disc. Set-up positions start without captured discs, and outside PopOut,
+// where discs also leave the board, the counts must fit the player to move,
+// who cannot have a line yet unless the board is still being filled.
+func (c *ConnectFour) ValidatePosition() error {
+	var counts [2]int
+	for row := range c.Board {
+		if err := checkPieces(c.Board[row], c.playerSymbols[:]...); err != nil {
+			return err
+		}
+		for col, cell := range c.Board[row] {
+			if cell != "" && row < c.Rows-1 && c.Board[row+1][col] == "" {
+				return fmt.Errorf("discs in column %d must rest on the ones below", col+1)
+			}
+		}
+		for i, symbol := range c.playerSymbols {
+			counts[i] += countPieces(c.Board[row], symbol)
+		}
+	}
+	if c.Captured != [2]int{} {
+		return fmt.Errorf("captured discs cannot be set in a position")
+	}
+	if c.rules.popOut {
+		return nil
+	}
+	if !c.rules.pop10 && c.findLine(c.playerSymbols[c.CurrentTurn]) != nil {
+		return fmt.Errorf("the player to move cannot have a line already")
+	}
+	return checkAlternation(counts, c.CurrentTurn)
+}
+
+func (c *ConnectFour) CurrentPlayer() int {
+	return c.CurrentTurn
+}
+
+// LegalMoves tries drops and pops in every column. In the Pop 10 phase a
+// disc that must be dropped back in is listed once per drop column.
+func (c *ConnectFour) LegalMoves() []any {
+	if c.IsGameOver() {
+		return nil
+	}
+	var candidates []any
+	for col := range c.Cols {
+		pop := map[string]any{"type": "pop", "column": float64(col)}
+		switch {
+		case c.Phase != "pop":
+			candidates = append(candidates, map[string]any{"column": float64(col)})
+			if c.rules.popOut {
+				candidates = append(candidates, pop)
+			}
+		case c.Clone().HandleMove(c.CurrentTurn, pop) == nil:
+			candidates = append(candidates, pop)
+		default:
+			for dropCol := range c.Cols {
+				candidates = append(candidates, map[string]any{"type": "pop", "column": float64(col), "dropColumn": float64(dropCol)})
+			}
+		}
+	}
+	return legalAmong(c, candidates)
+}
+
+func (c *ConnectFour) PlayerSymbol(playerIndex int) string {
+	return c.playerSymbols[playerIndex]
+}
+
+func (c *ConnectFour) PositionKey() string {
+	return stateKey(c)
+}
+
+// FormatMove numbers the columns from 1 on the left. A drop is just the
+// column and a pop is "p" and the column; a Pop 10 pop whose disc goes back
+// in names the drop column after a slash, as in "p3/5".
+func (c *ConnectFour) FormatMove(move any) (string, error) {
+	col, err := moveField(move, "column")
+	if err != nil {
+		return "", err
+	}
+	kind, err := moveType(move)
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case "", "drop":
+		return strconv.Itoa(col + 1), nil
+	case "pop":
+		if dropCol, err := moveField(move, "dropColumn"); err == nil {
+			return fmt.Sprintf("p%d/%d", col+1, dropCol+1), nil
+		}
+		return fmt.Sprintf("p%d", col+1), nil
+	default:
+		return "", fmt.Errorf("invalid move type: %s", kind)
+	}
+}
+
+func (c *ConnectFour) ParseMove(text string) (any, error) {
+	body, pop := strings.CutPrefix(strings.ToLower(text), "p")
+	colText, dropText, hasDrop := strings.Cut(body, "/")
+
+	col, err := c.parseColumn(colText)
+	if err != nil {
+		return nil, err
+	}
+	if !pop {
+		if hasDrop {
+			return nil, fmt.Errorf("%q is not a move such as 4 or p4", text)
+		}
+		return map[string]any{"column": float64(col)}, nil
+	}
+
+	move := map[string]any{"type": "pop", "column": float64(col)}
+	if hasDrop {
+		dropCol, err := c.parseColumn(dropText)
+		if err != nil {
+			return nil, err
+		}
+		move["dropColumn"] = float64(dropCol)
+	}
+	return move, nil
+}
+
+func (c *ConnectFour) parseColumn(text string) (int, error) {
+	col, err := strconv.Atoi(text)
+	if err != nil || col < 1 || col > c.Cols {
+		return 0, fmt.Errorf("%q is not a column from 1 to %d", text, c.Cols)
+	}
+	return col - 1, nil
 }
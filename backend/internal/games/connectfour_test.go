@@ -0,0 +1,177 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeConnectFourMovePayload(column int) any {
+	return map[string]any{"column": float64(column)}
+}
+
+func makeConnectFourPopPayload(column int) any {
+	return map[string]any{"type": "pop", "column": float64(column)}
+}
+
+func newTestConnectFour(t *testing.T, options string) *ConnectFour {
+	t.Helper()
+	game, err := NewConnectFour(json.RawMessage(options))
+	if err != nil {
+		t.Fatalf("Expected no error creating connect four game, but got %v", err)
+	}
+	return game.(*ConnectFour)
+}
+
+func TestConnectFour_Options(t *testing.T) {
+	tests := []struct {
+		name                string
+		options             string
+		wantErr             bool
+		rows, cols, connect int
+	}{
+		{"default", "", false, 6, 7, 4},
+		{"five in a row", `{"variant": "five-in-a-row"}`, false, 6, 9, 5},
+		{"connect three", `{"rows": 5, "cols": 5, "connect": 3}`, false, 5, 5, 3},
+		{"unknown variant", `{"variant": "pop100"}`, true, 0, 0, 0},
+		{"line longer than the board", `{"connect": 8}`, true, 0, 0, 0},
+		{"board too small", `{"rows": 3}`, true, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewConnectFour(json.RawMessage(tt.options))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error for invalid options, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			c := game.(*ConnectFour)
+			if len(c.Board) != tt.rows || len(c.Board[0]) != tt.cols || c.Connect != tt.connect {
+				t.Errorf("Expected a %dx%d board with connect %d, but got %dx%d with %d", tt.rows, tt.cols, tt.connect, len(c.Board), len(c.Board[0]), c.Connect)
+			}
+		})
+	}
+}
+
+func TestConnectFour_WinLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		columns []int
+		want    string
+	}{
+		{"four wins the standard game", "", []int{0, 1, 0, 1, 0, 1, 0}, "R"},
+		{"four is not enough for five in a row", `{"variant": "five-in-a-row"}`, []int{0, 1, 2, 3, 4, 5, 6, 7, 0, 1, 2, 3}, ""},
+		{"five wins five in a row", `{"variant": "five-in-a-row"}`, []int{0, 0, 1, 1, 2, 2, 3, 3, 4}, "R"},
+		{"three wins connect three", `{"connect": 3}`, []int{0, 6, 1, 6, 2}, "R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestConnectFour(t, tt.options)
+			for _, column := range tt.columns {
+				if err := c.HandleMove(c.CurrentTurn, makeConnectFourMovePayload(column)); err != nil {
+					t.Fatalf("Expected no error dropping into column %d, but got %v", column, err)
+				}
+			}
+			if winner := c.GetWinner(); winner != tt.want {
+				t.Errorf("Expected winner to be '%s', but got '%s'", tt.want, winner)
+			}
+		})
+	}
+}
+
+func TestConnectFour_PopOut(t *testing.T) {
+	standard := newTestConnectFour(t, "")
+	standard.HandleMove(0, makeConnectFourMovePayload(0))
+	if err := standard.HandleMove(1, makeConnectFourPopPayload(0)); err == nil {
+		t.Fatal("Expected an error for popping in the standard variant, but got nil")
+	}
+
+	c := newTestConnectFour(t, `{"variant": "popout"}`)
+	for _, column := range []int{0, 0} {
+		if err := c.HandleMove(c.CurrentTurn, makeConnectFourMovePayload(column)); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	}
+
+	if err := c.HandleMove(0, makeConnectFourPopPayload(1)); err == nil {
+		t.Fatal("Expected an error for popping an empty column, but got nil")
+	}
+	if err := c.HandleMove(0, makeConnectFourPopPayload(0)); err != nil {
+		t.Fatalf("Expected no error popping own disc, but got %v", err)
+	}
+	if c.Board[5][0] != "B" || c.Board[4][0] != "" {
+		t.Errorf("Expected B's disc to fall to the bottom, but got '%s'", c.Board[5][0])
+	}
+	if err := c.HandleMove(1, makeConnectFourPopPayload(0)); err != nil {
+		t.Fatalf("Expected no error popping own disc, but got %v", err)
+	}
+	if err := c.HandleMove(0, makeConnectFourPopPayload(0)); err == nil {
+		t.Fatal("Expected an error for popping from an empty column, but got nil")
+	}
+}
+
+func TestConnectFour_PopOutCompletesLine(t *testing.T) {
+	c := newTestConnectFour(t, `{"variant": "popout"}`)
+	c.Board[5][0] = "R"
+	c.Board[4][0] = "B"
+	for col := 1; col <= 3; col++ {
+		c.Board[5][col] = "B"
+		c.Board[4][col] = "R"
+	}
+
+	if err := c.HandleMove(0, makeConnectFourPopPayload(0)); err != nil {
+		t.Fatalf("Expected no error popping own disc, but got %v", err)
+	}
+	if c.GetWinner() != "B" {
+		t.Errorf("Expected the pop to complete B's line, but got winner '%s'", c.GetWinner())
+	}
+}
+
+func TestConnectFour_Pop10(t *testing.T) {
+	c := newTestConnectFour(t, `{"variant": "pop10"}`)
+
+	if c.Phase != "fill" {
+		t.Fatalf("Expected the fill phase, but got %s", c.Phase)
+	}
+	if err := c.HandleMove(0, makeConnectFourMovePayload(0)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := c.HandleMove(1, makeConnectFourMovePayload(0)); err == nil {
+		t.Fatal("Expected an error for skipping an incomplete bottom row, but got nil")
+	}
+
+	c = newTestConnectFour(t, `{"variant": "pop10"}`)
+	c.Phase = "pop"
+	for col, symbol := range []string{"R", "R", "R", "R", "B", "B", "R"} {
+		c.Board[5][col] = symbol
+	}
+
+	if err := c.HandleMove(0, makeConnectFourPopPayload(0)); err != nil {
+		t.Fatalf("Expected no error popping a disc from a line, but got %v", err)
+	}
+	if c.Captured[0] != 1 || c.CurrentTurn != 0 {
+		t.Errorf("Expected R to keep the disc and move again, but got %d captured and turn %d", c.Captured[0], c.CurrentTurn)
+	}
+
+	if err := c.HandleMove(0, makeConnectFourPopPayload(6)); err == nil {
+		t.Fatal("Expected an error for a missing dropColumn, but got nil")
+	}
+	move := map[string]any{"type": "pop", "column": float64(6), "dropColumn": float64(6)}
+	if err := c.HandleMove(0, move); err == nil {
+		t.Fatal("Expected an error for dropping back into the same column, but got nil")
+	}
+	move["dropColumn"] = float64(4)
+	if err := c.HandleMove(0, move); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if c.Board[5][6] != "" || c.Board[4][4] != "R" || c.CurrentTurn != 1 {
+		t.Error("Expected R's disc to move from column 6 to column 4 and the turn to pass")
+	}
+}
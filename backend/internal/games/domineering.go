@@ -1,23 +1,69 @@
 package games
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+)
+
+const (
+	domineeringDefaultSize = 8
+	domineeringMinSize     = 2
+	domineeringMaxSize     = 8
 )
 
 func init() {
-	RegisterGame("domineering", NewDomineering)
+	RegisterGameWithOptions("domineering", NewDomineering)
+	RegisterMetadata("domineering", domineeringMetadata)
+}
+
+var domineeringMetadata = Metadata{
+	DisplayName: "Domineering",
+	Description: "A strategic blocking game where one player places horizontal dominoes and the other vertical, competing for space on the grid. The player who can no longer make a valid move loses.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "rows", Label: "Rows", Type: OptionInt, Default: domineeringDefaultSize, Range: &Range{Min: domineeringMinSize, Max: domineeringMaxSize}},
+		{Name: "cols", Label: "Columns", Type: OptionInt, Default: domineeringDefaultSize, Range: &Range{Min: domineeringMinSize, Max: domineeringMaxSize}},
+	},
+	TimeControl: quickTimeControl,
+	Rules: []string{
+		"Horizontal player can only place dominoes horizontally",
+		"Vertical player can only place dominoes vertically",
+		"Dominoes must occupy exactly 2 adjacent empty squares",
+		"You lose if you cannot make a valid move on your turn",
+	},
 }
 
+// Domineering is played on a Rows x Cols board, 8x8 by default. "H" places
+// horizontal dominoes and "V" vertical ones; whoever cannot place one loses.
+// A move names the domino's first cell, or its last one on the far edge.
 type Domineering struct {
-	Board         [8][8]string `json:"board"`
-	CurrentTurn   int          `json:"currentTurn"`
-	Winner        string       `json:"winner"`
+	Rows          int        `json:"rows"`
+	Cols          int        `json:"cols"`
+	Board         [][]string `json:"board"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
 	playerSymbols [2]string
 	moves         int
 }
 
-func NewDomineering() (Game, error) {
-	d := &Domineering{}
+type domineeringOptions struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+}
+
+func NewDomineering(options json.RawMessage) (Game, error) {
+	opts := domineeringOptions{Rows: domineeringDefaultSize, Cols: domineeringDefaultSize}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Rows < domineeringMinSize || opts.Rows > domineeringMaxSize ||
+		opts.Cols < domineeringMinSize || opts.Cols > domineeringMaxSize {
+		return nil, fmt.Errorf("domineering boards must be between %d and %d cells on each side", domineeringMinSize, domineeringMaxSize)
+	}
+
+	d := &Domineering{Rows: opts.Rows, Cols: opts.Cols}
 	d.Reset()
 	return d, nil
 }
@@ -51,14 +97,14 @@ func (d *Domineering) HandleMove(playerIndex int, move any) error {
 	row := int(rowFloat)
 	col := int(colFloat)
 
-	if row < 0 || row > 7 || col < 0 || col > 7 {
+	if row < 0 || row >= d.Rows || col < 0 || col >= d.Cols {
 		return fmt.Errorf("out of bounds")
 	}
 
 	symbol := d.playerSymbols[playerIndex]
 
 	if symbol == "H" {
-		if col == 7 {
+		if col == d.Cols-1 {
 			if d.Board[row][col] != "" || d.Board[row][col-1] != "" {
 				return fmt.Errorf("cell is already occupied")
 			}
@@ -72,7 +118,7 @@ func (d *Domineering) HandleMove(playerIndex int, move any) error {
 			d.Board[row][col+1] = symbol
 		}
 	} else if symbol == "V" {
-		if row == 7 {
+		if row == d.Rows-1 {
 			if d.Board[row][col] != "" || d.Board[row-1][col] != "" {
 				return fmt.Errorf("cell is already occupied")
 			}
@@ -111,11 +157,9 @@ func (d *Domineering) GetWinner() string {
 }
 
 func (d *Domineering) Reset() {
-	d.Board = [8][8]string{}
+	d.Board = make([][]string, d.Rows)
 	for i := range d.Board {
-		for j := range d.Board[i] {
-			d.Board[i][j] = ""
-		}
+		d.Board[i] = make([]string, d.Cols)
 	}
 	d.CurrentTurn = 0
 	d.Winner = ""
@@ -136,11 +180,11 @@ func (d *Domineering) hasValidMoves(symbol string) bool {
 	for r := range d.Board {
 		for c := range d.Board[r] {
 			if symbol == "H" {
-				if c+1 < 8 && d.Board[r][c] == "" && d.Board[r][c+1] == "" {
+				if c+1 < d.Cols && d.Board[r][c] == "" && d.Board[r][c+1] == "" {
 					return true
 				}
 			} else {
-				if r+1 < 8 && d.Board[r][c] == "" && d.Board[r+1][c] == "" {
+				if r+1 < d.Rows && d.Board[r][c] == "" && d.Board[r+1][c] == "" {
 					return true
 				}
 			}
@@ -148,3 +192,149 @@ func (d *Domineering) hasValidMoves(symbol string) bool {
 	}
 	return false
 }
+
+type domineeringPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+	Moves         int       `json:"moves"`
+}
+
+func (d *Domineering) Clone() Game {
+	cloned := *d
+	cloned.Board = cloneGrid(d.Board)
+	return &cloned
+}
+
+func (d *Domineering) MarshalState() ([]byte, error) {
+	return marshalState(d, domineeringPrivate{PlayerSymbols: d.playerSymbols, Moves: d.moves})
+}
+
+func (d *Domineering) UnmarshalState(data []byte) error {
+	var restored Domineering
+	var private domineeringPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.moves = private.Moves
+	*d = restored
+	return nil
+}
+
+// ValidatePosition checks that the marked cells split into whole dominoes:
+// every run of horizontal cells along a row, and of vertical cells down a
+// column, must have an even length.
+// afterSetup recounts the dominoes and ends the game if the player to move
+// has no room left for one.
+func (d *Domineering) afterSetup() {
+	cells := slices.Concat(d.Board...)
+	d.moves = (countPieces(cells, d.playerSymbols[0]) + countPieces(cells, d.playerSymbols[1])) / 2
+
+	previous := d.playerSymbols[1-d.CurrentTurn]
+	if d.checkWinner(previous) {
+		d.Winner = previous
+	}
+}
+
+func (d *Domineering) ValidatePosition() error {
+	cells := slices.Concat(d.Board...)
+	if err := checkPieces(cells, d.playerSymbols[:]...); err != nil {
+		return err
+	}
+
+	for r := range d.Rows {
+		run := 0
+		for c := range d.Cols + 1 {
+			if c < d.Cols && d.Board[r][c] == d.playerSymbols[0] {
+				run++
+				continue
+			}
+			if run%2 != 0 {
+				return fmt.Errorf("horizontal dominoes in row %d do not pair up", r+1)
+			}
+			run = 0
+		}
+	}
+	for c := range d.Cols {
+		run := 0
+		for r := range d.Rows + 1 {
+			if r < d.Rows && d.Board[r][c] == d.playerSymbols[1] {
+				run++
+				continue
+			}
+			if run%2 != 0 {
+				return fmt.Errorf("vertical dominoes in column %d do not pair up", c+1)
+			}
+			run = 0
+		}
+	}
+
+	return checkAlternation([2]int{
+		countPieces(cells, d.playerSymbols[0]) / 2,
+		countPieces(cells, d.playerSymbols[1]) / 2,
+	}, d.CurrentTurn)
+}
+
+func (d *Domineering) CurrentPlayer() int {
+	return d.CurrentTurn
+}
+
+// LegalMoves names every domino by its first cell.
+func (d *Domineering) LegalMoves() []any {
+	if d.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	horizontal := d.playerSymbols[d.CurrentTurn] == "H"
+	for r := range d.Board {
+		for c := range d.Board[r] {
+			if d.Board[r][c] != "" {
+				continue
+			}
+			if horizontal && c+1 < d.Cols && d.Board[r][c+1] == "" ||
+				!horizontal && r+1 < d.Rows && d.Board[r+1][c] == "" {
+				moves = append(moves, map[string]any{"row": float64(r), "col": float64(c)})
+			}
+		}
+	}
+	return moves
+}
+
+func (d *Domineering) PlayerSymbol(playerIndex int) string {
+	return d.playerSymbols[playerIndex]
+}
+
+func (d *Domineering) PositionKey() string {
+	key := make([]byte, 0, d.Rows*d.Cols+1)
+	for _, row := range d.Board {
+		for _, cell := range row {
+			if cell == "" {
+				key = append(key, '.')
+			} else {
+				key = append(key, cell...)
+			}
+		}
+	}
+	return string(append(key, byte('0'+d.CurrentTurn)))
+}
+
+// FormatMove names the cell the domino is placed from by column letter and
+// row number from the top-left corner, as in "c2".
+func (d *Domineering) FormatMove(move any) (string, error) {
+	row, err := moveField(move, "row")
+	if err != nil {
+		return "", err
+	}
+	col, err := moveField(move, "col")
+	if err != nil {
+		return "", err
+	}
+	return cellName(row, col), nil
+}
+
+func (d *Domineering) ParseMove(text string) (any, error) {
+	row, col, err := parseCellName(text)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"row": float64(row), "col": float64(col)}, nil
+}
@@ -9,7 +9,7 @@ func makeDomineeringMovePayload(col int, row int) any {
 }
 
 func TestDomineering_HandleMove_ValidMove(t *testing.T) {
-	game, _ := NewDomineering()
+	game, _ := NewDomineering(nil)
 
 	err := game.HandleMove(0, makeDomineeringMovePayload(0, 0))
 	if err != nil {
@@ -39,7 +39,7 @@ func TestDomineering_HandleMove_ValidMove(t *testing.T) {
 }
 
 func TestDomineering_HandleMove_OccupiedCell(t *testing.T) {
-	game, _ := NewDomineering()
+	game, _ := NewDomineering(nil)
 
 	game.HandleMove(0, makeDomineeringMovePayload(0, 0))
 	err := game.HandleMove(1, makeDomineeringMovePayload(0, 0))
@@ -55,7 +55,7 @@ func TestDomineering_HandleMove_OccupiedCell(t *testing.T) {
 }
 
 func TestDomineering_HandleMove_NotYourTurn(t *testing.T) {
-	game, _ := NewDomineering()
+	game, _ := NewDomineering(nil)
 
 	game.HandleMove(0, makeDomineeringMovePayload(0, 0))
 	err := game.HandleMove(0, makeDomineeringMovePayload(1, 1))
@@ -71,7 +71,7 @@ func TestDomineering_HandleMove_NotYourTurn(t *testing.T) {
 }
 
 func TestDomineering_WinCondition(t *testing.T) {
-	game, _ := NewDomineering()
+	game, _ := NewDomineering(nil)
 	d := game.GetGameState().(*Domineering)
 
 	for r := 0; r < 8; r++ {
@@ -1,26 +1,96 @@
 package games
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	dotsAndBoxesDefaultSize = 4
+	dotsAndBoxesMinSize     = 2
+	dotsAndBoxesMaxSize     = 10
+	dotsAndBoxesLongChain   = 3
 )
 
 func init() {
-	RegisterGame("dots-and-boxes", NewDotsAndBoxes)
+	RegisterGameWithOptions("dots-and-boxes", NewDotsAndBoxes)
+	RegisterMetadata("dots-and-boxes", dotsAndBoxesMetadata)
+}
+
+var dotsAndBoxesMetadata = Metadata{
+	DisplayName: "Dots & Boxes",
+	Description: "Connect dots to form boxes and claim them. The player who completes the most boxes wins!",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "rows", Label: "Rows of boxes", Type: OptionInt, Default: dotsAndBoxesDefaultSize, Range: &Range{Min: dotsAndBoxesMinSize, Max: dotsAndBoxesMaxSize}},
+		{Name: "cols", Label: "Columns of boxes", Type: OptionInt, Default: dotsAndBoxesDefaultSize, Range: &Range{Min: dotsAndBoxesMinSize, Max: dotsAndBoxesMaxSize}},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"You must draw a line between two adjacent dots",
+		"You cannot draw a line that already exists",
+		"Completing a box earns you another turn",
+		"Most boxes claimed wins the game",
+	},
 }
 
+// DotsAndBoxes is played on a grid of Rows x Cols boxes. HLines has Rows+1
+// rows of Cols lines and VLines has Rows rows of Cols+1 lines.
+//
+// Chains and Strings describe the same position in its Strings-and-Coins
+// dual, where every box is a coin and every undrawn line is a string tying
+// two coins together, or a coin to the ground at the edge of the board.
 type DotsAndBoxes struct {
-	HLines         [5][4]string `json:"hLines"`
-	VLines         [4][5]string `json:"vLines"`
-	Boxes          [4][4]string `json:"boxes"`
-	CurrentTurn    int          `json:"currentTurn"`
-	Winner         string       `json:"winner"`
-	BoxesCompleted int          `json:"boxesCompleted"`
-	Scores         [2]int       `json:"scores"`
+	Rows           int                 `json:"rows"`
+	Cols           int                 `json:"cols"`
+	HLines         [][]string          `json:"hLines"`
+	VLines         [][]string          `json:"vLines"`
+	Boxes          [][]string          `json:"boxes"`
+	CurrentTurn    int                 `json:"currentTurn"`
+	Winner         string              `json:"winner"`
+	BoxesCompleted int                 `json:"boxesCompleted"`
+	Scores         [2]int              `json:"scores"`
+	Chains         []DotsAndBoxesChain `json:"chains"`
+	Strings        []CoinString        `json:"strings"`
 	playerSymbols  [2]string
 }
 
-func NewDotsAndBoxes() (Game, error) {
-	d := &DotsAndBoxes{}
+// DotsAndBoxesChain is a long chain or loop of unclaimed boxes, each with at
+// most two undrawn sides. Boxes are listed in order along the chain.
+type DotsAndBoxesChain struct {
+	Boxes [][2]int `json:"boxes"`
+	Loop  bool     `json:"loop"`
+}
+
+// CoinString is an undrawn line seen as a string between two coins. Coins
+// are box indices (row*Cols+col) and -1 stands for the ground.
+type CoinString struct {
+	Type  string `json:"type"`
+	Row   int    `json:"row"`
+	Col   int    `json:"col"`
+	Coins [2]int `json:"coins"`
+}
+
+type dotsAndBoxesOptions struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+}
+
+func NewDotsAndBoxes(options json.RawMessage) (Game, error) {
+	opts := dotsAndBoxesOptions{Rows: dotsAndBoxesDefaultSize, Cols: dotsAndBoxesDefaultSize}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Rows < dotsAndBoxesMinSize || opts.Rows > dotsAndBoxesMaxSize ||
+		opts.Cols < dotsAndBoxesMinSize || opts.Cols > dotsAndBoxesMaxSize {
+		return nil, fmt.Errorf("dots and boxes rows and cols must be between %d and %d", dotsAndBoxesMinSize, dotsAndBoxesMaxSize)
+	}
+
+	d := &DotsAndBoxes{Rows: opts.Rows, Cols: opts.Cols}
 	d.Reset()
 	return d, nil
 }
@@ -61,7 +131,7 @@ func (d *DotsAndBoxes) HandleMove(playerIndex int, move any) error {
 
 	switch lineType {
 	case "h":
-		if row < 0 || row > 4 || col < 0 || col > 3 {
+		if row < 0 || row > d.Rows || col < 0 || col >= d.Cols {
 			return fmt.Errorf("horizontal line out of bounds")
 		}
 		if d.HLines[row][col] != "" {
@@ -70,7 +140,7 @@ func (d *DotsAndBoxes) HandleMove(playerIndex int, move any) error {
 		d.HLines[row][col] = symbol
 
 	case "v":
-		if row < 0 || row > 3 || col < 0 || col > 4 {
+		if row < 0 || row >= d.Rows || col < 0 || col > d.Cols {
 			return fmt.Errorf("vertical line out of bounds")
 		}
 		if d.VLines[row][col] != "" {
@@ -95,6 +165,9 @@ func (d *DotsAndBoxes) HandleMove(playerIndex int, move any) error {
 		d.determineWinner()
 	}
 
+	d.Strings = d.coinStrings()
+	d.Chains = d.findChains()
+
 	return nil
 }
 
@@ -106,7 +179,7 @@ func (d *DotsAndBoxes) checkNewBoxes(lineType string, row, col int, symbol strin
 			d.Boxes[row-1][col] = symbol
 			completed++
 		}
-		if row < 4 && d.isBoxComplete(row, col) && d.Boxes[row][col] == "" {
+		if row < d.Rows && d.isBoxComplete(row, col) && d.Boxes[row][col] == "" {
 			d.Boxes[row][col] = symbol
 			completed++
 		}
@@ -115,7 +188,7 @@ func (d *DotsAndBoxes) checkNewBoxes(lineType string, row, col int, symbol strin
 			d.Boxes[row][col-1] = symbol
 			completed++
 		}
-		if col < 4 && d.isBoxComplete(row, col) && d.Boxes[row][col] == "" {
+		if col < d.Cols && d.isBoxComplete(row, col) && d.Boxes[row][col] == "" {
 			d.Boxes[row][col] = symbol
 			completed++
 		}
@@ -125,7 +198,7 @@ func (d *DotsAndBoxes) checkNewBoxes(lineType string, row, col int, symbol strin
 }
 
 func (d *DotsAndBoxes) isBoxComplete(row, col int) bool {
-	if row < 0 || row > 3 || col < 0 || col > 3 {
+	if row < 0 || row >= d.Rows || col < 0 || col >= d.Cols {
 		return false
 	}
 	return d.HLines[row][col] != "" &&
@@ -135,8 +208,8 @@ func (d *DotsAndBoxes) isBoxComplete(row, col int) bool {
 }
 
 func (d *DotsAndBoxes) checkGameOver() bool {
-	for i := range 4 {
-		for j := range 4 {
+	for i := range d.Rows {
+		for j := range d.Cols {
 			if d.Boxes[i][j] == "" {
 				return false
 			}
@@ -155,6 +228,95 @@ func (d *DotsAndBoxes) determineWinner() {
 	}
 }
 
+// coinStrings lists every undrawn line as a string of the dual graph.
+func (d *DotsAndBoxes) coinStrings() []CoinString {
+	coin := func(row, col int) int {
+		if row < 0 || row >= d.Rows || col < 0 || col >= d.Cols {
+			return -1
+		}
+		return row*d.Cols + col
+	}
+
+	strings := []CoinString{}
+	for r := range d.HLines {
+		for c := range d.HLines[r] {
+			if d.HLines[r][c] == "" {
+				strings = append(strings, CoinString{Type: "h", Row: r, Col: c, Coins: [2]int{coin(r-1, c), coin(r, c)}})
+			}
+		}
+	}
+	for r := range d.VLines {
+		for c := range d.VLines[r] {
+			if d.VLines[r][c] == "" {
+				strings = append(strings, CoinString{Type: "v", Row: r, Col: c, Coins: [2]int{coin(r, c-1), coin(r, c)}})
+			}
+		}
+	}
+	return strings
+}
+
+// findChains walks the coins that have one or two strings left. Those coins
+// can only link up into simple paths and cycles; paths of at least three
+// coins are reported as long chains and every cycle as a loop.
+func (d *DotsAndBoxes) findChains() []DotsAndBoxesChain {
+	neighbours := make([][]int, d.Rows*d.Cols)
+	for _, s := range d.Strings {
+		for i, coin := range s.Coins {
+			if coin >= 0 {
+				neighbours[coin] = append(neighbours[coin], s.Coins[1-i])
+			}
+		}
+	}
+
+	inChain := func(coin int) bool {
+		return coin >= 0 && (len(neighbours[coin]) == 1 || len(neighbours[coin]) == 2)
+	}
+	linked := func(coin int) int {
+		count := 0
+		for _, n := range neighbours[coin] {
+			if inChain(n) {
+				count++
+			}
+		}
+		return count
+	}
+
+	visited := make([]bool, len(neighbours))
+	walk := func(start int, loop bool) DotsAndBoxesChain {
+		chain := DotsAndBoxesChain{Loop: loop}
+		for coin := start; coin >= 0; {
+			visited[coin] = true
+			chain.Boxes = append(chain.Boxes, [2]int{coin / d.Cols, coin % d.Cols})
+			next := -1
+			for _, n := range neighbours[coin] {
+				if inChain(n) && !visited[n] {
+					next = n
+					break
+				}
+			}
+			coin = next
+		}
+		return chain
+	}
+
+	chains := []DotsAndBoxesChain{}
+	// Walking every chain from one of its ends first leaves only loops.
+	for coin := range neighbours {
+		if inChain(coin) && !visited[coin] && linked(coin) < 2 {
+			if chain := walk(coin, false); len(chain.Boxes) >= dotsAndBoxesLongChain {
+				chains = append(chains, chain)
+			}
+		}
+	}
+	for coin := range neighbours {
+		if inChain(coin) && !visited[coin] {
+			chains = append(chains, walk(coin, true))
+		}
+	}
+
+	return chains
+}
+
 func (d *DotsAndBoxes) GetGameState() any {
 	return d
 }
@@ -168,12 +330,160 @@ func (d *DotsAndBoxes) GetWinner() string {
 }
 
 func (d *DotsAndBoxes) Reset() {
-	d.HLines = [5][4]string{}
-	d.VLines = [4][5]string{}
-	d.Boxes = [4][4]string{}
+	d.HLines = make([][]string, d.Rows+1)
+	for i := range d.HLines {
+		d.HLines[i] = make([]string, d.Cols)
+	}
+	d.VLines = make([][]string, d.Rows)
+	d.Boxes = make([][]string, d.Rows)
+	for i := range d.Rows {
+		d.VLines[i] = make([]string, d.Cols+1)
+		d.Boxes[i] = make([]string, d.Cols)
+	}
 	d.CurrentTurn = 0
 	d.Winner = ""
 	d.BoxesCompleted = 0
 	d.Scores = [2]int{}
 	d.playerSymbols = [2]string{"P1", "P2"}
+	d.Strings = d.coinStrings()
+	d.Chains = d.findChains()
+}
+
+type dotsAndBoxesPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+}
+
+func (d *DotsAndBoxes) Clone() Game {
+	cloned := *d
+	cloned.HLines = cloneGrid(d.HLines)
+	cloned.VLines = cloneGrid(d.VLines)
+	cloned.Boxes = cloneGrid(d.Boxes)
+	cloned.Strings = slices.Clone(d.Strings)
+	cloned.Chains = make([]DotsAndBoxesChain, len(d.Chains))
+	for i, chain := range d.Chains {
+		cloned.Chains[i] = DotsAndBoxesChain{Boxes: slices.Clone(chain.Boxes), Loop: chain.Loop}
+	}
+	return &cloned
+}
+
+func (d *DotsAndBoxes) MarshalState() ([]byte, error) {
+	return marshalState(d, dotsAndBoxesPrivate{PlayerSymbols: d.playerSymbols})
+}
+
+func (d *DotsAndBoxes) UnmarshalState(data []byte) error {
+	var restored DotsAndBoxes
+	var private dotsAndBoxesPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	*d = restored
+	return nil
+}
+
+// afterSetup recounts the scores from the claimed boxes, rebuilds the
+// chain and strings views and ends the game once every box is claimed.
+func (d *DotsAndBoxes) afterSetup() {
+	d.Scores = [2]int{}
+	for _, row := range d.Boxes {
+		for _, box := range row {
+			for i, symbol := range d.playerSymbols {
+				if box == symbol {
+					d.Scores[i]++
+				}
+			}
+		}
+	}
+	d.BoxesCompleted = d.Scores[0] + d.Scores[1]
+	d.Strings = d.coinStrings()
+	d.Chains = d.findChains()
+	if d.checkGameOver() {
+		d.determineWinner()
+	}
+}
+
+// ValidatePosition checks that exactly the boxes with all four sides drawn
+// are claimed.
+func (d *DotsAndBoxes) ValidatePosition() error {
+	for _, grid := range [][][]string{d.HLines, d.VLines, d.Boxes} {
+		if err := checkPieces(slices.Concat(grid...), d.playerSymbols[:]...); err != nil {
+			return err
+		}
+	}
+	for row := range d.Rows {
+		for col := range d.Cols {
+			if (d.Boxes[row][col] != "") != d.isBoxComplete(row, col) {
+				return fmt.Errorf("box %d,%d must be claimed exactly when all its sides are drawn", row+1, col+1)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *DotsAndBoxes) CurrentPlayer() int {
+	return d.CurrentTurn
+}
+
+func (d *DotsAndBoxes) LegalMoves() []any {
+	if d.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	for _, lineType := range []string{"h", "v"} {
+		lines := d.HLines
+		if lineType == "v" {
+			lines = d.VLines
+		}
+		for r := range lines {
+			for c := range lines[r] {
+				if lines[r][c] == "" {
+					moves = append(moves, map[string]any{"type": lineType, "row": float64(r), "col": float64(c)})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (d *DotsAndBoxes) PlayerSymbol(playerIndex int) string {
+	return d.playerSymbols[playerIndex]
+}
+
+func (d *DotsAndBoxes) PositionKey() string {
+	return stateKey(d)
+}
+
+// FormatMove writes "h" or "v" followed by the line's row and column counted
+// from 1, as in "h1,2" for the second line along the top edge.
+func (d *DotsAndBoxes) FormatMove(move any) (string, error) {
+	lineType, err := moveType(move)
+	if err != nil {
+		return "", err
+	}
+	if lineType != "h" && lineType != "v" {
+		return "", fmt.Errorf("type must be 'h' or 'v'")
+	}
+	row, err := moveField(move, "row")
+	if err != nil {
+		return "", err
+	}
+	col, err := moveField(move, "col")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%d,%d", lineType, row+1, col+1), nil
+}
+
+func (d *DotsAndBoxes) ParseMove(text string) (any, error) {
+	text = strings.ToLower(text)
+	if len(text) < 4 || (text[0] != 'h' && text[0] != 'v') {
+		return nil, fmt.Errorf("%q is not a line such as h1,2", text)
+	}
+	rowText, colText, _ := strings.Cut(text[1:], ",")
+	row, rowErr := strconv.Atoi(rowText)
+	col, colErr := strconv.Atoi(colText)
+	if rowErr != nil || colErr != nil || row < 1 || col < 1 {
+		return nil, fmt.Errorf("%q is not a line such as h1,2", text)
+	}
+	return map[string]any{"type": text[:1], "row": float64(row - 1), "col": float64(col - 1)}, nil
 }
@@ -0,0 +1,149 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeDotsAndBoxesMovePayload(lineType string, row int, col int) any {
+	return map[string]any{"type": lineType, "row": float64(row), "col": float64(col)}
+}
+
+func newTestDotsAndBoxes(t *testing.T, rows, cols int) *DotsAndBoxes {
+	t.Helper()
+	options, _ := json.Marshal(map[string]int{"rows": rows, "cols": cols})
+	game, err := NewDotsAndBoxes(options)
+	if err != nil {
+		t.Fatalf("Expected no error creating dots and boxes game, but got %v", err)
+	}
+	return game.(*DotsAndBoxes)
+}
+
+func TestDotsAndBoxes_Options(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    string
+		wantErr    bool
+		rows, cols int
+	}{
+		{"default", "", false, 4, 4},
+		{"rectangular", `{"rows": 2, "cols": 10}`, false, 2, 10},
+		{"too small", `{"rows": 1}`, true, 0, 0},
+		{"too large", `{"cols": 11}`, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewDotsAndBoxes(json.RawMessage(tt.options))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error for invalid options, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			d := game.(*DotsAndBoxes)
+			if len(d.HLines) != tt.rows+1 || len(d.HLines[0]) != tt.cols {
+				t.Errorf("Expected %dx%d horizontal lines, but got %dx%d", tt.rows+1, tt.cols, len(d.HLines), len(d.HLines[0]))
+			}
+			if len(d.VLines) != tt.rows || len(d.VLines[0]) != tt.cols+1 {
+				t.Errorf("Expected %dx%d vertical lines, but got %dx%d", tt.rows, tt.cols+1, len(d.VLines), len(d.VLines[0]))
+			}
+			if len(d.Strings) != (tt.rows+1)*tt.cols+tt.rows*(tt.cols+1) {
+				t.Errorf("Expected every line to be an uncut string, but got %d strings", len(d.Strings))
+			}
+		})
+	}
+}
+
+func TestDotsAndBoxes_CompletingBoxKeepsTurn(t *testing.T) {
+	d := newTestDotsAndBoxes(t, 2, 3)
+
+	moves := []struct {
+		playerIndex int
+		lineType    string
+		row, col    int
+	}{
+		{0, "h", 0, 2},
+		{1, "h", 1, 2},
+		{0, "v", 0, 2},
+	}
+	for _, move := range moves {
+		if err := d.HandleMove(move.playerIndex, makeDotsAndBoxesMovePayload(move.lineType, move.row, move.col)); err != nil {
+			t.Fatalf("Move sequence failed at %s %d,%d: %v", move.lineType, move.row, move.col, err)
+		}
+	}
+
+	if err := d.HandleMove(1, makeDotsAndBoxesMovePayload("v", 0, 3)); err != nil {
+		t.Fatalf("Expected no error completing a box, but got %v", err)
+	}
+	if d.Boxes[0][2] != "P2" || d.Scores[1] != 1 {
+		t.Errorf("Expected P2 to own box 0,2, but got '%s'", d.Boxes[0][2])
+	}
+	if d.CurrentTurn != 1 {
+		t.Errorf("Expected P2 to keep the turn, but got %d", d.CurrentTurn)
+	}
+
+	if err := d.HandleMove(1, makeDotsAndBoxesMovePayload("v", 0, 4)); err == nil {
+		t.Error("Expected an error for a line outside the 2x3 grid, but got nil")
+	}
+}
+
+func TestDotsAndBoxes_ChainAnalysis(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     int
+		cols     int
+		lines    [][3]any
+		wantLoop bool
+		wantLen  int
+	}{
+		{
+			name: "long chain along the top row",
+			rows: 2,
+			cols: 3,
+			lines: [][3]any{
+				{"h", 0, 0}, {"h", 0, 1}, {"h", 0, 2},
+				{"h", 1, 0}, {"h", 1, 1}, {"h", 1, 2},
+			},
+			wantLen: 3,
+		},
+		{
+			name: "loop around the centre",
+			rows: 2,
+			cols: 2,
+			lines: [][3]any{
+				{"h", 0, 0}, {"h", 0, 1}, {"h", 2, 0}, {"h", 2, 1},
+				{"v", 0, 0}, {"v", 1, 0}, {"v", 0, 2}, {"v", 1, 2},
+			},
+			wantLoop: true,
+			wantLen:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDotsAndBoxes(t, tt.rows, tt.cols)
+			if len(d.Chains) != 0 {
+				t.Fatalf("Expected no chains on an empty board, but got %d", len(d.Chains))
+			}
+
+			for _, line := range tt.lines {
+				move := makeDotsAndBoxesMovePayload(line[0].(string), line[1].(int), line[2].(int))
+				if err := d.HandleMove(d.CurrentTurn, move); err != nil {
+					t.Fatalf("Expected no error drawing %v, but got %v", line, err)
+				}
+			}
+
+			if len(d.Chains) != 1 {
+				t.Fatalf("Expected exactly one chain, but got %d", len(d.Chains))
+			}
+			if d.Chains[0].Loop != tt.wantLoop || len(d.Chains[0].Boxes) != tt.wantLen {
+				t.Errorf("Expected loop=%v with %d boxes, but got loop=%v with %d", tt.wantLoop, tt.wantLen, d.Chains[0].Loop, len(d.Chains[0].Boxes))
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package games
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+)
+
+const propertyMaxPlies = 400
+
+// extraTurns says when a player may move again straight away. Every other
+// move of every game hands the turn to the opponent.
+var extraTurns = map[string]func(before, after Game) bool{
+	"dots-and-boxes": func(before, after Game) bool {
+		scores := func(g Game) int { return g.(*DotsAndBoxes).Scores[0] + g.(*DotsAndBoxes).Scores[1] }
+		return scores(after) > scores(before)
+	},
+	"kalah": func(_, after Game) bool {
+		lastSown := after.(*Mancala).LastSown
+		return len(lastSown) > 0 && lastSown[len(lastSown)-1][1] == mancalaStore
+	},
+	"nine-mens-morris": func(_, after Game) bool {
+		return after.(*NineMensMorris).PendingRemoval
+	},
+	"santorini": func(before, after Game) bool {
+		s := after.(*Santorini)
+		return before.(*Santorini).Phase == "placement" || s.Turn.Moves > 0 || s.Turn.Builds > 0
+	},
+	"go": func(before, after Game) bool {
+		return before.(*Go).Phase == goPhaseMarking || after.(*Go).Phase == goPhaseMarking
+	},
+}
+
+// playRandomMoves plays up to plies random moves, legal ones where the game
+// lists them and accepted candidates otherwise.
+func playRandomMoves(game Game, r *rand.Rand, plies int, candidates func(r *rand.Rand) any) {
+	for range plies {
+		if game.IsGameOver() {
+			return
+		}
+		if gen, ok := game.(MoveGenerator); ok {
+			moves := gen.LegalMoves()
+			gen.HandleMove(gen.CurrentPlayer(), moves[r.IntN(len(moves))])
+			continue
+		}
+		if candidates == nil {
+			return
+		}
+		for range 1000 {
+			if game.HandleMove(r.IntN(2), candidates(r)) == nil {
+				break
+			}
+		}
+	}
+}
+
+// checkInvariants checks what must hold after any accepted move.
+func checkInvariants(t *testing.T, gameType string, game Game) {
+	t.Helper()
+
+	if game.GetWinner() != "" && !game.IsGameOver() {
+		t.Fatalf("Expected a winner only once the game is over, but %s won an unfinished game", game.GetWinner())
+	}
+
+	if gen, ok := game.(MoveGenerator); ok {
+		if moves := gen.LegalMoves(); game.IsGameOver() != (len(moves) == 0) {
+			t.Fatalf("Expected legal moves exactly while the game is on, but got %d with the game over: %v", len(moves), game.IsGameOver())
+		}
+	}
+
+	if d, ok := game.(*DotsAndBoxes); ok {
+		claimed := 0
+		for _, row := range d.Boxes {
+			for _, box := range row {
+				if box != "" {
+					claimed++
+				}
+			}
+		}
+		if d.Scores[0]+d.Scores[1] != claimed {
+			t.Fatalf("Expected scores %v to add up to the %d boxes claimed", d.Scores, claimed)
+		}
+	}
+
+	state, err := game.MarshalState()
+	if err != nil {
+		t.Fatalf("Expected no error marshalling the state, but got %v", err)
+	}
+	restored, _ := NewGame(gameType, nil)
+	if err := restored.UnmarshalState(state); err != nil {
+		t.Fatalf("Expected the state to load back, but got %v", err)
+	}
+	if again, _ := restored.MarshalState(); !bytes.Equal(state, again) {
+		t.Fatalf("Expected the state to survive a round trip, but got\n%s\nand\n%s", state, again)
+	}
+}
+
+// TestProperties_RandomGames plays random legal games to the end and checks
+// the invariants after every move, along with the order of turns.
+func TestProperties_RandomGames(t *testing.T) {
+	for _, gameType := range GameTypes() {
+		t.Run(gameType, func(t *testing.T) {
+			for seed := range uint64(5) {
+				r := rand.New(rand.NewPCG(seed, 48))
+				game, _ := NewGame(gameType, nil)
+				gen, ok := game.(MoveGenerator)
+				if !ok {
+					t.Skip("no move generator")
+				}
+
+				for ply := 0; ply < propertyMaxPlies && !gen.IsGameOver(); ply++ {
+					mover := gen.CurrentPlayer()
+					before := gen.Clone()
+					moves := gen.LegalMoves()
+					move := moves[r.IntN(len(moves))]
+					if err := gen.HandleMove(mover, move); err != nil {
+						t.Fatalf("Expected legal move %v to be accepted, but got %v", move, err)
+					}
+					checkInvariants(t, gameType, gen)
+
+					if gen.IsGameOver() {
+						break
+					}
+					extraTurn := extraTurns[gameType]
+					if next := gen.CurrentPlayer(); next == mover && (extraTurn == nil || !extraTurn(before, gen)) {
+						t.Fatalf("Expected the turn to pass after %v, but player %d moves again", move, mover)
+					}
+				}
+			}
+		})
+	}
+}
+
+// FuzzHandleMove feeds arbitrary JSON moves to every game, part way through
+// a random game. Nothing may panic, a rejected move must leave the game as
+// it was and an accepted one must keep the invariants.
+func FuzzHandleMove(f *testing.F) {
+	gameTypes := GameTypes()
+	for i, gameType := range gameTypes {
+		game, _ := NewGame(gameType, nil)
+		var seeds []any
+		if gen, ok := game.(MoveGenerator); ok {
+			seeds = gen.LegalMoves()
+		} else if candidates, ok := randomMoveCandidates[gameType]; ok {
+			seeds = []any{candidates(rand.New(rand.NewPCG(1, 1)))}
+		}
+		for _, move := range seeds[:min(len(seeds), 3)] {
+			payload, _ := json.Marshal(move)
+			f.Add(uint8(i), uint8(0), false, payload)
+			f.Add(uint8(i), uint8(12), true, payload)
+			f.Add(uint8(i), uint8(5), false, bytes.ReplaceAll(payload, []byte("1"), []byte("-1")))
+			f.Add(uint8(i), uint8(5), true, bytes.ReplaceAll(payload, []byte("0"), []byte("99")))
+		}
+		f.Add(uint8(i), uint8(3), false, []byte(`{"row": 1e9, "col": -3, "cellIndex": 1e12, "column": -1, "pit": 6, "heap": -1, "sticks": 1, "board": 9, "point": 24}`))
+		f.Add(uint8(i), uint8(3), true, []byte(`{"type": "move", "from": [-1, 99], "to": [0], "at": "a1", "path": [[0, 0]], "worker": 7}`))
+		f.Add(uint8(i), uint8(1), false, []byte(`[1, 2, 3]`))
+		f.Add(uint8(i), uint8(1), true, []byte(`null`))
+	}
+
+	f.Fuzz(func(t *testing.T, gameIndex, plies uint8, second bool, payload []byte) {
+		var move any
+		if err := json.Unmarshal(payload, &move); err != nil {
+			return
+		}
+
+		gameType := gameTypes[int(gameIndex)%len(gameTypes)]
+		game, _ := NewGame(gameType, nil)
+		playRandomMoves(game, rand.New(rand.NewPCG(uint64(plies), 48)), int(plies), randomMoveCandidates[gameType])
+
+		playerIndex := 0
+		if second {
+			playerIndex = 1
+		}
+		before, _ := game.MarshalState()
+		if err := game.HandleMove(playerIndex, move); err != nil {
+			if after, _ := game.MarshalState(); !bytes.Equal(before, after) {
+				t.Fatalf("Expected rejected move %s to leave the game untouched, but got\n%s\nafter\n%s", payload, after, before)
+			}
+			return
+		}
+		checkInvariants(t, gameType, game)
+	})
+}
@@ -1,6 +1,11 @@
 package games
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
 
 // Game defines the interface that each game must implement.
 type Game interface {
@@ -19,15 +24,76 @@ type Game interface {
 
 	// Reset resets the game to its initial state.
 	Reset()
+
+	// Clone returns a deep copy of the game that shares no mutable state
+	// with the original.
+	Clone() Game
+
+	// MarshalState serializes the complete state, including anything
+	// hidden from clients, so that UnmarshalState can restore it. Equal
+	// states serialize to equal bytes.
+	MarshalState() ([]byte, error)
+
+	// UnmarshalState replaces the state with one produced by MarshalState
+	// on a game of the same type.
+	UnmarshalState(data []byte) error
+}
+
+// HiddenInformationGame is implemented by games where players must not see
+// the whole state, such as secret ship placements. Rooms send each viewer the
+// result of GetPlayerState instead of the shared GetGameState.
+type HiddenInformationGame interface {
+	Game
+
+	// GetPlayerState returns the state visible to the given player.
+	// Spectators are passed a playerIndex of -1.
+	GetPlayerState(playerIndex int) any
+}
+
+// MoveGenerator is implemented by games that can list their legal moves,
+// which lets solvers and bots search them by cloning the game and playing
+// each move in turn.
+type MoveGenerator interface {
+	Game
+
+	// CurrentPlayer returns the index of the player to move.
+	CurrentPlayer() int
+
+	// LegalMoves returns every legal move for the player to move, in the
+	// format HandleMove accepts. It is empty once the game is over.
+	LegalMoves() []any
+
+	// PlayerSymbol returns what GetWinner reports when the given player wins.
+	PlayerSymbol(playerIndex int) string
+
+	// PositionKey identifies the position together with the player to move,
+	// so that searches can share results between transpositions. Two states
+	// with the same key must have the same legal moves and outcomes.
+	PositionKey() string
 }
 
 // Factory is a function that creates a new instance of a game.
 type Factory func() (Game, error)
 
-var gameFactories = make(map[string]Factory)
+// OptionsFactory is a function that creates a new instance of a game
+// configured with the room's game options. options may be empty.
+type OptionsFactory func(options json.RawMessage) (Game, error)
+
+var gameFactories = make(map[string]OptionsFactory)
 
 // RegisterGame is used to register a new type of game.
 func RegisterGame(gameType string, factory Factory) {
+	if factory == nil {
+		panic("game factory cannot be nil")
+	}
+	RegisterGameWithOptions(gameType, func(json.RawMessage) (Game, error) {
+		return factory()
+	})
+}
+
+// RegisterGameWithOptions is used to register a new type of game that can be
+// configured through room options.
+func RegisterGameWithOptions(gameType string, factory OptionsFactory) {
 	if factory == nil {
 		panic("game factory cannot be nil")
 	}
@@ -38,11 +104,28 @@ func RegisterGame(gameType string, factory Factory) {
 	gameFactories[gameType] = factory
 }
 
-// NewGame creates an instance of a game based on its type.
-func NewGame(gameType string) (Game, error) {
+// NewGame creates an instance of a game based on its type and room options.
+func NewGame(gameType string, options json.RawMessage) (Game, error) {
 	factory, ok := gameFactories[gameType]
 	if !ok {
 		return nil, fmt.Errorf("unsupported game: %s", gameType)
 	}
-	return factory()
+	return factory(options)
+}
+
+// GameTypes returns every registered game type in alphabetical order.
+func GameTypes() []string {
+	return slices.Sorted(maps.Keys(gameFactories))
+}
+
+// decodeOptions unmarshals room options into dst. dst is left untouched when
+// no options were provided, so callers should fill it with defaults first.
+func decodeOptions(options json.RawMessage, dst any) error {
+	if len(options) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(options, dst); err != nil {
+		return fmt.Errorf("invalid game options: %w", err)
+	}
+	return nil
 }
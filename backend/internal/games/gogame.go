@@ -0,0 +1,636 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	goDefaultSize = 9
+	goDefaultKomi = 7.5
+)
+
+const (
+	goPhasePlay     = "play"
+	goPhaseMarking  = "marking"
+	goPhaseFinished = "finished"
+)
+
+func init() {
+	RegisterGameWithOptions("go", NewGo)
+	RegisterMetadata("go", goMetadata)
+}
+
+var goMetadata = Metadata{
+	DisplayName: "Go",
+	Description: "Place stones to surround territory and capture your opponent's groups. The larger area wins.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "size", Label: "Board size", Type: OptionChoice, Default: goDefaultSize, Choices: []any{9, 13, 19}},
+		{Name: "komi", Label: "Komi", Type: OptionNumber, Default: goDefaultKomi, Range: &Range{Min: -100, Max: 100, Step: 0.5}},
+	},
+	TimeControl: longTimeControl,
+	Rules: []string{
+		"Black and White take turns placing a stone on an empty point, or passing",
+		"Groups with no liberties left are captured",
+		"A move may not be suicide or repeat an earlier board position",
+		"After two passes both players mark dead stones and must agree before scoring",
+		"Stones plus surrounded empty points score, with komi added for White",
+	},
+}
+
+var goNeighbors = [4][2]int{
+	{-1, 0},
+	{1, 0},
+	{0, -1},
+	{0, 1},
+}
+
+// Go is played under area scoring with positional superko. After two
+// consecutive passes the game enters a marking phase where both players
+// toggle dead groups and must accept the same marking before it is scored.
+type Go struct {
+	Size          int        `json:"size"`
+	Komi          float64    `json:"komi"`
+	Board         [][]string `json:"board"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
+	Phase         string     `json:"phase"`
+	Captures      [2]int     `json:"captures"`
+	LastMove      *[2]int    `json:"lastMove"`
+	DeadStones    [][]bool   `json:"deadStones"`
+	Accepted      [2]bool    `json:"accepted"`
+	Scores        [2]float64 `json:"scores"`
+	playerSymbols [2]string
+	passes        int
+	history       map[string]bool
+}
+
+type goOptions struct {
+	Size int     `json:"size"`
+	Komi float64 `json:"komi"`
+}
+
+func NewGo(options json.RawMessage) (Game, error) {
+	opts := goOptions{Size: goDefaultSize, Komi: goDefaultKomi}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Size != 9 && opts.Size != 13 && opts.Size != 19 {
+		return nil, fmt.Errorf("go board size must be 9, 13 or 19")
+	}
+	if math.Abs(opts.Komi) > 100 || math.Mod(opts.Komi*2, 1) != 0 {
+		return nil, fmt.Errorf("komi must be a multiple of 0.5")
+	}
+
+	g := &Go{Size: opts.Size, Komi: opts.Komi}
+	g.Reset()
+	return g, nil
+}
+
+func (g *Go) HandleMove(playerIndex int, move any) error {
+	if g.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	moveType, _ := moveData["type"].(string)
+	if moveType == "" {
+		moveType = "place"
+	}
+
+	if g.Phase == goPhaseMarking {
+		switch moveType {
+		case "mark_dead":
+			row, col, err := g.parseCell(moveData)
+			if err != nil {
+				return err
+			}
+			return g.toggleDead(row, col)
+		case "accept":
+			return g.accept(playerIndex)
+		case "resume":
+			g.resume(playerIndex)
+			return nil
+		default:
+			return fmt.Errorf("only mark_dead, accept or resume are allowed while marking dead stones")
+		}
+	}
+
+	if playerIndex != g.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	switch moveType {
+	case "place":
+		row, col, err := g.parseCell(moveData)
+		if err != nil {
+			return err
+		}
+		if err := g.place(playerIndex, row, col); err != nil {
+			return err
+		}
+		g.passes = 0
+	case "pass":
+		g.passes++
+		g.LastMove = nil
+		if g.passes == 2 {
+			g.Phase = goPhaseMarking
+			g.DeadStones = g.emptyMarks()
+			g.Scores = g.score()
+		}
+	default:
+		return fmt.Errorf("invalid move type: must be 'place' or 'pass'")
+	}
+
+	g.CurrentTurn = (g.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+func (g *Go) parseCell(moveData map[string]any) (int, int, error) {
+	rowFloat, ok := moveData["row"].(float64)
+	if !ok {
+		return 0, 0, fmt.Errorf("row must be a number")
+	}
+
+	colFloat, ok := moveData["col"].(float64)
+	if !ok {
+		return 0, 0, fmt.Errorf("col must be a number")
+	}
+
+	row := int(rowFloat)
+	col := int(colFloat)
+
+	if !g.inBounds(row, col) {
+		return 0, 0, fmt.Errorf("out of bounds")
+	}
+	return row, col, nil
+}
+
+// place puts a stone on the board, removing captured groups. The move is
+// rejected without changing the board if it is suicide or repeats an earlier
+// position.
+func (g *Go) place(playerIndex, row, col int) error {
+	board, captured, key, err := g.placement(playerIndex, row, col)
+	if err != nil {
+		return err
+	}
+
+	g.Board = board
+	g.history[key] = true
+	g.Captures[playerIndex] += captured
+	g.LastMove = &[2]int{row, col}
+
+	return nil
+}
+
+// placement works out the board after a stone is placed, together with the
+// number of stones it captures and the superko key of the result, without
+// changing the game.
+func (g *Go) placement(playerIndex, row, col int) ([][]string, int, string, error) {
+	if g.Board[row][col] != "" {
+		return nil, 0, "", fmt.Errorf("cell is already occupied")
+	}
+
+	symbol := g.playerSymbols[playerIndex]
+	opponent := g.playerSymbols[1-playerIndex]
+
+	board := g.copyBoard()
+	board[row][col] = symbol
+
+	captured := 0
+	for _, dir := range goNeighbors {
+		r, c := row+dir[0], col+dir[1]
+		if !g.inBounds(r, c) || board[r][c] != opponent {
+			continue
+		}
+		stones, liberties := g.group(board, r, c)
+		if liberties == 0 {
+			for _, stone := range stones {
+				board[stone[0]][stone[1]] = ""
+			}
+			captured += len(stones)
+		}
+	}
+
+	if _, liberties := g.group(board, row, col); liberties == 0 {
+		return nil, 0, "", fmt.Errorf("suicide is not allowed")
+	}
+
+	key := g.positionKey(board)
+	if g.history[key] {
+		return nil, 0, "", fmt.Errorf("move repeats a previous position (superko)")
+	}
+
+	return board, captured, key, nil
+}
+
+// group returns the stones connected to row, col and the number of distinct
+// liberties of that group.
+func (g *Go) group(board [][]string, row, col int) ([][2]int, int) {
+	symbol := board[row][col]
+	visited := make([]bool, g.Size*g.Size)
+	libertySeen := make([]bool, g.Size*g.Size)
+	liberties := 0
+
+	stones := [][2]int{{row, col}}
+	visited[row*g.Size+col] = true
+
+	for i := 0; i < len(stones); i++ {
+		for _, dir := range goNeighbors {
+			r, c := stones[i][0]+dir[0], stones[i][1]+dir[1]
+			if !g.inBounds(r, c) {
+				continue
+			}
+			idx := r*g.Size + c
+			switch board[r][c] {
+			case "":
+				if !libertySeen[idx] {
+					libertySeen[idx] = true
+					liberties++
+				}
+			case symbol:
+				if !visited[idx] {
+					visited[idx] = true
+					stones = append(stones, [2]int{r, c})
+				}
+			}
+		}
+	}
+
+	return stones, liberties
+}
+
+// toggleDead flips the dead marking of the whole group at row, col. Any change
+// to the marking withdraws both players' acceptance.
+func (g *Go) toggleDead(row, col int) error {
+	if g.Board[row][col] == "" {
+		return fmt.Errorf("there is no stone at that point")
+	}
+
+	dead := !g.DeadStones[row][col]
+	stones, _ := g.group(g.Board, row, col)
+	for _, stone := range stones {
+		g.DeadStones[stone[0]][stone[1]] = dead
+	}
+
+	g.Accepted = [2]bool{}
+	g.Scores = g.score()
+
+	return nil
+}
+
+func (g *Go) accept(playerIndex int) error {
+	if g.Accepted[playerIndex] {
+		return fmt.Errorf("you have already accepted the dead stones")
+	}
+	g.Accepted[playerIndex] = true
+
+	if g.Accepted[0] && g.Accepted[1] {
+		g.Phase = goPhaseFinished
+		g.Scores = g.score()
+		g.determineWinner()
+	}
+
+	return nil
+}
+
+// resume returns to play after a disagreement over dead stones. The opponent
+// of the player asking to resume moves first.
+func (g *Go) resume(playerIndex int) {
+	g.Phase = goPhasePlay
+	g.DeadStones = g.emptyMarks()
+	g.Accepted = [2]bool{}
+	g.Scores = [2]float64{}
+	g.passes = 0
+	g.CurrentTurn = 1 - playerIndex
+}
+
+// score counts stones plus surrounded empty points for each player, treating
+// stones marked dead as empty points. Komi is added to White's score.
+func (g *Go) score() [2]float64 {
+	board := g.copyBoard()
+	var scores [2]float64
+
+	for r := range board {
+		for c := range board[r] {
+			if g.DeadStones[r][c] {
+				board[r][c] = ""
+			}
+			for i, symbol := range g.playerSymbols {
+				if board[r][c] == symbol {
+					scores[i]++
+				}
+			}
+		}
+	}
+
+	visited := make([]bool, g.Size*g.Size)
+	for r := range board {
+		for c := range board[r] {
+			if board[r][c] != "" || visited[r*g.Size+c] {
+				continue
+			}
+
+			region := [][2]int{{r, c}}
+			visited[r*g.Size+c] = true
+			borders := map[string]bool{}
+
+			for i := 0; i < len(region); i++ {
+				for _, dir := range goNeighbors {
+					nr, nc := region[i][0]+dir[0], region[i][1]+dir[1]
+					if !g.inBounds(nr, nc) {
+						continue
+					}
+					if board[nr][nc] != "" {
+						borders[board[nr][nc]] = true
+						continue
+					}
+					if !visited[nr*g.Size+nc] {
+						visited[nr*g.Size+nc] = true
+						region = append(region, [2]int{nr, nc})
+					}
+				}
+			}
+
+			if len(borders) != 1 {
+				continue
+			}
+			for i, symbol := range g.playerSymbols {
+				if borders[symbol] {
+					scores[i] += float64(len(region))
+				}
+			}
+		}
+	}
+
+	scores[1] += g.Komi
+	return scores
+}
+
+func (g *Go) determineWinner() {
+	if g.Scores[0] > g.Scores[1] {
+		g.Winner = g.playerSymbols[0]
+	} else if g.Scores[1] > g.Scores[0] {
+		g.Winner = g.playerSymbols[1]
+	} else {
+		g.Winner = "draw"
+	}
+}
+
+func (g *Go) positionKey(board [][]string) string {
+	var sb strings.Builder
+	sb.Grow(g.Size * g.Size)
+	for r := range board {
+		for c := range board[r] {
+			switch board[r][c] {
+			case "":
+				sb.WriteByte('.')
+			default:
+				sb.WriteString(board[r][c])
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (g *Go) copyBoard() [][]string {
+	board := make([][]string, g.Size)
+	for i := range g.Board {
+		board[i] = append([]string(nil), g.Board[i]...)
+	}
+	return board
+}
+
+func (g *Go) emptyMarks() [][]bool {
+	marks := make([][]bool, g.Size)
+	for i := range marks {
+		marks[i] = make([]bool, g.Size)
+	}
+	return marks
+}
+
+func (g *Go) inBounds(row, col int) bool {
+	return row >= 0 && row < g.Size && col >= 0 && col < g.Size
+}
+
+func (g *Go) GetGameState() any {
+	return g
+}
+
+func (g *Go) IsGameOver() bool {
+	return g.Winner != ""
+}
+
+func (g *Go) GetWinner() string {
+	return g.Winner
+}
+
+func (g *Go) Reset() {
+	g.Board = make([][]string, g.Size)
+	for i := range g.Board {
+		g.Board[i] = make([]string, g.Size)
+	}
+	g.CurrentTurn = 0
+	g.Winner = ""
+	g.Phase = goPhasePlay
+	g.Captures = [2]int{}
+	g.LastMove = nil
+	g.DeadStones = g.emptyMarks()
+	g.Accepted = [2]bool{}
+	g.Scores = [2]float64{}
+	g.playerSymbols = [2]string{"B", "W"}
+	g.passes = 0
+	g.history = map[string]bool{g.positionKey(g.Board): true}
+}
+
+type goPrivate struct {
+	PlayerSymbols [2]string       `json:"playerSymbols"`
+	Passes        int             `json:"passes"`
+	History       map[string]bool `json:"history"`
+}
+
+func (g *Go) Clone() Game {
+	cloned := *g
+	cloned.Board = cloneGrid(g.Board)
+	cloned.DeadStones = cloneGrid(g.DeadStones)
+	if g.LastMove != nil {
+		lastMove := *g.LastMove
+		cloned.LastMove = &lastMove
+	}
+	cloned.history = maps.Clone(g.history)
+	return &cloned
+}
+
+func (g *Go) MarshalState() ([]byte, error) {
+	return marshalState(g, goPrivate{PlayerSymbols: g.playerSymbols, Passes: g.passes, History: g.history})
+}
+
+func (g *Go) UnmarshalState(data []byte) error {
+	var restored Go
+	var private goPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.passes = private.Passes
+	restored.history = private.History
+	if restored.history == nil {
+		restored.history = map[string]bool{}
+	}
+	*g = restored
+	return nil
+}
+
+// afterSetup starts the superko history from the set-up position.
+func (g *Go) afterSetup() {
+	g.passes = 0
+	g.history = map[string]bool{g.positionKey(g.Board): true}
+}
+
+// ValidatePosition requires every group to have a liberty and the counts to
+// be those of a game in play: no more captures than points, and no dead
+// stones, acceptances or scores, which only come with the end of the game.
+func (g *Go) ValidatePosition() error {
+	for row := range g.Board {
+		if err := checkPieces(g.Board[row], g.playerSymbols[:]...); err != nil {
+			return err
+		}
+	}
+	for row := range g.Board {
+		for col, stone := range g.Board[row] {
+			if stone == "" {
+				continue
+			}
+			if _, liberties := g.group(g.Board, row, col); liberties == 0 {
+				return fmt.Errorf("the group at %c%d has no liberties", goColumns[col], g.Size-row)
+			}
+		}
+	}
+
+	for _, captures := range g.Captures {
+		if captures < 0 || captures > g.Size*g.Size {
+			return fmt.Errorf("captures must be between 0 and %d", g.Size*g.Size)
+		}
+	}
+	if slices.Contains(slices.Concat(g.DeadStones...), true) || g.Accepted != [2]bool{} || g.Scores != [2]float64{} {
+		return fmt.Errorf("dead stones and scores cannot be set in a position")
+	}
+	return nil
+}
+
+// CurrentPlayer returns the player to move, or while marking dead stones the
+// first player who has yet to accept the marking.
+func (g *Go) CurrentPlayer() int {
+	if g.Phase == goPhaseMarking && !g.Accepted[0] {
+		return 0
+	}
+	if g.Phase == goPhaseMarking {
+		return 1
+	}
+	return g.CurrentTurn
+}
+
+// LegalMoves lists every legal placement and passing. While marking dead
+// stones the only move listed is accepting the marking as it stands, which
+// keeps searches from toggling groups forever.
+func (g *Go) LegalMoves() []any {
+	if g.IsGameOver() {
+		return nil
+	}
+	if g.Phase == goPhaseMarking {
+		return []any{map[string]any{"type": "accept"}}
+	}
+
+	var moves []any
+	for r := range g.Board {
+		for c := range g.Board[r] {
+			if g.Board[r][c] != "" {
+				continue
+			}
+			if _, _, _, err := g.placement(g.CurrentTurn, r, c); err == nil {
+				moves = append(moves, map[string]any{"type": "place", "row": float64(r), "col": float64(c)})
+			}
+		}
+	}
+	return append(moves, map[string]any{"type": "pass"})
+}
+
+func (g *Go) PlayerSymbol(playerIndex int) string {
+	return g.playerSymbols[playerIndex]
+}
+
+func (g *Go) PositionKey() string {
+	return stateKey(g)
+}
+
+// goColumns are the column letters of the Go Text Protocol, which skips "i".
+const goColumns = "abcdefghjklmnopqrst"
+
+// FormatMove writes points as in the Go Text Protocol: a column letter and a
+// row counted from 1 at the bottom, so "d4" is a star point on the 19x19
+// board. The other moves are "pass", "accept", "resume" and "x" before a
+// point to mark its group dead.
+func (g *Go) FormatMove(move any) (string, error) {
+	kind, err := moveType(move)
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case "pass", "accept", "resume":
+		return kind, nil
+	case "", "place", "mark_dead":
+		row, err := moveField(move, "row")
+		if err != nil {
+			return "", err
+		}
+		col, err := moveField(move, "col")
+		if err != nil {
+			return "", err
+		}
+		if !g.inBounds(row, col) {
+			return "", fmt.Errorf("out of bounds")
+		}
+		point := fmt.Sprintf("%c%d", goColumns[col], g.Size-row)
+		if kind == "mark_dead" {
+			return "x" + point, nil
+		}
+		return point, nil
+	default:
+		return "", fmt.Errorf("invalid move type: %s", kind)
+	}
+}
+
+func (g *Go) ParseMove(text string) (any, error) {
+	text = strings.ToLower(text)
+	switch text {
+	case "pass", "accept", "resume":
+		return map[string]any{"type": text}, nil
+	}
+
+	kind := "place"
+	if point, ok := strings.CutPrefix(text, "x"); ok {
+		kind, text = "mark_dead", point
+	}
+	col := -1
+	if text != "" {
+		col = strings.IndexByte(goColumns[:g.Size], text[0])
+	}
+	number, err := strconv.Atoi(text[min(1, len(text)):])
+	if col == -1 || err != nil || number < 1 || number > g.Size {
+		return nil, fmt.Errorf("%q is not a point on the board", text)
+	}
+	return map[string]any{"type": kind, "row": float64(g.Size - number), "col": float64(col)}, nil
+}
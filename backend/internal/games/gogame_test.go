@@ -0,0 +1,137 @@
+package games
+
+import (
+	"testing"
+)
+
+func makeGoMovePayload(row int, col int) any {
+	return map[string]any{"type": "place", "row": float64(row), "col": float64(col)}
+}
+
+func newTestGo(t *testing.T) *Go {
+	t.Helper()
+	game, err := NewGo(nil)
+	if err != nil {
+		t.Fatalf("Expected no error creating go game, but got %v", err)
+	}
+	return game.(*Go)
+}
+
+func playGoMoves(t *testing.T, g *Go, moves [][2]int) {
+	t.Helper()
+	for i, move := range moves {
+		if err := g.HandleMove(i%2, makeGoMovePayload(move[0], move[1])); err != nil {
+			t.Fatalf("Move sequence failed at move %d (%d,%d): %v", i, move[0], move[1], err)
+		}
+	}
+}
+
+func TestGo_Capture(t *testing.T) {
+	g := newTestGo(t)
+
+	playGoMoves(t, g, [][2]int{
+		{0, 1}, {0, 0},
+		{8, 8}, {5, 5},
+		{1, 0}, // B removes the last liberty of the corner stone
+	})
+
+	if g.Board[0][0] != "" {
+		t.Errorf("Expected stone at 0,0 to be captured, but got '%s'", g.Board[0][0])
+	}
+	if g.Captures[0] != 1 {
+		t.Errorf("Expected black to have 1 capture, but got %d", g.Captures[0])
+	}
+}
+
+func TestGo_SuicideRejected(t *testing.T) {
+	g := newTestGo(t)
+
+	playGoMoves(t, g, [][2]int{{0, 1}, {8, 8}, {1, 0}})
+
+	err := g.HandleMove(1, makeGoMovePayload(0, 0))
+	if err == nil {
+		t.Fatal("Expected an error for a suicide move, but got nil")
+	}
+	if g.Board[0][0] != "" {
+		t.Errorf("Expected the board to be unchanged, but got '%s' at 0,0", g.Board[0][0])
+	}
+	if g.CurrentTurn != 1 {
+		t.Errorf("Expected CurrentTurn to still be 1, but got %d", g.CurrentTurn)
+	}
+}
+
+func TestGo_KoRejected(t *testing.T) {
+	g := newTestGo(t)
+
+	playGoMoves(t, g, [][2]int{
+		{1, 0}, {0, 2},
+		{0, 1}, {2, 2},
+		{2, 1}, {1, 3},
+		{8, 8}, {1, 1},
+		{1, 2}, // B takes the ko
+	})
+
+	if g.Board[1][1] != "" {
+		t.Fatalf("Expected white stone at 1,1 to be captured, but got '%s'", g.Board[1][1])
+	}
+
+	err := g.HandleMove(1, makeGoMovePayload(1, 1))
+	if err == nil {
+		t.Fatal("Expected an error when immediately retaking the ko, but got nil")
+	}
+}
+
+func TestGo_PassAndScoring(t *testing.T) {
+	g := newTestGo(t)
+
+	g.HandleMove(0, makeGoMovePayload(4, 4))
+	g.HandleMove(1, map[string]any{"type": "pass"})
+	g.HandleMove(0, map[string]any{"type": "pass"})
+
+	if g.Phase != "marking" {
+		t.Fatalf("Expected phase to be 'marking' after two passes, but got '%s'", g.Phase)
+	}
+	if g.Scores[0] != 81 || g.Scores[1] != 7.5 {
+		t.Errorf("Expected scores 81 and 7.5, but got %v", g.Scores)
+	}
+
+	if err := g.HandleMove(1, makeGoMovePayload(0, 0)); err == nil {
+		t.Fatal("Expected an error when placing a stone while marking, but got nil")
+	}
+
+	g.HandleMove(1, map[string]any{"type": "accept"})
+	g.HandleMove(0, map[string]any{"type": "mark_dead", "row": float64(4), "col": float64(4)})
+
+	if g.Accepted[1] {
+		t.Error("Expected changing the marking to withdraw white's acceptance")
+	}
+	if g.Scores[0] != 0 {
+		t.Errorf("Expected black to score 0 with its only stone dead, but got %v", g.Scores[0])
+	}
+
+	g.HandleMove(0, map[string]any{"type": "mark_dead", "row": float64(4), "col": float64(4)})
+	g.HandleMove(0, map[string]any{"type": "accept"})
+	g.HandleMove(1, map[string]any{"type": "accept"})
+
+	if !g.IsGameOver() {
+		t.Fatal("Expected game to be over after both players accepted, but it wasn't")
+	}
+	if g.GetWinner() != "B" {
+		t.Errorf("Expected winner to be 'B', but got '%s'", g.GetWinner())
+	}
+}
+
+func TestGo_Resume(t *testing.T) {
+	g := newTestGo(t)
+
+	g.HandleMove(0, map[string]any{"type": "pass"})
+	g.HandleMove(1, map[string]any{"type": "pass"})
+	g.HandleMove(1, map[string]any{"type": "resume"})
+
+	if g.Phase != "play" {
+		t.Fatalf("Expected phase to be 'play' after resuming, but got '%s'", g.Phase)
+	}
+	if g.CurrentTurn != 0 {
+		t.Errorf("Expected the opponent of the resuming player to move, but got turn %d", g.CurrentTurn)
+	}
+}
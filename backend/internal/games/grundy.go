@@ -0,0 +1,168 @@
+package games
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SubtractionGame describes an impartial heap game in which a move takes a
+// number of sticks from a single heap. The allowed amounts come from the
+// subtraction set; an empty set allows taking any number, which is Nim.
+type SubtractionGame struct {
+	Subtraction []int
+	grundy      []int
+	misere      map[string]bool
+}
+
+func NewSubtractionGame(subtraction []int) *SubtractionGame {
+	return &SubtractionGame{Subtraction: subtraction}
+}
+
+// Moves lists the amounts that can be taken from a heap of the given size.
+func (s *SubtractionGame) Moves(heap int) []int {
+	moves := []int{}
+	if len(s.Subtraction) == 0 {
+		for take := 1; take <= heap; take++ {
+			moves = append(moves, take)
+		}
+		return moves
+	}
+	for _, take := range s.Subtraction {
+		if take <= heap {
+			moves = append(moves, take)
+		}
+	}
+	return moves
+}
+
+// HasMoves reports whether any heap still allows a move.
+func (s *SubtractionGame) HasMoves(heaps []int) bool {
+	for _, heap := range heaps {
+		if len(s.Moves(heap)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Grundy returns the Sprague-Grundy value of a single heap under normal play.
+func (s *SubtractionGame) Grundy(heap int) int {
+	if len(s.Subtraction) == 0 {
+		return heap
+	}
+	for n := len(s.grundy); n <= heap; n++ {
+		reachable := []int{}
+		for _, take := range s.Moves(n) {
+			reachable = append(reachable, s.grundy[n-take])
+		}
+		s.grundy = append(s.grundy, mex(reachable))
+	}
+	return s.grundy[heap]
+}
+
+// NimSum returns the Sprague-Grundy value of a position of several heaps,
+// the XOR of the values of the single heaps. It is zero exactly when the
+// player to move loses under normal play.
+func (s *SubtractionGame) NimSum(heaps []int) int {
+	sum := 0
+	for _, heap := range heaps {
+		sum ^= s.Grundy(heap)
+	}
+	return sum
+}
+
+// BestMove picks a move for the player to move. winning reports whether the
+// move keeps a forced win; when the position is lost the first legal move is
+// returned instead. heap is -1 when there is no legal move at all.
+func (s *SubtractionGame) BestMove(heaps []int, misere bool) (heap, take int, winning bool) {
+	heap = -1
+	for i, size := range heaps {
+		for _, t := range s.Moves(size) {
+			if heap == -1 {
+				heap, take = i, t
+			}
+			next := slices.Clone(heaps)
+			next[i] -= t
+			if !s.IsWinning(next, misere) {
+				return i, t, true
+			}
+		}
+	}
+	return heap, take, false
+}
+
+// IsWinning reports whether the player to move wins with perfect play.
+// Normal play follows from the nim-sum. Misère Nim uses Bouton's rule, and
+// other misère subtraction games fall back to a memoised search because the
+// Sprague-Grundy theory does not carry over to misère sums.
+func (s *SubtractionGame) IsWinning(heaps []int, misere bool) bool {
+	if !misere {
+		return s.NimSum(heaps) != 0
+	}
+
+	if len(s.Subtraction) == 0 {
+		large := false
+		ones := 0
+		for _, heap := range heaps {
+			if heap > 1 {
+				large = true
+			} else if heap == 1 {
+				ones++
+			}
+		}
+		if !large {
+			return ones%2 == 0
+		}
+		return s.NimSum(heaps) != 0
+	}
+
+	return s.isWinningMisere(heaps)
+}
+
+func (s *SubtractionGame) isWinningMisere(heaps []int) bool {
+	sorted := slices.Clone(heaps)
+	slices.Sort(sorted)
+	key := fmt.Sprint(sorted)
+	if won, ok := s.misere[key]; ok {
+		return won
+	}
+	if s.misere == nil {
+		s.misere = map[string]bool{}
+	}
+
+	// The player who cannot move wins a misère game.
+	won := true
+	for i, size := range sorted {
+		if i > 0 && size == sorted[i-1] {
+			continue
+		}
+		for _, take := range s.Moves(size) {
+			won = false
+			next := slices.Clone(sorted)
+			next[i] -= take
+			if !s.isWinningMisere(next) {
+				s.misere[key] = true
+				return true
+			}
+		}
+	}
+
+	s.misere[key] = won
+	return won
+}
+
+// mex returns the minimum excluded value of a set of non-negative integers.
+func mex(values []int) int {
+	seen := make([]bool, len(values)+1)
+	for _, v := range values {
+		if v < len(seen) {
+			seen[v] = true
+		}
+	}
+	for i, ok := range seen {
+		if !ok {
+			return i
+		}
+	}
+	return len(values)
+}
@@ -0,0 +1,95 @@
+package games
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMex(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   int
+	}{
+		{nil, 0},
+		{[]int{1, 2}, 0},
+		{[]int{0, 1, 3}, 2},
+		{[]int{2, 0, 1, 1}, 3},
+	}
+
+	for _, tt := range tests {
+		if got := mex(tt.values); got != tt.want {
+			t.Errorf("Expected mex(%v) to be %d, but got %d", tt.values, tt.want, got)
+		}
+	}
+}
+
+func TestSubtractionGame_Grundy(t *testing.T) {
+	tests := []struct {
+		name        string
+		subtraction []int
+		want        []int
+	}{
+		{"nim", nil, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"take one to three", []int{1, 2, 3}, []int{0, 1, 2, 3, 0, 1, 2}},
+		{"take two or five", []int{2, 5}, []int{0, 0, 1, 1, 0, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubtractionGame(tt.subtraction)
+			got := make([]int, len(tt.want))
+			for heap := range got {
+				got[heap] = s.Grundy(heap)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected Grundy values %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubtractionGame_IsWinning(t *testing.T) {
+	tests := []struct {
+		name        string
+		subtraction []int
+		heaps       []int
+		misere      bool
+		want        bool
+	}{
+		{"nim zero nim-sum", nil, []int{1, 2, 3}, false, false},
+		{"nim non-zero nim-sum", nil, []int{3, 4, 5}, false, true},
+		{"misère nim even ones", nil, []int{1, 1}, true, true},
+		{"misère nim odd ones", nil, []int{1, 1, 1}, true, false},
+		{"misère nim large heap", nil, []int{1, 1, 4}, true, true},
+		{"misère one to three from 21", []int{1, 2, 3}, []int{21}, true, false},
+		{"misère one to three from 20", []int{1, 2, 3}, []int{20}, true, true},
+		{"misère stuck position", []int{2, 5}, []int{1, 1}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubtractionGame(tt.subtraction)
+			if got := s.IsWinning(tt.heaps, tt.misere); got != tt.want {
+				t.Errorf("Expected IsWinning(%v) to be %v, but got %v", tt.heaps, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubtractionGame_BestMove(t *testing.T) {
+	s := NewSubtractionGame(nil)
+	heaps := []int{3, 4, 5}
+
+	heap, take, winning := s.BestMove(heaps, false)
+	if !winning {
+		t.Fatal("Expected a winning move from a non-zero nim-sum, but got none")
+	}
+	heaps[heap] -= take
+	if sum := s.NimSum(heaps); sum != 0 {
+		t.Errorf("Expected the best move to leave a nim-sum of 0, but got %d", sum)
+	}
+
+	if heap, _, _ := s.BestMove([]int{0, 0}, false); heap != -1 {
+		t.Errorf("Expected no move on empty heaps, but got heap %d", heap)
+	}
+}
@@ -0,0 +1,449 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	hexDefaultSize = 11
+	hexMinSize     = 5
+	hexMaxSize     = 19
+)
+
+func init() {
+	RegisterGameWithOptions("hex", NewHex)
+	RegisterMetadata("hex", hexMetadata)
+}
+
+var hexMetadata = Metadata{
+	DisplayName: "Hex",
+	Description: "Claim cells on a rhombus of hexagons to connect your two sides of the board. There are no draws.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "size", Label: "Board size", Type: OptionInt, Default: hexDefaultSize, Range: &Range{Min: hexMinSize, Max: hexMaxSize}},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Players take turns placing a stone on any empty cell",
+		"After the first move, the second player may swap instead of placing a stone",
+		"Connect your two opposite sides of the board with a chain of stones to win",
+	},
+}
+
+// hexNeighbors lists the six neighbours of a cell on a rhombus board.
+var hexNeighbors = [6][2]int{
+	{-1, 0},
+	{-1, 1},
+	{0, -1},
+	{0, 1},
+	{1, -1},
+	{1, 0},
+}
+
+// Hex is played on a Size x Size rhombus. "R" connects the top and bottom
+// edges, "B" connects the left and right edges. The second player may swap
+// on move two, taking over the first stone mirrored to their own colour.
+type Hex struct {
+	Size          int        `json:"size"`
+	Board         [][]string `json:"board"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
+	WinningCells  [][2]int   `json:"winningCells"`
+	CanSwap       bool       `json:"canSwap"`
+	Swapped       bool       `json:"swapped"`
+	playerSymbols [2]string
+	moves         int
+	parent        []int
+}
+
+type hexOptions struct {
+	Size int `json:"size"`
+}
+
+func NewHex(options json.RawMessage) (Game, error) {
+	opts := hexOptions{Size: hexDefaultSize}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Size < hexMinSize || opts.Size > hexMaxSize {
+		return nil, fmt.Errorf("hex board size must be between %d and %d", hexMinSize, hexMaxSize)
+	}
+
+	h := &Hex{Size: opts.Size}
+	h.Reset()
+	return h, nil
+}
+
+func (h *Hex) HandleMove(playerIndex int, move any) error {
+	if h.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != h.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	if moveType, _ := moveData["type"].(string); moveType == "swap" {
+		return h.swap()
+	}
+
+	rowFloat, ok := moveData["row"].(float64)
+	if !ok {
+		return fmt.Errorf("row must be a number")
+	}
+
+	colFloat, ok := moveData["col"].(float64)
+	if !ok {
+		return fmt.Errorf("col must be a number")
+	}
+
+	row := int(rowFloat)
+	col := int(colFloat)
+
+	if !h.inBounds(row, col) {
+		return fmt.Errorf("out of bounds")
+	}
+
+	if h.Board[row][col] != "" {
+		return fmt.Errorf("cell is already occupied")
+	}
+
+	symbol := h.playerSymbols[playerIndex]
+	h.place(row, col, symbol)
+	h.moves++
+	h.CanSwap = h.moves == 1
+
+	if h.checkWinner(symbol) {
+		h.Winner = symbol
+		h.WinningCells = h.findWinningPath(symbol)
+	}
+
+	h.CurrentTurn = (h.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+// swap applies the pie rule: the opening stone is replaced by a stone of the
+// second player's colour reflected across the long diagonal, so that each
+// player keeps the edges they connect.
+func (h *Hex) swap() error {
+	if !h.CanSwap {
+		return fmt.Errorf("swap is only allowed on move two")
+	}
+
+	var row, col int
+	for r := range h.Board {
+		for c := range h.Board[r] {
+			if h.Board[r][c] != "" {
+				row, col = r, c
+			}
+		}
+	}
+
+	h.clearBoard()
+	h.place(col, row, h.playerSymbols[1])
+	h.moves++
+	h.CanSwap = false
+	h.Swapped = true
+	h.CurrentTurn = (h.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+func (h *Hex) place(row, col int, symbol string) {
+	h.Board[row][col] = symbol
+	cell := row*h.Size + col
+
+	top, bottom, left, right := h.edgeNodes()
+	if symbol == h.playerSymbols[0] {
+		if row == 0 {
+			h.union(cell, top)
+		}
+		if row == h.Size-1 {
+			h.union(cell, bottom)
+		}
+	} else {
+		if col == 0 {
+			h.union(cell, left)
+		}
+		if col == h.Size-1 {
+			h.union(cell, right)
+		}
+	}
+
+	for _, dir := range hexNeighbors {
+		r, c := row+dir[0], col+dir[1]
+		if h.inBounds(r, c) && h.Board[r][c] == symbol {
+			h.union(cell, r*h.Size+c)
+		}
+	}
+}
+
+func (h *Hex) checkWinner(symbol string) bool {
+	top, bottom, left, right := h.edgeNodes()
+	if symbol == h.playerSymbols[0] {
+		return h.find(top) == h.find(bottom)
+	}
+	return h.find(left) == h.find(right)
+}
+
+// findWinningPath returns the shortest chain of symbol's stones joining that
+// player's two edges. The connected group may contain dead branches, so the
+// path is found with a breadth-first search rather than read from the
+// union-find structure.
+func (h *Hex) findWinningPath(symbol string) [][2]int {
+	isStart := func(r, c int) bool {
+		if symbol == h.playerSymbols[0] {
+			return r == 0
+		}
+		return c == 0
+	}
+	isGoal := func(r, c int) bool {
+		if symbol == h.playerSymbols[0] {
+			return r == h.Size-1
+		}
+		return c == h.Size-1
+	}
+
+	prev := make([]int, h.Size*h.Size)
+	for i := range prev {
+		prev[i] = -2
+	}
+
+	var queue []int
+	for r := range h.Board {
+		for c := range h.Board[r] {
+			if h.Board[r][c] == symbol && isStart(r, c) {
+				prev[r*h.Size+c] = -1
+				queue = append(queue, r*h.Size+c)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		row, col := cell/h.Size, cell%h.Size
+
+		if isGoal(row, col) {
+			var path [][2]int
+			for ; cell != -1; cell = prev[cell] {
+				path = append(path, [2]int{cell / h.Size, cell % h.Size})
+			}
+			return path
+		}
+
+		for _, dir := range hexNeighbors {
+			r, c := row+dir[0], col+dir[1]
+			if !h.inBounds(r, c) || h.Board[r][c] != symbol {
+				continue
+			}
+			next := r*h.Size + c
+			if prev[next] != -2 {
+				continue
+			}
+			prev[next] = cell
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+func (h *Hex) inBounds(row, col int) bool {
+	return row >= 0 && row < h.Size && col >= 0 && col < h.Size
+}
+
+// edgeNodes returns the union-find indices of the four virtual edge nodes,
+// which sit after the Size*Size board cells.
+func (h *Hex) edgeNodes() (top, bottom, left, right int) {
+	n := h.Size * h.Size
+	return n, n + 1, n + 2, n + 3
+}
+
+func (h *Hex) find(x int) int {
+	for h.parent[x] != x {
+		h.parent[x] = h.parent[h.parent[x]]
+		x = h.parent[x]
+	}
+	return x
+}
+
+func (h *Hex) union(a, b int) {
+	rootA, rootB := h.find(a), h.find(b)
+	if rootA != rootB {
+		h.parent[rootA] = rootB
+	}
+}
+
+func (h *Hex) clearBoard() {
+	h.Board = make([][]string, h.Size)
+	for i := range h.Board {
+		h.Board[i] = make([]string, h.Size)
+	}
+	h.parent = make([]int, h.Size*h.Size+4)
+	for i := range h.parent {
+		h.parent[i] = i
+	}
+}
+
+func (h *Hex) GetGameState() any {
+	return h
+}
+
+func (h *Hex) IsGameOver() bool {
+	return h.Winner != ""
+}
+
+func (h *Hex) GetWinner() string {
+	return h.Winner
+}
+
+func (h *Hex) Reset() {
+	h.clearBoard()
+	h.CurrentTurn = 0
+	h.Winner = ""
+	h.WinningCells = nil
+	h.CanSwap = false
+	h.Swapped = false
+	h.playerSymbols = [2]string{"R", "B"}
+	h.moves = 0
+}
+
+type hexPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+	Moves         int       `json:"moves"`
+	Parent        []int     `json:"parent"`
+}
+
+func (h *Hex) Clone() Game {
+	cloned := *h
+	cloned.Board = cloneGrid(h.Board)
+	cloned.WinningCells = slices.Clone(h.WinningCells)
+	cloned.parent = slices.Clone(h.parent)
+	return &cloned
+}
+
+func (h *Hex) MarshalState() ([]byte, error) {
+	return marshalState(h, hexPrivate{PlayerSymbols: h.playerSymbols, Moves: h.moves, Parent: h.parent})
+}
+
+func (h *Hex) UnmarshalState(data []byte) error {
+	var restored Hex
+	var private hexPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.moves = private.Moves
+	restored.parent = private.Parent
+	*h = restored
+	return nil
+}
+
+// afterSetup rebuilds the union-find tree from the stones on the board and
+// ends the game if the last player to move joined their edges. The swap is
+// only offered after a real first move.
+func (h *Hex) afterSetup() {
+	board := h.Board
+	h.clearBoard()
+	h.moves = 0
+	for r := range board {
+		for c, cell := range board[r] {
+			if cell == h.playerSymbols[0] || cell == h.playerSymbols[1] {
+				h.place(r, c, cell)
+				h.moves++
+			}
+		}
+	}
+	h.CanSwap = false
+
+	previous := h.playerSymbols[1-h.CurrentTurn]
+	if h.checkWinner(previous) {
+		h.Winner = previous
+		h.WinningCells = h.findWinningPath(previous)
+	}
+}
+
+// ValidatePosition takes the stones as placed without a swap, so the first
+// player is level or one stone ahead.
+func (h *Hex) ValidatePosition() error {
+	cells := slices.Concat(h.Board...)
+	if err := checkPieces(cells, h.playerSymbols[:]...); err != nil {
+		return err
+	}
+	if h.findWinningPath(h.playerSymbols[h.CurrentTurn]) != nil {
+		return fmt.Errorf("the player to move cannot have joined their edges already")
+	}
+	return checkAlternation([2]int{
+		countPieces(cells, h.playerSymbols[0]),
+		countPieces(cells, h.playerSymbols[1]),
+	}, h.CurrentTurn)
+}
+
+func (h *Hex) CurrentPlayer() int {
+	return h.CurrentTurn
+}
+
+func (h *Hex) LegalMoves() []any {
+	if h.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	if h.CanSwap {
+		moves = append(moves, map[string]any{"type": "swap"})
+	}
+	for r := range h.Board {
+		for c := range h.Board[r] {
+			if h.Board[r][c] == "" {
+				moves = append(moves, map[string]any{"row": float64(r), "col": float64(c)})
+			}
+		}
+	}
+	return moves
+}
+
+func (h *Hex) PlayerSymbol(playerIndex int) string {
+	return h.playerSymbols[playerIndex]
+}
+
+func (h *Hex) PositionKey() string {
+	return stateKey(h)
+}
+
+// FormatMove names cells by column letter and row number from the top-left
+// corner, as in "c5", and writes the pie rule as "swap".
+func (h *Hex) FormatMove(move any) (string, error) {
+	if kind, err := moveType(move); err != nil || kind == "swap" {
+		return kind, err
+	}
+	row, err := moveField(move, "row")
+	if err != nil {
+		return "", err
+	}
+	col, err := moveField(move, "col")
+	if err != nil {
+		return "", err
+	}
+	return cellName(row, col), nil
+}
+
+func (h *Hex) ParseMove(text string) (any, error) {
+	if strings.ToLower(text) == "swap" {
+		return map[string]any{"type": "swap"}, nil
+	}
+	row, col, err := parseCellName(text)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"row": float64(row), "col": float64(col)}, nil
+}
@@ -0,0 +1,120 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeHexMovePayload(row int, col int) any {
+	return map[string]any{"row": float64(row), "col": float64(col)}
+}
+
+func newTestHex(t *testing.T, size int) *Hex {
+	t.Helper()
+	options, _ := json.Marshal(map[string]int{"size": size})
+	game, err := NewHex(options)
+	if err != nil {
+		t.Fatalf("Expected no error creating hex game, but got %v", err)
+	}
+	return game.(*Hex)
+}
+
+func TestHex_DefaultSize(t *testing.T) {
+	game, err := NewHex(nil)
+	if err != nil {
+		t.Fatalf("Expected no error creating hex game, but got %v", err)
+	}
+
+	h := game.(*Hex)
+	if h.Size != 11 || len(h.Board) != 11 || len(h.Board[0]) != 11 {
+		t.Errorf("Expected an 11x11 board, but got size %d", h.Size)
+	}
+}
+
+func TestHex_InvalidSize(t *testing.T) {
+	_, err := NewHex(json.RawMessage(`{"size": 2}`))
+	if err == nil {
+		t.Fatal("Expected an error for a board that is too small, but got nil")
+	}
+}
+
+func TestHex_Swap(t *testing.T) {
+	h := newTestHex(t, 5)
+
+	if err := h.HandleMove(0, makeHexMovePayload(1, 3)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+	if !h.CanSwap {
+		t.Fatal("Expected swap to be available on move two")
+	}
+
+	if err := h.HandleMove(1, map[string]any{"type": "swap"}); err != nil {
+		t.Fatalf("Expected no error when swapping, but got %v", err)
+	}
+
+	if h.Board[1][3] != "" {
+		t.Errorf("Expected cell 1,3 to be empty after swap, but got '%s'", h.Board[1][3])
+	}
+	if h.Board[3][1] != "B" {
+		t.Errorf("Expected cell 3,1 to be 'B' after swap, but got '%s'", h.Board[3][1])
+	}
+	if h.CurrentTurn != 0 {
+		t.Errorf("Expected CurrentTurn to be 0 after swap, but got %d", h.CurrentTurn)
+	}
+
+	h.HandleMove(0, makeHexMovePayload(0, 0))
+	err := h.HandleMove(1, map[string]any{"type": "swap"})
+	if err == nil {
+		t.Fatal("Expected an error when swapping after move two, but got nil")
+	}
+}
+
+func TestHex_WinCondition(t *testing.T) {
+	h := newTestHex(t, 5)
+
+	// R builds a bent chain from top to bottom while B plays on the left edge.
+	moves := []struct {
+		playerIndex int
+		row, col    int
+	}{
+		{0, 0, 2}, {1, 0, 0},
+		{0, 1, 2}, {1, 1, 0},
+		{0, 2, 1}, {1, 2, 0},
+		{0, 3, 1}, {1, 3, 0},
+		{0, 1, 3}, {1, 4, 4},
+	}
+
+	for _, move := range moves {
+		if err := h.HandleMove(move.playerIndex, makeHexMovePayload(move.row, move.col)); err != nil {
+			t.Fatalf("Move sequence failed at player %d, cell %d,%d: %v", move.playerIndex, move.row, move.col, err)
+		}
+	}
+	if h.IsGameOver() {
+		t.Fatal("Expected game not to be over yet")
+	}
+
+	if err := h.HandleMove(0, makeHexMovePayload(4, 1)); err != nil {
+		t.Fatalf("Expected no error for the winning move, but got %v", err)
+	}
+
+	if h.GetWinner() != "R" {
+		t.Errorf("Expected winner to be 'R', but got '%s'", h.GetWinner())
+	}
+	// The dead branch at 1,3 is not part of the winning path.
+	if len(h.WinningCells) != 5 {
+		t.Errorf("Expected a winning path of 5 cells, but got %v", h.WinningCells)
+	}
+}
+
+func TestHex_Reset(t *testing.T) {
+	h := newTestHex(t, 7)
+	h.HandleMove(0, makeHexMovePayload(3, 3))
+	h.Reset()
+
+	if h.Size != 7 || len(h.Board) != 7 {
+		t.Errorf("Expected size 7 to survive reset, but got %d", h.Size)
+	}
+	if h.Board[3][3] != "" || h.CanSwap || h.moves != 0 {
+		t.Error("Expected board to be cleared after reset")
+	}
+}
@@ -0,0 +1,92 @@
+package games
+
+import "fmt"
+
+// History wraps a game with undo and redo stacks. It keeps a MarshalState
+// snapshot from before every accepted move, so taking a move back restores
+// everything the game tracks, including scores, winning cells and unexported
+// counters, without each game having to know how to invert its own moves.
+type History struct {
+	game Game
+	undo [][]byte
+	redo [][]byte
+}
+
+func NewHistory(game Game) *History {
+	return &History{game: game}
+}
+
+// Game returns the wrapped game. Moves made on it directly bypass the history.
+func (h *History) Game() Game {
+	return h.game
+}
+
+// HandleMove plays a move and records it. Any moves that were undone are
+// dropped, since they no longer follow from the current position.
+func (h *History) HandleMove(playerIndex int, move any) error {
+	snapshot, err := h.game.MarshalState()
+	if err != nil {
+		return err
+	}
+	if err := h.game.HandleMove(playerIndex, move); err != nil {
+		return err
+	}
+	h.undo = append(h.undo, snapshot)
+	h.redo = nil
+	return nil
+}
+
+// Undo takes back the last n moves.
+func (h *History) Undo(n int) error {
+	if n < 1 || n > len(h.undo) {
+		return fmt.Errorf("cannot undo %d moves, only %d can be taken back", n, len(h.undo))
+	}
+	return h.step(&h.undo, &h.redo, n)
+}
+
+// Redo replays the last n undone moves.
+func (h *History) Redo(n int) error {
+	if n < 1 || n > len(h.redo) {
+		return fmt.Errorf("cannot redo %d moves, only %d can be replayed", n, len(h.redo))
+	}
+	return h.step(&h.redo, &h.undo, n)
+}
+
+// step moves n snapshots from one stack to the other, restoring the game to
+// the nth one popped. The current state is pushed first so that the move can
+// be stepped over again in the other direction.
+func (h *History) step(from, to *[][]byte, n int) error {
+	current, err := h.game.MarshalState()
+	if err != nil {
+		return err
+	}
+
+	target := (*from)[len(*from)-n]
+	if err := h.game.UnmarshalState(target); err != nil {
+		return err
+	}
+
+	*to = append(*to, current)
+	for i := len(*from) - 1; i > len(*from)-n; i-- {
+		*to = append(*to, (*from)[i])
+	}
+	*from = (*from)[:len(*from)-n]
+	return nil
+}
+
+// UndoCount is the number of moves that can be taken back.
+func (h *History) UndoCount() int {
+	return len(h.undo)
+}
+
+// RedoCount is the number of undone moves that can be replayed.
+func (h *History) RedoCount() int {
+	return len(h.redo)
+}
+
+// Reset restarts the game and forgets its history.
+func (h *History) Reset() {
+	h.game.Reset()
+	h.undo = nil
+	h.redo = nil
+}
@@ -0,0 +1,253 @@
+package games
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+)
+
+func randomSquare(r *rand.Rand, size int) [2]int {
+	return [2]int{r.IntN(size), r.IntN(size)}
+}
+
+// randomStep moves from square by up to maxDistance in one of the eight
+// queen directions. The result may be off the board.
+func randomStep(r *rand.Rand, from [2]int, maxDistance int) [2]int {
+	dir := queenDirections[r.IntN(len(queenDirections))]
+	distance := 1 + r.IntN(maxDistance)
+	return [2]int{from[0] + dir[0]*distance, from[1] + dir[1]*distance}
+}
+
+// randomMoveCandidates produces moves of the right shape for each game with
+// default options. Most of them are illegal in any given position; random
+// playouts keep drawing until the game accepts one.
+var randomMoveCandidates = map[string]func(r *rand.Rand) any{
+	"tic-tac-toe": func(r *rand.Rand) any {
+		return makeTicTacToeMovePayload(r.IntN(9))
+	},
+	"domineering": func(r *rand.Rand) any {
+		return makeDomineeringMovePayload(r.IntN(8), r.IntN(8))
+	},
+	"connect-four": func(r *rand.Rand) any {
+		return makeConnectFourMovePayload(r.IntN(7))
+	},
+	"dots-and-boxes": func(r *rand.Rand) any {
+		lineType := "h"
+		if r.IntN(2) == 0 {
+			lineType = "v"
+		}
+		return makeDotsAndBoxesMovePayload(lineType, r.IntN(5), r.IntN(5))
+	},
+	"nim": func(r *rand.Rand) any {
+		return makeNimMovePayload(0, 1+r.IntN(3))
+	},
+	"hex": func(r *rand.Rand) any {
+		return makeHexMovePayload(r.IntN(hexDefaultSize), r.IntN(hexDefaultSize))
+	},
+	"go": func(r *rand.Rand) any {
+		if r.IntN(40) == 0 {
+			return map[string]any{"type": "pass"}
+		}
+		return makeGoMovePayload(r.IntN(goDefaultSize), r.IntN(goDefaultSize))
+	},
+	"checkers": func(r *rand.Rand) any {
+		from := randomSquare(r, 8)
+		distance := 1 + r.IntN(2)
+		dRow, dCol := 1-2*r.IntN(2), 1-2*r.IntN(2)
+		return makeCheckersMovePayload(from, [2]int{from[0] + dRow*distance, from[1] + dCol*distance})
+	},
+	"kalah": func(r *rand.Rand) any {
+		return makeMancalaMovePayload(r.IntN(6))
+	},
+	"oware": func(r *rand.Rand) any {
+		return makeMancalaMovePayload(r.IntN(6))
+	},
+	"ultimate-tic-tac-toe": func(r *rand.Rand) any {
+		return makeUltimateTicTacToeMovePayload(r.IntN(9), r.IntN(9))
+	},
+	"quoridor": func(r *rand.Rand) any {
+		if r.IntN(4) == 0 {
+			orientation := "h"
+			if r.IntN(2) == 0 {
+				orientation = "v"
+			}
+			return makeQuoridorWallPayload(r.IntN(quoridorSize-1), r.IntN(quoridorSize-1), orientation)
+		}
+		return makeQuoridorPawnPayload(r.IntN(quoridorSize), r.IntN(quoridorSize))
+	},
+	"battleship": func(r *rand.Rand) any {
+		if r.IntN(20) == 0 {
+			return makeBattleshipFleetPayload(r.IntN(6))
+		}
+		return makeBattleshipShotPayload(r.IntN(battleshipSize), r.IntN(battleshipSize))
+	},
+	"nine-mens-morris": func(r *rand.Rand) any {
+		switch r.IntN(3) {
+		case 0:
+			return makeMorrisPlacePayload(r.IntN(24))
+		case 1:
+			return makeMorrisMovePayload(r.IntN(24), r.IntN(24))
+		default:
+			return makeMorrisRemovePayload(r.IntN(24))
+		}
+	},
+	"amazons": func(r *rand.Rand) any {
+		from := randomSquare(r, 10)
+		to := randomStep(r, from, 9)
+		return makeAmazonsMovePayload(from, to, randomStep(r, to, 9))
+	},
+	"breakthrough": func(r *rand.Rand) any {
+		from := randomSquare(r, 8)
+		return makeBreakthroughMovePayload(from, [2]int{from[0] + 1 - 2*r.IntN(2), from[1] + r.IntN(3) - 1})
+	},
+	"lines-of-action": func(r *rand.Rand) any {
+		from := randomSquare(r, 8)
+		return makeLinesOfActionMovePayload(from, randomStep(r, from, 7))
+	},
+	"santorini": func(r *rand.Rand) any {
+		switch r.IntN(4) {
+		case 0:
+			return makeSantoriniPlacePayload(r.IntN(5), r.IntN(5))
+		case 1:
+			return makeSantoriniMovePayload(r.IntN(2), r.IntN(5), r.IntN(5))
+		case 2:
+			return makeSantoriniBuildPayload(r.IntN(5), r.IntN(5), r.IntN(4) == 0)
+		default:
+			return map[string]any{"type": "end"}
+		}
+	},
+	"pentago": func(r *rand.Rand) any {
+		direction := "cw"
+		if r.IntN(2) == 0 {
+			direction = "ccw"
+		}
+		return makePentagoMovePayload(r.IntN(6), r.IntN(6), r.IntN(4), direction)
+	},
+}
+
+// playRandomMove draws candidates until the game accepts one for either
+// player. Candidates are tried on a clone first so that rejected moves never
+// touch the history. It reports false when nothing was found.
+func playRandomMove(t *testing.T, r *rand.Rand, h *History, candidates func(r *rand.Rand) any) bool {
+	t.Helper()
+	for range 20000 {
+		move := candidates(r)
+		playerIndex := r.IntN(2)
+		if h.Game().Clone().HandleMove(playerIndex, move) != nil {
+			continue
+		}
+		if err := h.HandleMove(playerIndex, move); err != nil {
+			t.Fatalf("Expected a move accepted by the clone to be accepted, but got %v", err)
+		}
+		return true
+	}
+	return false
+}
+
+func TestHistory_RandomPlayoutsUndoToInitialState(t *testing.T) {
+	for gameType, candidates := range randomMoveCandidates {
+		t.Run(gameType, func(t *testing.T) {
+			for seed := range uint64(3) {
+				r := rand.New(rand.NewPCG(seed, 42))
+				game, err := NewGame(gameType, nil)
+				if err != nil {
+					t.Fatalf("Expected no error creating %s, but got %v", gameType, err)
+				}
+				h := NewHistory(game)
+
+				states := [][]byte{mustMarshalState(t, game)}
+				for len(states) <= 80 && !game.IsGameOver() && playRandomMove(t, r, h, candidates) {
+					states = append(states, mustMarshalState(t, game))
+				}
+				if len(states) < 3 {
+					t.Fatalf("Expected the playout to make some moves, but it made %d", len(states)-1)
+				}
+
+				for i := len(states) - 2; i >= 0; i-- {
+					if err := h.Undo(1); err != nil {
+						t.Fatalf("Expected no error undoing, but got %v", err)
+					}
+					if got := mustMarshalState(t, game); !bytes.Equal(states[i], got) {
+						t.Fatalf("Expected undo to restore the state after move %d\nwant %s\ngot  %s", i, states[i], got)
+					}
+				}
+				if h.UndoCount() != 0 {
+					t.Errorf("Expected nothing left to undo, but got %d", h.UndoCount())
+				}
+
+				if err := h.Redo(len(states) - 1); err != nil {
+					t.Fatalf("Expected no error redoing, but got %v", err)
+				}
+				if got := mustMarshalState(t, game); !bytes.Equal(states[len(states)-1], got) {
+					t.Errorf("Expected redo to return to the final state\nwant %s\ngot  %s", states[len(states)-1], got)
+				}
+			}
+		})
+	}
+}
+
+func TestHistory_UndoSeveralMovesAtOnce(t *testing.T) {
+	game, _ := NewDotsAndBoxes(nil)
+	d := game.(*DotsAndBoxes)
+	h := NewHistory(game)
+
+	// Player 1 completes the top-left box and keeps the turn.
+	moves := []struct {
+		playerIndex int
+		lineType    string
+		row, col    int
+	}{
+		{0, "h", 0, 0}, {1, "h", 1, 0}, {0, "v", 0, 0}, {1, "v", 0, 1},
+	}
+	for _, move := range moves {
+		if err := h.HandleMove(move.playerIndex, makeDotsAndBoxesMovePayload(move.lineType, move.row, move.col)); err != nil {
+			t.Fatalf("Move sequence failed at %s %d,%d: %v", move.lineType, move.row, move.col, err)
+		}
+	}
+	if d.Scores[1] != 1 || d.CurrentTurn != 1 {
+		t.Fatalf("Expected player 1 to score and keep the turn, but got scores %v and turn %d", d.Scores, d.CurrentTurn)
+	}
+
+	if err := h.Undo(2); err != nil {
+		t.Fatalf("Expected no error undoing two moves, but got %v", err)
+	}
+	if d.Scores[1] != 0 || d.CurrentTurn != 0 || d.VLines[0][0] != "" {
+		t.Errorf("Expected the box and the turn to be taken back, but got scores %v and turn %d", d.Scores, d.CurrentTurn)
+	}
+	if h.RedoCount() != 2 {
+		t.Errorf("Expected 2 moves to redo, but got %d", h.RedoCount())
+	}
+
+	if err := h.Redo(1); err != nil {
+		t.Fatalf("Expected no error redoing, but got %v", err)
+	}
+	if d.VLines[0][0] == "" || d.CurrentTurn != 1 {
+		t.Errorf("Expected redo to replay the left side of the box, but got turn %d", d.CurrentTurn)
+	}
+
+	// A new move forgets whatever was left to redo.
+	if err := h.HandleMove(1, makeDotsAndBoxesMovePayload("h", 4, 3)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if h.RedoCount() != 0 {
+		t.Errorf("Expected a new move to clear the redo stack, but got %d", h.RedoCount())
+	}
+	if err := h.Redo(1); err == nil {
+		t.Error("Expected an error redoing with nothing to redo, but got nil")
+	}
+}
+
+func TestHistory_RejectedMoveIsNotRecorded(t *testing.T) {
+	game, _ := NewTicTacToe()
+	h := NewHistory(game)
+
+	if err := h.HandleMove(1, makeTicTacToeMovePayload(0)); err == nil {
+		t.Fatal("Expected an error for a move out of turn, but got nil")
+	}
+	if h.UndoCount() != 0 {
+		t.Errorf("Expected a rejected move to leave no history, but got %d", h.UndoCount())
+	}
+	if err := h.Undo(1); err == nil {
+		t.Error("Expected an error undoing with no moves made, but got nil")
+	}
+}
@@ -0,0 +1,373 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	linesOfActionDefaultSize = 8
+	linesOfActionMinSize     = 6
+	linesOfActionMaxSize     = 10
+)
+
+func init() {
+	RegisterGameWithOptions("lines-of-action", NewLinesOfAction)
+	RegisterMetadata("lines-of-action", linesOfActionMetadata)
+}
+
+var linesOfActionMetadata = Metadata{
+	DisplayName: "Lines of Action",
+	Description: "Move your pieces by the number of pieces on their line and bring them all together in one connected group.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "size", Label: "Board size", Type: OptionInt, Default: linesOfActionDefaultSize, Range: &Range{Min: linesOfActionMinSize, Max: linesOfActionMaxSize}},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"A piece moves in a straight line exactly as many squares as there are pieces on that line",
+		"A piece may jump over its own pieces but not over the opponent's",
+		"Landing on an opposing piece captures it",
+		"Connect all of your pieces into a single group to win",
+	},
+}
+
+// LinesOfAction starts with Black on the top and bottom rows and White on the
+// left and right columns, corners excluded. A piece moves in any of the eight
+// directions exactly as many squares as there are pieces on that whole line.
+// It may jump over its own pieces but not over enemy pieces, and captures by
+// landing on an enemy piece. A player whose pieces form a single connected
+// group wins; if a move connects both sides at once the mover wins.
+type LinesOfAction struct {
+	Size          int        `json:"size"`
+	Board         [][]string `json:"board"`
+	CurrentTurn   int        `json:"currentTurn"`
+	Winner        string     `json:"winner"`
+	LastMove      [][2]int   `json:"lastMove"`
+	playerSymbols [2]string
+}
+
+type linesOfActionOptions struct {
+	Size int `json:"size"`
+}
+
+func NewLinesOfAction(options json.RawMessage) (Game, error) {
+	opts := linesOfActionOptions{Size: linesOfActionDefaultSize}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Size < linesOfActionMinSize || opts.Size > linesOfActionMaxSize {
+		return nil, fmt.Errorf("lines of action board size must be between %d and %d", linesOfActionMinSize, linesOfActionMaxSize)
+	}
+
+	l := &LinesOfAction{Size: opts.Size}
+	l.Reset()
+	return l, nil
+}
+
+func (l *LinesOfAction) HandleMove(playerIndex int, move any) error {
+	if l.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != l.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	from, err := parseSquare(moveData["from"])
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	to, err := parseSquare(moveData["to"])
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+
+	if !l.inBounds(from[0], from[1]) || !l.inBounds(to[0], to[1]) {
+		return fmt.Errorf("out of bounds")
+	}
+
+	symbol := l.playerSymbols[playerIndex]
+	if l.Board[from[0]][from[1]] != symbol {
+		return fmt.Errorf("you must move one of your own pieces")
+	}
+
+	if !l.isValidMove(from, to) {
+		return fmt.Errorf("illegal move")
+	}
+
+	l.Board[from[0]][from[1]] = ""
+	l.Board[to[0]][to[1]] = symbol
+	l.LastMove = [][2]int{from, to}
+	l.judge(playerIndex)
+
+	l.CurrentTurn = (l.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+// judge ends the game after a move by playerIndex. A capture can connect
+// the opponent's remaining pieces, and an opponent left without a move
+// loses.
+func (l *LinesOfAction) judge(playerIndex int) {
+	symbol, opponent := l.playerSymbols[playerIndex], l.playerSymbols[1-playerIndex]
+	switch {
+	case l.isConnected(symbol):
+		l.Winner = symbol
+	case l.isConnected(opponent):
+		l.Winner = opponent
+	case !l.hasValidMoves(1 - playerIndex):
+		l.Winner = symbol
+	}
+}
+
+// isValidMove checks the distance and jumping rules for a move of the piece
+// standing on from.
+func (l *LinesOfAction) isValidMove(from, to [2]int) bool {
+	dRow, dCol := to[0]-from[0], to[1]-from[1]
+	if dRow == 0 && dCol == 0 {
+		return false
+	}
+	if dRow != 0 && dCol != 0 && abs(dRow) != abs(dCol) {
+		return false
+	}
+
+	stepRow, stepCol := sign(dRow), sign(dCol)
+	distance := max(abs(dRow), abs(dCol))
+	if distance != l.piecesOnLine(from, stepRow, stepCol) {
+		return false
+	}
+
+	symbol := l.Board[from[0]][from[1]]
+	for i := 1; i < distance; i++ {
+		cell := l.Board[from[0]+stepRow*i][from[1]+stepCol*i]
+		if cell != "" && cell != symbol {
+			return false
+		}
+	}
+
+	return l.Board[to[0]][to[1]] != symbol
+}
+
+// piecesOnLine counts the pieces of both colours on the full line through
+// from in the given direction, including the piece on from.
+func (l *LinesOfAction) piecesOnLine(from [2]int, stepRow, stepCol int) int {
+	count := 1
+	for _, dir := range [2]int{1, -1} {
+		r, c := from[0]+stepRow*dir, from[1]+stepCol*dir
+		for l.inBounds(r, c) {
+			if l.Board[r][c] != "" {
+				count++
+			}
+			r, c = r+stepRow*dir, c+stepCol*dir
+		}
+	}
+	return count
+}
+
+// isConnected reports whether all pieces of symbol form one group, counting
+// diagonal neighbours as connected.
+func (l *LinesOfAction) isConnected(symbol string) bool {
+	var start *[2]int
+	total := 0
+	for r := range l.Board {
+		for c := range l.Board[r] {
+			if l.Board[r][c] == symbol {
+				total++
+				if start == nil {
+					start = &[2]int{r, c}
+				}
+			}
+		}
+	}
+	if start == nil {
+		return false
+	}
+
+	visited := make([]bool, l.Size*l.Size)
+	visited[start[0]*l.Size+start[1]] = true
+	queue := [][2]int{*start}
+	reached := 0
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		reached++
+
+		for _, dir := range queenDirections {
+			r, c := cell[0]+dir[0], cell[1]+dir[1]
+			if !l.inBounds(r, c) || l.Board[r][c] != symbol || visited[r*l.Size+c] {
+				continue
+			}
+			visited[r*l.Size+c] = true
+			queue = append(queue, [2]int{r, c})
+		}
+	}
+
+	return reached == total
+}
+
+func (l *LinesOfAction) hasValidMoves(playerIndex int) bool {
+	symbol := l.playerSymbols[playerIndex]
+	for r := range l.Board {
+		for c := range l.Board[r] {
+			if l.Board[r][c] != symbol {
+				continue
+			}
+			for _, dir := range queenDirections {
+				distance := l.piecesOnLine([2]int{r, c}, dir[0], dir[1])
+				to := [2]int{r + dir[0]*distance, c + dir[1]*distance}
+				if l.inBounds(to[0], to[1]) && l.isValidMove([2]int{r, c}, to) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (l *LinesOfAction) inBounds(row, col int) bool {
+	return row >= 0 && row < l.Size && col >= 0 && col < l.Size
+}
+
+func (l *LinesOfAction) GetGameState() any {
+	return l
+}
+
+func (l *LinesOfAction) IsGameOver() bool {
+	return l.Winner != ""
+}
+
+func (l *LinesOfAction) GetWinner() string {
+	return l.Winner
+}
+
+func (l *LinesOfAction) Reset() {
+	l.playerSymbols = [2]string{"B", "W"}
+	l.Board = make([][]string, l.Size)
+	for r := range l.Board {
+		l.Board[r] = make([]string, l.Size)
+	}
+	for i := 1; i < l.Size-1; i++ {
+		l.Board[0][i] = l.playerSymbols[0]
+		l.Board[l.Size-1][i] = l.playerSymbols[0]
+		l.Board[i][0] = l.playerSymbols[1]
+		l.Board[i][l.Size-1] = l.playerSymbols[1]
+	}
+	l.CurrentTurn = 0
+	l.Winner = ""
+	l.LastMove = nil
+}
+
+type linesOfActionPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+}
+
+func (l *LinesOfAction) Clone() Game {
+	cloned := *l
+	cloned.Board = cloneGrid(l.Board)
+	cloned.LastMove = slices.Clone(l.LastMove)
+	return &cloned
+}
+
+func (l *LinesOfAction) MarshalState() ([]byte, error) {
+	return marshalState(l, linesOfActionPrivate{PlayerSymbols: l.playerSymbols})
+}
+
+func (l *LinesOfAction) UnmarshalState(data []byte) error {
+	var restored LinesOfAction
+	var private linesOfActionPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	*l = restored
+	return nil
+}
+
+// ValidatePosition requires both players to have pieces left and no more
+// than they start with.
+// afterSetup judges the position as if the last player to move had just
+// moved.
+func (l *LinesOfAction) afterSetup() {
+	l.judge(1 - l.CurrentTurn)
+}
+
+func (l *LinesOfAction) ValidatePosition() error {
+	cells := slices.Concat(l.Board...)
+	if err := checkPieces(cells, l.playerSymbols[:]...); err != nil {
+		return err
+	}
+	for _, symbol := range l.playerSymbols {
+		if count := countPieces(cells, symbol); count == 0 || count > 2*(l.Size-2) {
+			return fmt.Errorf("%s must have between 1 and %d pieces, but has %d", symbol, 2*(l.Size-2), count)
+		}
+	}
+	return nil
+}
+
+func (l *LinesOfAction) CurrentPlayer() int {
+	return l.CurrentTurn
+}
+
+func (l *LinesOfAction) LegalMoves() []any {
+	if l.IsGameOver() {
+		return nil
+	}
+	symbol := l.playerSymbols[l.CurrentTurn]
+	var moves []any
+	for r := range l.Board {
+		for c := range l.Board[r] {
+			if l.Board[r][c] != symbol {
+				continue
+			}
+			from := [2]int{r, c}
+			for _, dir := range queenDirections {
+				distance := l.piecesOnLine(from, dir[0], dir[1])
+				to := [2]int{r + dir[0]*distance, c + dir[1]*distance}
+				if l.inBounds(to[0], to[1]) && l.isValidMove(from, to) {
+					moves = append(moves, map[string]any{"from": squareValue(from), "to": squareValue(to)})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (l *LinesOfAction) PlayerSymbol(playerIndex int) string {
+	return l.playerSymbols[playerIndex]
+}
+
+func (l *LinesOfAction) PositionKey() string {
+	return stateKey(l)
+}
+
+// FormatMove names squares as in chess, with row 1 on player 0's side, as
+// in "b1-b3".
+func (l *LinesOfAction) FormatMove(move any) (string, error) {
+	from, err := moveSquare(move, "from")
+	if err != nil {
+		return "", err
+	}
+	to, err := moveSquare(move, "to")
+	if err != nil {
+		return "", err
+	}
+	return squareName(from, l.Size) + "-" + squareName(to, l.Size), nil
+}
+
+func (l *LinesOfAction) ParseMove(text string) (any, error) {
+	from, to, err := parseStep(strings.ToLower(text), l.Size)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"from": squareValue(from), "to": squareValue(to)}, nil
+}
@@ -0,0 +1,207 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeLinesOfActionMovePayload(from, to [2]int) any {
+	return map[string]any{
+		"from": []any{float64(from[0]), float64(from[1])},
+		"to":   []any{float64(to[0]), float64(to[1])},
+	}
+}
+
+func newEmptyLinesOfAction(t *testing.T, pieces map[[2]int]string) *LinesOfAction {
+	t.Helper()
+	game, err := NewLinesOfAction(nil)
+	if err != nil {
+		t.Fatalf("Expected no error creating lines of action game, but got %v", err)
+	}
+	l := game.(*LinesOfAction)
+	for r := range l.Board {
+		l.Board[r] = make([]string, l.Size)
+	}
+	for square, symbol := range pieces {
+		l.Board[square[0]][square[1]] = symbol
+	}
+	return l
+}
+
+func TestLinesOfAction_Options(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		wantErr bool
+		size    int
+	}{
+		{"default", "", false, 8},
+		{"small board", `{"size": 6}`, false, 6},
+		{"too small", `{"size": 5}`, true, 0},
+		{"too large", `{"size": 11}`, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewLinesOfAction(json.RawMessage(tt.options))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error for invalid options, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			l := game.(*LinesOfAction)
+			last := tt.size - 1
+			if len(l.Board) != tt.size || l.Board[0][0] != "" || l.Board[0][1] != "B" || l.Board[last][1] != "B" || l.Board[1][0] != "W" || l.Board[1][last] != "W" {
+				t.Errorf("Expected the standard %dx%d starting position", tt.size, tt.size)
+			}
+		})
+	}
+}
+
+func TestLinesOfAction_HandleMove(t *testing.T) {
+	tests := []struct {
+		name        string
+		playerIndex int
+		from, to    [2]int
+		wantErr     bool
+	}{
+		{"two pieces on the column", 0, [2]int{0, 1}, [2]int{2, 1}, false},
+		{"six pieces on the row", 0, [2]int{0, 1}, [2]int{0, 7}, false},
+		{"two pieces on the diagonal", 0, [2]int{0, 1}, [2]int{2, 3}, false},
+		{"too short", 0, [2]int{0, 1}, [2]int{1, 1}, true},
+		{"too long", 0, [2]int{0, 1}, [2]int{3, 1}, true},
+		{"not a line", 0, [2]int{0, 1}, [2]int{2, 2}, true},
+		{"onto own piece", 0, [2]int{0, 3}, [2]int{0, 6}, true},
+		{"opponent piece", 0, [2]int{1, 0}, [2]int{1, 2}, true},
+		{"out of turn", 1, [2]int{1, 0}, [2]int{1, 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, _ := NewLinesOfAction(nil)
+			l := game.(*LinesOfAction)
+
+			err := game.HandleMove(tt.playerIndex, makeLinesOfActionMovePayload(tt.from, tt.to))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if l.Board[tt.from[0]][tt.from[1]] != "" || l.Board[tt.to[0]][tt.to[1]] != "B" {
+				t.Error("Expected the piece to move to its destination")
+			}
+			if l.CurrentTurn != 1 {
+				t.Errorf("Expected CurrentTurn to be 1, but got %d", l.CurrentTurn)
+			}
+		})
+	}
+}
+
+func TestLinesOfAction_JumpingAndCapture(t *testing.T) {
+	tests := []struct {
+		name     string
+		pieces   map[[2]int]string
+		from, to [2]int
+		wantErr  bool
+	}{
+		{
+			name:   "jump over own piece",
+			pieces: map[[2]int]string{{4, 1}: "B", {4, 2}: "B", {4, 6}: "B", {0, 0}: "W", {7, 7}: "W"},
+			from:   [2]int{4, 1},
+			to:     [2]int{4, 4},
+		},
+		{
+			name:    "jump over enemy piece",
+			pieces:  map[[2]int]string{{4, 1}: "B", {4, 2}: "W", {0, 0}: "B", {7, 7}: "W"},
+			from:    [2]int{4, 1},
+			to:      [2]int{4, 3},
+			wantErr: true,
+		},
+		{
+			name:   "capture by landing",
+			pieces: map[[2]int]string{{4, 1}: "B", {4, 3}: "W", {0, 0}: "B", {7, 7}: "W"},
+			from:   [2]int{4, 1},
+			to:     [2]int{4, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newEmptyLinesOfAction(t, tt.pieces)
+
+			err := l.HandleMove(0, makeLinesOfActionMovePayload(tt.from, tt.to))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if l.Board[tt.to[0]][tt.to[1]] != "B" {
+				t.Errorf("Expected B on the destination, but got '%s'", l.Board[tt.to[0]][tt.to[1]])
+			}
+		})
+	}
+}
+
+func TestLinesOfAction_WinConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		pieces   map[[2]int]string
+		from, to [2]int
+		want     string
+	}{
+		{
+			name:   "mover connects",
+			pieces: map[[2]int]string{{3, 3}: "B", {3, 5}: "B", {0, 0}: "W", {7, 7}: "W"},
+			from:   [2]int{3, 5},
+			to:     [2]int{4, 4},
+			want:   "B",
+		},
+		{
+			name:   "both connect and the mover wins",
+			pieces: map[[2]int]string{{3, 3}: "B", {3, 5}: "B", {0, 0}: "W", {0, 1}: "W"},
+			from:   [2]int{3, 5},
+			to:     [2]int{4, 4},
+			want:   "B",
+		},
+		{
+			name:   "capture leaves the opponent connected",
+			pieces: map[[2]int]string{{4, 1}: "B", {0, 0}: "B", {4, 3}: "W", {7, 7}: "W"},
+			from:   [2]int{4, 1},
+			to:     [2]int{4, 3},
+			want:   "W",
+		},
+		{
+			name:   "nobody connected",
+			pieces: map[[2]int]string{{3, 0}: "B", {3, 5}: "B", {0, 0}: "W", {7, 7}: "W"},
+			from:   [2]int{3, 5},
+			to:     [2]int{4, 4},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newEmptyLinesOfAction(t, tt.pieces)
+
+			if err := l.HandleMove(0, makeLinesOfActionMovePayload(tt.from, tt.to)); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if winner := l.GetWinner(); winner != tt.want {
+				t.Errorf("Expected winner to be '%s', but got '%s'", tt.want, winner)
+			}
+		})
+	}
+}
@@ -0,0 +1,477 @@
+package games
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+const (
+	mancalaPits      = 6
+	mancalaStore     = mancalaPits
+	kalahDefaultSeed = 4
+	owareSeeds       = 4
+	owareWinningSeed = 25
+)
+
+const (
+	mancalaVariantKalah = "kalah"
+	mancalaVariantOware = "oware"
+)
+
+func init() {
+	RegisterGameWithOptions("kalah", NewKalah)
+	RegisterGame("oware", NewOware)
+	RegisterMetadata("kalah", kalahMetadata)
+	RegisterMetadata("oware", owareMetadata)
+}
+
+var kalahMetadata = Metadata{
+	DisplayName: "Kalah",
+	Description: "Sow seeds around the board into your store. Capture from empty pits and earn extra turns by ending in your store.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "seeds", Label: "Seeds per pit", Type: OptionInt, Default: kalahDefaultSeed, Range: &Range{Min: 3, Max: 6}},
+	},
+	TimeControl: quickTimeControl,
+	Rules: []string{
+		"Pick up all the seeds in one of your pits and sow them one by one counter-clockwise",
+		"Sowing passes through your own store but skips the opponent's",
+		"Ending in your store gives you another turn",
+		"Ending in an empty pit of yours captures it along with the seeds in the opposite pit, if there are any",
+		"When one side is empty the remaining seeds go to their owner, and the most seeds wins",
+	},
+}
+
+var owareMetadata = Metadata{
+	DisplayName: "Oware",
+	Description: "The West African sowing game. Capture seeds by ending your sowing on the opponent's side with two or three seeds.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: quickTimeControl,
+	Rules: []string{
+		"Pick up all the seeds in one of your pits and sow them one by one counter-clockwise",
+		"Ending on the opponent's side in a pit that now holds two or three captures it, along with the preceding pits that do",
+		"You must leave your opponent seeds to play whenever you can",
+		"The first player to capture more than half of the seeds wins",
+	},
+}
+
+// Mancala implements the Kalah and Oware sowing games. Each player owns a row
+// of six pits, numbered 0-5 in sowing order, and pit i faces the opponent's
+// pit 5-i. Positions in LastSown are [player, pit] pairs where pit 6 is the
+// player's store.
+type Mancala struct {
+	Variant       string              `json:"variant"`
+	Pits          [2][mancalaPits]int `json:"pits"`
+	Stores        [2]int              `json:"stores"`
+	CurrentTurn   int                 `json:"currentTurn"`
+	Winner        string              `json:"winner"`
+	LastSown      [][2]int            `json:"lastSown"`
+	LastCaptured  [][2]int            `json:"lastCaptured"`
+	seedsPerPit   int
+	playerSymbols [2]string
+	positions     map[mancalaPosition]bool
+}
+
+// mancalaPosition identifies a position for Oware's repetition rule.
+type mancalaPosition struct {
+	pits [2][mancalaPits]int
+	turn int
+}
+
+type kalahOptions struct {
+	Seeds int `json:"seeds"`
+}
+
+func NewKalah(options json.RawMessage) (Game, error) {
+	opts := kalahOptions{Seeds: kalahDefaultSeed}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	if opts.Seeds < 3 || opts.Seeds > 6 {
+		return nil, fmt.Errorf("seeds per pit must be between 3 and 6")
+	}
+
+	m := &Mancala{Variant: mancalaVariantKalah, seedsPerPit: opts.Seeds}
+	m.Reset()
+	return m, nil
+}
+
+func NewOware() (Game, error) {
+	m := &Mancala{Variant: mancalaVariantOware, seedsPerPit: owareSeeds}
+	m.Reset()
+	return m, nil
+}
+
+func (m *Mancala) HandleMove(playerIndex int, move any) error {
+	if m.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != m.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	pitFloat, ok := moveData["pit"].(float64)
+	if !ok {
+		return fmt.Errorf("pit must be a number")
+	}
+	pit := int(pitFloat)
+
+	if pit < 0 || pit >= mancalaPits {
+		return fmt.Errorf("pit out of bounds")
+	}
+
+	if m.Pits[playerIndex][pit] == 0 {
+		return fmt.Errorf("pit is empty")
+	}
+
+	if m.Variant == mancalaVariantOware && m.sideTotal(1-playerIndex) == 0 && !m.feeds(playerIndex, pit) {
+		return fmt.Errorf("you must give your opponent seeds to play")
+	}
+
+	m.LastSown = m.sow(playerIndex, pit)
+	m.LastCaptured = nil
+
+	if m.Variant == mancalaVariantKalah {
+		m.finishKalahMove(playerIndex)
+	} else {
+		m.finishOwareMove(playerIndex)
+	}
+
+	return nil
+}
+
+// sow distributes the seeds of the chosen pit counter-clockwise and returns
+// every position a seed was dropped into. Kalah sows into the player's own
+// store but skips the opponent's; Oware has no stores in the cycle and skips
+// the emptied pit on laps of twelve or more seeds.
+func (m *Mancala) sow(playerIndex, pit int) [][2]int {
+	var cycle [][2]int
+	for i := range mancalaPits {
+		cycle = append(cycle, [2]int{playerIndex, i})
+	}
+	if m.Variant == mancalaVariantKalah {
+		cycle = append(cycle, [2]int{playerIndex, mancalaStore})
+	}
+	for i := range mancalaPits {
+		cycle = append(cycle, [2]int{1 - playerIndex, i})
+	}
+
+	seeds := m.Pits[playerIndex][pit]
+	m.Pits[playerIndex][pit] = 0
+
+	var path [][2]int
+	for pos := pit; seeds > 0; {
+		pos = (pos + 1) % len(cycle)
+		if pos == pit && m.Variant == mancalaVariantOware {
+			continue
+		}
+		side, idx := cycle[pos][0], cycle[pos][1]
+		if idx == mancalaStore {
+			m.Stores[side]++
+		} else {
+			m.Pits[side][idx]++
+		}
+		path = append(path, cycle[pos])
+		seeds--
+	}
+	return path
+}
+
+// finishKalahMove applies captures, the extra-turn rule and the end of game
+// sweep after a Kalah sowing.
+func (m *Mancala) finishKalahMove(playerIndex int) {
+	last := m.LastSown[len(m.LastSown)-1]
+	side, idx := last[0], last[1]
+	opponent := 1 - playerIndex
+
+	if side == playerIndex && idx != mancalaStore && m.Pits[side][idx] == 1 {
+		opposite := mancalaPits - 1 - idx
+		if m.Pits[opponent][opposite] > 0 {
+			m.Stores[playerIndex] += m.Pits[opponent][opposite] + 1
+			m.Pits[opponent][opposite] = 0
+			m.Pits[side][idx] = 0
+			m.LastCaptured = [][2]int{{side, idx}, {opponent, opposite}}
+		}
+	}
+
+	if m.sideTotal(0) == 0 || m.sideTotal(1) == 0 {
+		m.collectRemaining()
+		m.determineWinner()
+		return
+	}
+
+	if side == playerIndex && idx == mancalaStore {
+		return
+	}
+	m.CurrentTurn = (m.CurrentTurn + 1) % 2
+}
+
+// finishOwareMove applies captures, the grand-slam and feeding rules and the
+// end of game conditions after an Oware sowing.
+func (m *Mancala) finishOwareMove(playerIndex int) {
+	last := m.LastSown[len(m.LastSown)-1]
+	opponent := 1 - playerIndex
+
+	if last[0] == opponent {
+		var captured [][2]int
+		total := 0
+		for i := last[1]; i >= 0; i-- {
+			seeds := m.Pits[opponent][i]
+			if seeds != 2 && seeds != 3 {
+				break
+			}
+			captured = append(captured, [2]int{opponent, i})
+			total += seeds
+		}
+
+		// A grand slam would leave the opponent without seeds, so the sowing
+		// stands but nothing is captured.
+		if total > 0 && total < m.sideTotal(opponent) {
+			for _, pos := range captured {
+				m.Pits[pos[0]][pos[1]] = 0
+			}
+			m.Stores[playerIndex] += total
+			m.LastCaptured = captured
+		}
+	}
+
+	m.CurrentTurn = opponent
+
+	if m.Stores[0] >= owareWinningSeed || m.Stores[1] >= owareWinningSeed ||
+		(m.Stores[0] == m.Stores[1] && m.Stores[0] == m.totalSeeds()/2) {
+		m.determineWinner()
+		return
+	}
+
+	position := mancalaPosition{pits: m.Pits, turn: m.CurrentTurn}
+	if !m.hasValidMoves(m.CurrentTurn) || m.positions[position] {
+		m.collectRemaining()
+		m.determineWinner()
+		return
+	}
+	m.positions[position] = true
+}
+
+// feeds reports whether sowing from pit reaches the opponent's side.
+func (m *Mancala) feeds(playerIndex, pit int) bool {
+	return m.Pits[playerIndex][pit] >= mancalaPits-pit
+}
+
+func (m *Mancala) hasValidMoves(playerIndex int) bool {
+	mustFeed := m.sideTotal(1-playerIndex) == 0
+	for pit := range mancalaPits {
+		if m.Pits[playerIndex][pit] > 0 && (!mustFeed || m.feeds(playerIndex, pit)) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectRemaining moves the seeds left on each side into that side's store.
+func (m *Mancala) collectRemaining() {
+	for side := range m.Pits {
+		m.Stores[side] += m.sideTotal(side)
+		m.Pits[side] = [mancalaPits]int{}
+	}
+}
+
+func (m *Mancala) sideTotal(side int) int {
+	total := 0
+	for _, seeds := range m.Pits[side] {
+		total += seeds
+	}
+	return total
+}
+
+func (m *Mancala) totalSeeds() int {
+	return 2 * mancalaPits * m.seedsPerPit
+}
+
+func (m *Mancala) determineWinner() {
+	if m.Stores[0] > m.Stores[1] {
+		m.Winner = m.playerSymbols[0]
+	} else if m.Stores[1] > m.Stores[0] {
+		m.Winner = m.playerSymbols[1]
+	} else {
+		m.Winner = "draw"
+	}
+}
+
+func (m *Mancala) GetGameState() any {
+	return m
+}
+
+func (m *Mancala) IsGameOver() bool {
+	return m.Winner != ""
+}
+
+func (m *Mancala) GetWinner() string {
+	return m.Winner
+}
+
+func (m *Mancala) Reset() {
+	for side := range m.Pits {
+		for pit := range m.Pits[side] {
+			m.Pits[side][pit] = m.seedsPerPit
+		}
+	}
+	m.Stores = [2]int{}
+	m.CurrentTurn = 0
+	m.Winner = ""
+	m.LastSown = nil
+	m.LastCaptured = nil
+	m.playerSymbols = [2]string{"P1", "P2"}
+	m.positions = map[mancalaPosition]bool{{pits: m.Pits}: true}
+}
+
+// mancalaPrivate lists the repetition history as a sorted slice, since
+// mancalaPosition keys cannot be encoded as a JSON object.
+type mancalaPrivate struct {
+	SeedsPerPit   int                    `json:"seedsPerPit"`
+	PlayerSymbols [2]string              `json:"playerSymbols"`
+	Positions     []mancalaSavedPosition `json:"positions"`
+}
+
+type mancalaSavedPosition struct {
+	Pits [2][mancalaPits]int `json:"pits"`
+	Turn int                 `json:"turn"`
+}
+
+func (m *Mancala) Clone() Game {
+	cloned := *m
+	cloned.LastSown = slices.Clone(m.LastSown)
+	cloned.LastCaptured = slices.Clone(m.LastCaptured)
+	cloned.positions = maps.Clone(m.positions)
+	return &cloned
+}
+
+func (m *Mancala) MarshalState() ([]byte, error) {
+	positions := make([]mancalaSavedPosition, 0, len(m.positions))
+	for position := range m.positions {
+		positions = append(positions, mancalaSavedPosition{Pits: position.pits, Turn: position.turn})
+	}
+	slices.SortFunc(positions, func(a, b mancalaSavedPosition) int {
+		return cmp.Or(
+			slices.Compare(a.Pits[0][:], b.Pits[0][:]),
+			slices.Compare(a.Pits[1][:], b.Pits[1][:]),
+			cmp.Compare(a.Turn, b.Turn),
+		)
+	})
+
+	return marshalState(m, mancalaPrivate{
+		SeedsPerPit:   m.seedsPerPit,
+		PlayerSymbols: m.playerSymbols,
+		Positions:     positions,
+	})
+}
+
+func (m *Mancala) UnmarshalState(data []byte) error {
+	var restored Mancala
+	var private mancalaPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.seedsPerPit = private.SeedsPerPit
+	restored.playerSymbols = private.PlayerSymbols
+	restored.positions = make(map[mancalaPosition]bool, len(private.Positions))
+	for _, position := range private.Positions {
+		restored.positions[mancalaPosition{pits: position.Pits, turn: position.Turn}] = true
+	}
+	*m = restored
+	return nil
+}
+
+// afterSetup starts the repetition history from the set-up position and
+// ends the game if it is over by the rules of the variant.
+func (m *Mancala) afterSetup() {
+	m.positions = map[mancalaPosition]bool{{pits: m.Pits, turn: m.CurrentTurn}: true}
+
+	switch {
+	case m.Variant == mancalaVariantKalah && (m.sideTotal(0) == 0 || m.sideTotal(1) == 0):
+		m.collectRemaining()
+		m.determineWinner()
+	case m.Variant == mancalaVariantOware && (m.Stores[0] >= owareWinningSeed || m.Stores[1] >= owareWinningSeed ||
+		(m.Stores[0] == m.Stores[1] && m.Stores[0] == m.totalSeeds()/2)):
+		m.determineWinner()
+	case m.Variant == mancalaVariantOware && !m.hasValidMoves(m.CurrentTurn):
+		m.collectRemaining()
+		m.determineWinner()
+	}
+}
+
+// ValidatePosition checks that no seeds were added or lost: the pits and
+// stores must hold all the seeds of the game between them.
+func (m *Mancala) ValidatePosition() error {
+	total := 0
+	for side := range m.Pits {
+		for _, seeds := range m.Pits[side] {
+			if seeds < 0 {
+				return fmt.Errorf("pits cannot hold a negative number of seeds")
+			}
+			total += seeds
+		}
+		if m.Stores[side] < 0 {
+			return fmt.Errorf("stores cannot hold a negative number of seeds")
+		}
+		total += m.Stores[side]
+	}
+	if total != m.totalSeeds() {
+		return fmt.Errorf("the board must hold %d seeds, but holds %d", m.totalSeeds(), total)
+	}
+	return nil
+}
+
+func (m *Mancala) CurrentPlayer() int {
+	return m.CurrentTurn
+}
+
+func (m *Mancala) LegalMoves() []any {
+	if m.IsGameOver() {
+		return nil
+	}
+	mustFeed := m.Variant == mancalaVariantOware && m.sideTotal(1-m.CurrentTurn) == 0
+	var moves []any
+	for pit := range mancalaPits {
+		if m.Pits[m.CurrentTurn][pit] > 0 && (!mustFeed || m.feeds(m.CurrentTurn, pit)) {
+			moves = append(moves, map[string]any{"pit": float64(pit)})
+		}
+	}
+	return moves
+}
+
+func (m *Mancala) PlayerSymbol(playerIndex int) string {
+	return m.playerSymbols[playerIndex]
+}
+
+func (m *Mancala) PositionKey() string {
+	return stateKey(m)
+}
+
+// FormatMove numbers the mover's pits from 1 to 6 in sowing order.
+func (m *Mancala) FormatMove(move any) (string, error) {
+	pit, err := moveField(move, "pit")
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(pit + 1), nil
+}
+
+func (m *Mancala) ParseMove(text string) (any, error) {
+	pit, err := strconv.Atoi(text)
+	if err != nil || pit < 1 || pit > mancalaPits {
+		return nil, fmt.Errorf("%q is not a pit from 1 to %d", text, mancalaPits)
+	}
+	return map[string]any{"pit": float64(pit - 1)}, nil
+}
@@ -0,0 +1,117 @@
+package games
+
+import (
+	"testing"
+)
+
+func makeMancalaMovePayload(pit int) any {
+	return map[string]any{"pit": float64(pit)}
+}
+
+func TestKalah_ExtraTurn(t *testing.T) {
+	game, _ := NewKalah(nil)
+	m := game.(*Mancala)
+
+	// Four seeds from pit 2 end in the store.
+	if err := m.HandleMove(0, makeMancalaMovePayload(2)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+
+	if m.Stores[0] != 1 {
+		t.Errorf("Expected store to have 1 seed, but got %d", m.Stores[0])
+	}
+	if m.CurrentTurn != 0 {
+		t.Errorf("Expected player 0 to keep the turn, but got %d", m.CurrentTurn)
+	}
+	if len(m.LastSown) != 4 || m.LastSown[3] != [2]int{0, 6} {
+		t.Errorf("Expected last sown path to end in the store, but got %v", m.LastSown)
+	}
+}
+
+func TestKalah_Capture(t *testing.T) {
+	game, _ := NewKalah(nil)
+	m := game.(*Mancala)
+	m.Pits = [2][6]int{
+		{1, 0, 0, 0, 0, 1},
+		{2, 2, 2, 2, 5, 2},
+	}
+
+	if err := m.HandleMove(0, makeMancalaMovePayload(0)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+
+	if m.Stores[0] != 6 {
+		t.Errorf("Expected store to hold 6 seeds after the capture, but got %d", m.Stores[0])
+	}
+	if m.Pits[0][1] != 0 || m.Pits[1][4] != 0 {
+		t.Errorf("Expected both capture pits to be emptied, but got %v", m.Pits)
+	}
+	if m.CurrentTurn != 1 {
+		t.Errorf("Expected CurrentTurn to be 1, but got %d", m.CurrentTurn)
+	}
+}
+
+func TestKalah_InvalidSeeds(t *testing.T) {
+	if _, err := NewKalah([]byte(`{"seeds": 12}`)); err == nil {
+		t.Fatal("Expected an error for too many seeds per pit, but got nil")
+	}
+}
+
+func TestOware_Capture(t *testing.T) {
+	game, _ := NewOware()
+	m := game.(*Mancala)
+	m.Pits = [2][6]int{
+		{0, 0, 0, 0, 0, 2},
+		{1, 2, 4, 1, 0, 0},
+	}
+
+	if err := m.HandleMove(0, makeMancalaMovePayload(5)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+
+	// The last seed lands in pit 1 making 3; pit 0 also holds 2.
+	if m.Stores[0] != 5 {
+		t.Errorf("Expected 5 captured seeds, but got %d", m.Stores[0])
+	}
+	if m.Pits[1][0] != 0 || m.Pits[1][1] != 0 || m.Pits[1][2] != 4 {
+		t.Errorf("Unexpected pits after capture: %v", m.Pits)
+	}
+}
+
+func TestOware_GrandSlam(t *testing.T) {
+	game, _ := NewOware()
+	m := game.(*Mancala)
+	m.Pits = [2][6]int{
+		{0, 0, 0, 4, 0, 2},
+		{1, 2, 0, 0, 0, 0},
+	}
+
+	if err := m.HandleMove(0, makeMancalaMovePayload(5)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+
+	if m.Stores[0] != 0 {
+		t.Errorf("Expected a grand slam to capture nothing, but got %d", m.Stores[0])
+	}
+	if m.Pits[1][0] != 2 || m.Pits[1][1] != 3 {
+		t.Errorf("Expected sown seeds to stay on the board, but got %v", m.Pits)
+	}
+}
+
+func TestOware_MustFeed(t *testing.T) {
+	game, _ := NewOware()
+	m := game.(*Mancala)
+	m.Pits = [2][6]int{
+		{1, 0, 0, 0, 2, 0},
+		{0, 0, 0, 0, 0, 0},
+	}
+
+	err := m.HandleMove(0, makeMancalaMovePayload(0))
+	if err == nil {
+		t.Fatal("Expected an error when not feeding the opponent, but got nil")
+	}
+
+	if err := m.HandleMove(0, makeMancalaMovePayload(4)); err != nil {
+		t.Fatalf("Expected no error for a feeding move, but got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package games
+
+// Metadata describes a game for clients: how it is presented, how many
+// players it takes, what rooms can configure and how it is played. It is
+// served as the game catalog so that new games show up without client
+// changes.
+type Metadata struct {
+	Type        string      `json:"type"`
+	DisplayName string      `json:"displayName"`
+	Description string      `json:"description"`
+	MinPlayers  int         `json:"minPlayers"`
+	MaxPlayers  int         `json:"maxPlayers"`
+	Options     []Option    `json:"options"`
+	TimeControl TimeControl `json:"timeControl"`
+	Rules       []string    `json:"rules"`
+}
+
+// OptionType is the kind of value a room option takes.
+type OptionType string
+
+const (
+	OptionInt    OptionType = "int"
+	OptionNumber OptionType = "number"
+	OptionBool   OptionType = "bool"
+	OptionChoice OptionType = "choice"
+	// OptionIntList and OptionChoiceList are lists whose items follow the
+	// option's range or choices.
+	OptionIntList    OptionType = "intList"
+	OptionChoiceList OptionType = "choiceList"
+)
+
+// Option describes one field of a game's room options. An optional field
+// with no default is worked out from the other options when left unset.
+type Option struct {
+	Name     string     `json:"name"`
+	Label    string     `json:"label"`
+	Type     OptionType `json:"type"`
+	Default  any        `json:"default"`
+	Optional bool       `json:"optional,omitempty"`
+	Range    *Range     `json:"range,omitempty"`
+	Choices  []any      `json:"choices,omitempty"`
+	MinItems int        `json:"minItems,omitempty"`
+	MaxItems int        `json:"maxItems,omitempty"`
+}
+
+// Range bounds a numeric option, or the items of a list. Step is the
+// granularity of number options; zero means any value.
+type Range struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step,omitempty"`
+}
+
+// TimeControl is a game's suggested clock: the time each player starts
+// with and the time added after each of their moves.
+type TimeControl struct {
+	InitialSeconds   int `json:"initialSeconds"`
+	IncrementSeconds int `json:"incrementSeconds"`
+}
+
+var gameMetadata = make(map[string]Metadata)
+
+// RegisterMetadata describes a registered game type. The type is filled in
+// from gameType.
+func RegisterMetadata(gameType string, meta Metadata) {
+	meta.Type = gameType
+	gameMetadata[gameType] = meta
+}
+
+// GameMetadata returns the description of a registered game. Games that
+// were never described get a plain two-player entry named after the type.
+func GameMetadata(gameType string) (Metadata, bool) {
+	if _, ok := gameFactories[gameType]; !ok {
+		return Metadata{}, false
+	}
+	if meta, ok := gameMetadata[gameType]; ok {
+		return meta, true
+	}
+	return Metadata{Type: gameType, DisplayName: gameType, MinPlayers: 2, MaxPlayers: 2}, true
+}
+
+// Catalog describes every registered game in alphabetical order of type.
+func Catalog() []Metadata {
+	types := GameTypes()
+	catalog := make([]Metadata, len(types))
+	for i, gameType := range types {
+		catalog[i], _ = GameMetadata(gameType)
+	}
+	return catalog
+}
+
+// Suggested clocks by how long a game usually runs.
+var (
+	quickTimeControl  = TimeControl{InitialSeconds: 60, IncrementSeconds: 2}
+	normalTimeControl = TimeControl{InitialSeconds: 300, IncrementSeconds: 3}
+	longTimeControl   = TimeControl{InitialSeconds: 900, IncrementSeconds: 10}
+)
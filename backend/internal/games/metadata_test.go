@@ -0,0 +1,128 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// optionsWith builds room options from the defaults of meta, with the given
+// values on top.
+func optionsWith(t *testing.T, meta Metadata, values map[string]any) json.RawMessage {
+	t.Helper()
+	options := make(map[string]any)
+	for _, option := range meta.Options {
+		if option.Default != nil {
+			options[option.Name] = option.Default
+		}
+	}
+	for name, value := range values {
+		options[name] = value
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		t.Fatalf("Expected options to marshal, but got %v", err)
+	}
+	return data
+}
+
+func TestCatalog_DescribesEveryGame(t *testing.T) {
+	catalog := Catalog()
+	if len(catalog) != len(GameTypes()) {
+		t.Fatalf("Expected an entry for each of the %d games, but got %d", len(GameTypes()), len(catalog))
+	}
+
+	for _, meta := range catalog {
+		if _, ok := gameMetadata[meta.Type]; !ok {
+			t.Errorf("Expected %s to register its metadata", meta.Type)
+			continue
+		}
+		if meta.DisplayName == "" || meta.Description == "" || len(meta.Rules) == 0 {
+			t.Errorf("Expected %s to have a name, description and rules, but got %+v", meta.Type, meta)
+		}
+		if meta.MinPlayers < 1 || meta.MinPlayers > meta.MaxPlayers {
+			t.Errorf("Expected %s to take a sensible number of players, but got %d to %d", meta.Type, meta.MinPlayers, meta.MaxPlayers)
+		}
+		if meta.TimeControl.InitialSeconds <= 0 {
+			t.Errorf("Expected %s to suggest a clock, but got %+v", meta.Type, meta.TimeControl)
+		}
+	}
+}
+
+// TestCatalog_OptionsMatchTheGames checks each option schema against the
+// game's factory: the defaults and the ends of every range are accepted,
+// and anything outside them is rejected.
+func TestCatalog_OptionsMatchTheGames(t *testing.T) {
+	for _, meta := range Catalog() {
+		t.Run(meta.Type, func(t *testing.T) {
+			accept := func(values map[string]any) {
+				t.Helper()
+				if _, err := NewGame(meta.Type, optionsWith(t, meta, values)); err != nil {
+					t.Errorf("Expected options %v to be accepted, but got %v", values, err)
+				}
+			}
+			reject := func(values map[string]any) {
+				t.Helper()
+				if _, err := NewGame(meta.Type, optionsWith(t, meta, values)); err == nil {
+					t.Errorf("Expected options %v to be rejected, but got nil", values)
+				}
+			}
+
+			// Ranges of different options can depend on each other, such as
+			// the line length of Connect Four on the board size, so the ends
+			// are tried for all options at once.
+			lowest, highest := make(map[string]any), make(map[string]any)
+			accept(nil)
+			for _, option := range meta.Options {
+				switch option.Type {
+				case OptionInt, OptionNumber:
+					if option.Range == nil {
+						t.Fatalf("Expected option %s to have a range", option.Name)
+					}
+					lowest[option.Name], highest[option.Name] = option.Range.Min, option.Range.Max
+					reject(map[string]any{option.Name: option.Range.Min - 1})
+					reject(map[string]any{option.Name: option.Range.Max + 1})
+
+				case OptionIntList:
+					lowest[option.Name], highest[option.Name] = []float64{option.Range.Min}, []float64{option.Range.Max}
+					reject(map[string]any{option.Name: []float64{option.Range.Max + 1}})
+					if option.MaxItems > 0 {
+						reject(map[string]any{option.Name: make([]float64, option.MaxItems+1)})
+					}
+
+				case OptionChoice:
+					for _, choice := range option.Choices {
+						accept(map[string]any{option.Name: choice})
+					}
+					reject(map[string]any{option.Name: "no-such-choice"})
+
+				case OptionChoiceList:
+					for _, choice := range option.Choices {
+						items := make([]any, option.MinItems)
+						for i := range items {
+							items[i] = choice
+						}
+						accept(map[string]any{option.Name: items})
+					}
+					reject(map[string]any{option.Name: []any{"no-such-choice"}})
+
+				case OptionBool:
+					accept(map[string]any{option.Name: false})
+					accept(map[string]any{option.Name: true})
+
+				default:
+					t.Fatalf("Expected a known type for option %s, but got %q", option.Name, option.Type)
+				}
+			}
+			if len(lowest) > 0 {
+				accept(lowest)
+				accept(highest)
+			}
+		})
+	}
+}
+
+func TestGameMetadata_UnknownGame(t *testing.T) {
+	if _, ok := GameMetadata("no-such-game"); ok {
+		t.Error("Expected no metadata for an unregistered game")
+	}
+}
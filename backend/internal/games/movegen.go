@@ -0,0 +1,30 @@
+package games
+
+// legalAmong keeps the candidate moves that the player to move may make,
+// trying each one on a clone. It suits games whose rules are easier to
+// check than to enumerate.
+func legalAmong(game MoveGenerator, candidates []any) []any {
+	playerIndex := game.CurrentPlayer()
+	var moves []any
+	for _, move := range candidates {
+		if game.Clone().HandleMove(playerIndex, move) == nil {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// stateKey is a PositionKey for games whose serialized state is the simplest
+// exact description of a position, including hidden bookkeeping such as
+// repetition counts. Games only hold JSON-friendly values, so marshalling
+// their own state cannot fail.
+func stateKey(game Game) string {
+	data, _ := game.MarshalState()
+	return string(data)
+}
+
+// squareValue converts a square into the JSON [row, col] pair that
+// parseSquare reads.
+func squareValue(square [2]int) []any {
+	return []any{float64(square[0]), float64(square[1])}
+}
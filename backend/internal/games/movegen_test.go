@@ -0,0 +1,60 @@
+package games
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+const moveGenPlies = 120
+
+// TestMoveGenerators_MatchHandleMove checks along random playouts that every
+// listed move is accepted and that every move the game accepts is listed,
+// comparing moves by the position they lead to. Long games are checked over
+// their first moveGenPlies moves.
+func TestMoveGenerators_MatchHandleMove(t *testing.T) {
+	for gameType := range gameFactories {
+		game, _ := NewGame(gameType, nil)
+		if _, ok := game.(MoveGenerator); !ok {
+			continue
+		}
+
+		t.Run(gameType, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(7, 7))
+			gen := game.(MoveGenerator)
+
+			for ply := 0; ply < moveGenPlies && !gen.IsGameOver(); ply++ {
+				player := gen.CurrentPlayer()
+				moves := gen.LegalMoves()
+				if len(moves) == 0 {
+					t.Fatal("Expected legal moves in an unfinished game, but got none")
+				}
+
+				reachable := make(map[string]bool, len(moves))
+				for _, move := range moves {
+					child := gen.Clone().(MoveGenerator)
+					if err := child.HandleMove(player, move); err != nil {
+						t.Fatalf("Expected listed move %v to be accepted, but got %v", move, err)
+					}
+					reachable[child.PositionKey()] = true
+				}
+
+				if candidates, ok := randomMoveCandidates[gameType]; ok {
+					for range 200 {
+						child := gen.Clone().(MoveGenerator)
+						if child.HandleMove(player, candidates(r)) == nil && !reachable[child.PositionKey()] {
+							t.Fatalf("Expected every accepted move to be listed, but %s was missing", child.PositionKey())
+						}
+					}
+				}
+
+				if err := gen.HandleMove(player, moves[r.IntN(len(moves))]); err != nil {
+					t.Fatalf("Expected no error, but got %v", err)
+				}
+			}
+
+			if gen.IsGameOver() && gen.LegalMoves() != nil {
+				t.Error("Expected no legal moves once the game is over")
+			}
+		})
+	}
+}
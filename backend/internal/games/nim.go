@@ -3,26 +3,105 @@ package games
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	nimMaxHeaps         = 10
+	nimMaxHeapSize      = 100
+	nimMaxMiserePosSize = 1_000_000
 )
 
 func init() {
-	RegisterGame("nim", func() (Game, error) {
-		return NewNimGame(), nil
-	})
+	RegisterGameWithOptions("nim", NewNimGame)
+	RegisterMetadata("nim", nimMetadata)
+}
+
+var nimMetadata = Metadata{
+	DisplayName: "Nim",
+	Description: "A strategic game starting with 21 sticks. Players take turns removing 1, 2, or 3 sticks. Force your opponent to take the last stick and win!",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "heaps", Label: "Heaps", Type: OptionIntList, Default: []int{21}, Range: &Range{Min: 1, Max: nimMaxHeapSize}, MinItems: 1, MaxItems: nimMaxHeaps},
+		{Name: "subtraction", Label: "Sticks that may be taken", Type: OptionIntList, Default: []int{1, 2, 3}, Range: &Range{Min: 1, Max: nimMaxHeapSize}},
+		{Name: "misere", Label: "Last stick loses", Type: OptionBool, Default: true},
+	},
+	TimeControl: quickTimeControl,
+	Rules: []string{
+		"On your turn, take sticks from a single heap",
+		"You may only take the amounts in the subtraction set, or any number when it is empty",
+		"You cannot skip your turn",
+		"Under misère play you lose if you take the last stick; otherwise you win",
+	},
 }
 
+// NimGame covers multi-heap Nim and subtraction games. A move takes sticks
+// from one heap; with a subtraction set only those amounts may be taken,
+// otherwise any number can. The game ends when no move is left, and under
+// misère play whoever made the last move loses. The defaults are a single
+// heap of 21 with takes of 1-3 under misère play.
 type NimGame struct {
-	Sticks      int    `json:"sticks"`
-	CurrentTurn int    `json:"currentTurn"`
-	Winner      string `json:"winner"`
+	Heaps        []int  `json:"heaps"`
+	Sticks       int    `json:"sticks"`
+	Subtraction  []int  `json:"subtraction"`
+	Misere       bool   `json:"misere"`
+	CurrentTurn  int    `json:"currentTurn"`
+	Winner       string `json:"winner"`
+	initialHeaps []int
+	analysis     *SubtractionGame
 }
 
-func NewNimGame() *NimGame {
-	return &NimGame{
-		Sticks:      21,
-		CurrentTurn: 0,
-		Winner:      "",
+// nimOptions keeps the default subtraction set unless the room sets one;
+// an explicit empty list means any number of sticks may be taken.
+type nimOptions struct {
+	Heaps       []int `json:"heaps"`
+	Subtraction []int `json:"subtraction"`
+	Misere      bool  `json:"misere"`
+}
+
+func NewNimGame(options json.RawMessage) (Game, error) {
+	opts := nimOptions{Heaps: []int{21}, Subtraction: []int{1, 2, 3}, Misere: true}
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+
+	if len(opts.Heaps) == 0 || len(opts.Heaps) > nimMaxHeaps {
+		return nil, fmt.Errorf("nim needs between 1 and %d heaps", nimMaxHeaps)
+	}
+	positions := 1
+	for _, heap := range opts.Heaps {
+		if heap < 1 || heap > nimMaxHeapSize {
+			return nil, fmt.Errorf("nim heaps must hold between 1 and %d sticks", nimMaxHeapSize)
+		}
+		positions *= heap + 1
+	}
+
+	subtraction := slices.Clone(opts.Subtraction)
+	slices.Sort(subtraction)
+	subtraction = slices.Compact(subtraction)
+	for _, take := range subtraction {
+		if take < 1 || take > nimMaxHeapSize {
+			return nil, fmt.Errorf("subtraction set values must be between 1 and %d", nimMaxHeapSize)
+		}
+	}
+
+	// Misère subtraction games are analysed by exhaustive search.
+	if opts.Misere && len(subtraction) > 0 && positions > nimMaxMiserePosSize {
+		return nil, fmt.Errorf("misère subtraction games are limited to %d positions", nimMaxMiserePosSize)
+	}
+
+	g := &NimGame{
+		Subtraction:  subtraction,
+		Misere:       opts.Misere,
+		initialHeaps: slices.Clone(opts.Heaps),
+		analysis:     NewSubtractionGame(subtraction),
 	}
+	g.Reset()
+	return g, nil
 }
 
 func (g *NimGame) HandleMove(playerIndex int, move any) error {
@@ -44,21 +123,41 @@ func (g *NimGame) HandleMove(playerIndex int, move any) error {
 		return errors.New("invalid sticks value")
 	}
 
-	sticks := int(sticksToTake)
+	heap := 0
+	if heapValue, present := moveMap["heap"]; present {
+		heapFloat, ok := heapValue.(float64)
+		if !ok {
+			return errors.New("invalid heap value")
+		}
+		heap = int(heapFloat)
+	}
 
-	if sticks < 1 || sticks > 3 {
-		return errors.New("you can only take 1, 2, or 3 sticks")
+	if heap < 0 || heap >= len(g.Heaps) {
+		return errors.New("heap does not exist")
 	}
 
-	if sticks > g.Sticks {
+	sticks := int(sticksToTake)
+
+	if sticks > g.Heaps[heap] {
 		return errors.New("not enough sticks remaining")
 	}
 
+	if !slices.Contains(g.analysis.Moves(g.Heaps[heap]), sticks) {
+		if len(g.Subtraction) == 0 {
+			return errors.New("you must take at least one stick")
+		}
+		return fmt.Errorf("you can only take %v sticks", g.Subtraction)
+	}
+
+	g.Heaps[heap] -= sticks
 	g.Sticks -= sticks
 
-	if g.Sticks == 0 {
-		// whoever took the last stick loses, so the winner is the other player
-		winnerIndex := 1 - playerIndex
+	if !g.analysis.HasMoves(g.Heaps) {
+		// under misère play whoever made the last move loses
+		winnerIndex := playerIndex
+		if g.Misere {
+			winnerIndex = 1 - playerIndex
+		}
 		if winnerIndex == 0 {
 			g.Winner = "P1"
 		} else {
@@ -71,6 +170,19 @@ func (g *NimGame) HandleMove(playerIndex int, move any) error {
 	return nil
 }
 
+// BestMove returns a perfect-play move for the player to move, for hints
+// and bots. winning is false when every move loses against perfect play.
+func (g *NimGame) BestMove() (heap, sticks int, winning bool) {
+	return g.analysis.BestMove(g.Heaps, g.Misere)
+}
+
+// NimSum returns the Sprague-Grundy value of the heaps. It gives the game
+// away, so it is not part of the shared state and only reaches players
+// through hints.
+func (g *NimGame) NimSum() int {
+	return g.analysis.NimSum(g.Heaps)
+}
+
 func (g *NimGame) GetGameState() interface{} {
 	return g
 }
@@ -84,19 +196,153 @@ func (g *NimGame) GetWinner() string {
 }
 
 func (g *NimGame) Reset() {
-	g.Sticks = 21
+	g.Heaps = slices.Clone(g.initialHeaps)
+	g.Sticks = 0
+	for _, heap := range g.Heaps {
+		g.Sticks += heap
+	}
 	g.CurrentTurn = 0
 	g.Winner = ""
 }
 
 func (g *NimGame) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
+		Heaps       []int  `json:"heaps"`
 		Sticks      int    `json:"sticks"`
+		Subtraction []int  `json:"subtraction"`
+		Misere      bool   `json:"misere"`
 		CurrentTurn int    `json:"currentTurn"`
 		Winner      string `json:"winner"`
 	}{
+		Heaps:       g.Heaps,
 		Sticks:      g.Sticks,
+		Subtraction: g.Subtraction,
+		Misere:      g.Misere,
 		CurrentTurn: g.CurrentTurn,
 		Winner:      g.Winner,
 	})
 }
+
+type nimPrivate struct {
+	InitialHeaps []int `json:"initialHeaps"`
+}
+
+// Clone gives the copy its own analysis so that the two never share the
+// memoised Grundy tables.
+func (g *NimGame) Clone() Game {
+	cloned := *g
+	cloned.Heaps = slices.Clone(g.Heaps)
+	cloned.Subtraction = slices.Clone(g.Subtraction)
+	cloned.initialHeaps = slices.Clone(g.initialHeaps)
+	cloned.analysis = NewSubtractionGame(cloned.Subtraction)
+	return &cloned
+}
+
+func (g *NimGame) MarshalState() ([]byte, error) {
+	return marshalState(g, nimPrivate{InitialHeaps: g.initialHeaps})
+}
+
+func (g *NimGame) UnmarshalState(data []byte) error {
+	var restored NimGame
+	var private nimPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.initialHeaps = private.InitialHeaps
+	restored.analysis = NewSubtractionGame(restored.Subtraction)
+	*g = restored
+	return nil
+}
+
+// afterSetup recounts the sticks of the set-up heaps and ends the game when
+// no move is left, as if the last player to move had just taken the last
+// sticks.
+func (g *NimGame) afterSetup() {
+	g.Sticks = 0
+	for _, heap := range g.Heaps {
+		g.Sticks += heap
+	}
+
+	if !g.analysis.HasMoves(g.Heaps) {
+		winnerIndex := 1 - g.CurrentTurn
+		if g.Misere {
+			winnerIndex = g.CurrentTurn
+		}
+		g.Winner = g.PlayerSymbol(winnerIndex)
+	}
+}
+
+// ValidatePosition applies the limits of the room options to the set-up
+// heaps and subtraction set. Heaps may be empty.
+func (g *NimGame) ValidatePosition() error {
+	positions := 1
+	for _, heap := range g.Heaps {
+		if heap < 0 || heap > nimMaxHeapSize {
+			return fmt.Errorf("nim heaps must hold between 0 and %d sticks", nimMaxHeapSize)
+		}
+		positions *= heap + 1
+	}
+	for i, take := range g.Subtraction {
+		if take < 1 || take > nimMaxHeapSize {
+			return fmt.Errorf("subtraction set values must be between 1 and %d", nimMaxHeapSize)
+		}
+		if i > 0 && take <= g.Subtraction[i-1] {
+			return fmt.Errorf("subtraction set values must be listed in increasing order")
+		}
+	}
+	if g.Misere && len(g.Subtraction) > 0 && positions > nimMaxMiserePosSize {
+		return fmt.Errorf("misère subtraction games are limited to %d positions", nimMaxMiserePosSize)
+	}
+	return nil
+}
+
+func (g *NimGame) CurrentPlayer() int {
+	return g.CurrentTurn
+}
+
+func (g *NimGame) LegalMoves() []any {
+	if g.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	for heap, size := range g.Heaps {
+		for _, take := range g.analysis.Moves(size) {
+			moves = append(moves, map[string]any{"heap": float64(heap), "sticks": float64(take)})
+		}
+	}
+	return moves
+}
+
+func (g *NimGame) PlayerSymbol(playerIndex int) string {
+	return [2]string{"P1", "P2"}[playerIndex]
+}
+
+func (g *NimGame) PositionKey() string {
+	return fmt.Sprint(g.Heaps, g.CurrentTurn)
+}
+
+// FormatMove letters the heaps from "a" and writes the heap followed by the
+// number of sticks taken, as in "b3".
+func (g *NimGame) FormatMove(move any) (string, error) {
+	sticks, err := moveField(move, "sticks")
+	if err != nil {
+		return "", err
+	}
+	heap, err := moveField(move, "heap")
+	if err != nil {
+		heap = 0
+	}
+	return fmt.Sprintf("%c%d", 'a'+heap, sticks), nil
+}
+
+func (g *NimGame) ParseMove(text string) (any, error) {
+	text = strings.ToLower(text)
+	if len(text) < 2 || text[0] < 'a' || text[0] > 'z' {
+		return nil, fmt.Errorf("%q is not a heap and count such as a3", text)
+	}
+	sticks, err := strconv.Atoi(text[1:])
+	if err != nil || sticks < 1 {
+		return nil, fmt.Errorf("%q is not a heap and count such as a3", text)
+	}
+	return map[string]any{"heap": float64(text[0] - 'a'), "sticks": float64(sticks)}, nil
+}
@@ -0,0 +1,127 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeNimMovePayload(heap int, sticks int) any {
+	return map[string]any{"heap": float64(heap), "sticks": float64(sticks)}
+}
+
+func newTestNim(t *testing.T, options string) *NimGame {
+	t.Helper()
+	game, err := NewNimGame(json.RawMessage(options))
+	if err != nil {
+		t.Fatalf("Expected no error creating nim game, but got %v", err)
+	}
+	return game.(*NimGame)
+}
+
+func TestNim_Options(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+		wantErr bool
+	}{
+		{"default", "", false},
+		{"multi-heap nim", `{"heaps": [3, 4, 5], "subtraction": [], "misere": false}`, false},
+		{"no heaps", `{"heaps": []}`, true},
+		{"empty heap", `{"heaps": [3, 0]}`, true},
+		{"heap too large", `{"heaps": [101]}`, true},
+		{"bad subtraction value", `{"subtraction": [0, 2]}`, true},
+		{"misère search too large", `{"heaps": [100, 100, 100, 100]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNimGame(json.RawMessage(tt.options))
+			if tt.wantErr && err == nil {
+				t.Error("Expected an error for invalid options, but got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestNim_DefaultGame(t *testing.T) {
+	g := newTestNim(t, "")
+
+	if g.Sticks != 21 || !g.Misere {
+		t.Fatalf("Expected a misère heap of 21, but got %d sticks", g.Sticks)
+	}
+	if err := g.HandleMove(0, map[string]any{"sticks": float64(4)}); err == nil {
+		t.Fatal("Expected an error when taking 4 sticks, but got nil")
+	}
+
+	for g.Sticks > 1 {
+		if err := g.HandleMove(g.CurrentTurn, map[string]any{"sticks": float64(min(3, g.Sticks-1))}); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	}
+	loser := g.CurrentTurn
+	if err := g.HandleMove(loser, map[string]any{"sticks": float64(1)}); err != nil {
+		t.Fatalf("Expected no error taking the last stick, but got %v", err)
+	}
+
+	expected := "P1"
+	if loser == 0 {
+		expected = "P2"
+	}
+	if g.Winner != expected {
+		t.Errorf("Expected winner to be '%s', but got '%s'", expected, g.Winner)
+	}
+}
+
+func TestNim_MultiHeapNormalPlay(t *testing.T) {
+	g := newTestNim(t, `{"heaps": [1, 2], "subtraction": [], "misere": false}`)
+
+	if g.NimSum() != 3 {
+		t.Errorf("Expected a nim-sum of 3, but got %d", g.NimSum())
+	}
+	if err := g.HandleMove(0, makeNimMovePayload(2, 1)); err == nil {
+		t.Error("Expected an error for a heap that does not exist, but got nil")
+	}
+	if err := g.HandleMove(0, makeNimMovePayload(1, 1)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if g.NimSum() != 0 {
+		t.Errorf("Expected a nim-sum of 0, but got %d", g.NimSum())
+	}
+	if err := g.HandleMove(1, makeNimMovePayload(0, 1)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := g.HandleMove(0, makeNimMovePayload(1, 1)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if g.Winner != "P1" {
+		t.Errorf("Expected the player taking the last stick to win, but got '%s'", g.Winner)
+	}
+}
+
+func TestNim_SubtractionSetEndsWithSticksLeft(t *testing.T) {
+	g := newTestNim(t, `{"heaps": [6], "subtraction": [2, 5], "misere": false}`)
+
+	if err := g.HandleMove(0, makeNimMovePayload(0, 3)); err == nil {
+		t.Fatal("Expected an error for an amount outside the subtraction set, but got nil")
+	}
+	if err := g.HandleMove(0, makeNimMovePayload(0, 5)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if g.Winner != "P1" || g.Sticks != 1 {
+		t.Errorf("Expected P1 to win with one stick left, but got '%s' with %d", g.Winner, g.Sticks)
+	}
+}
+
+func TestNim_BestMove(t *testing.T) {
+	g := newTestNim(t, `{"heaps": [20]}`)
+
+	heap, sticks, winning := g.BestMove()
+	if !winning || heap != 0 || sticks != 3 {
+		t.Errorf("Expected taking 3 to win, but got heap %d, %d sticks, winning %v", heap, sticks, winning)
+	}
+}
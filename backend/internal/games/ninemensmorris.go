@@ -0,0 +1,574 @@
+package games
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	morrisPoints        = 24
+	morrisPiecesPerSide = 9
+	morrisQuietLimit    = 50
+)
+
+const (
+	morrisPhasePlacing = "placing"
+	morrisPhaseMoving  = "moving"
+	morrisPhaseFlying  = "flying"
+)
+
+func init() {
+	RegisterGame("nine-mens-morris", NewNineMensMorris)
+	RegisterMetadata("nine-mens-morris", nineMensMorrisMetadata)
+}
+
+var nineMensMorrisMetadata = Metadata{
+	DisplayName: "Nine Men's Morris",
+	Description: "Place and move your nine pieces to form mills of three in a row, removing an opposing piece with each one.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Players first take turns placing their nine pieces on empty points",
+		"Once all are placed, pieces move along the lines to an adjacent empty point",
+		"A player down to three pieces may fly to any empty point",
+		"Forming a mill of three in a row lets you remove an opposing piece",
+		"You lose when you are down to two pieces or cannot move",
+	},
+}
+
+// Points are numbered row by row from the top-left corner of the outer
+// square:
+//
+//	0-----------1-----------2
+//	|   3-------4-------5   |
+//	|   |   6---7---8   |   |
+//	9---10--11      12--13--14
+//	|   |   15--16--17  |   |
+//	|   18------19------20  |
+//	21----------22----------23
+var morrisAdjacency = [morrisPoints][]int{
+	{1, 9}, {0, 2, 4}, {1, 14},
+	{4, 10}, {1, 3, 5, 7}, {4, 13},
+	{7, 11}, {4, 6, 8}, {7, 12},
+	{0, 10, 21}, {3, 9, 11, 18}, {6, 10, 15}, {8, 13, 17}, {5, 12, 14, 20}, {2, 13, 23},
+	{11, 16}, {15, 17, 19}, {12, 16},
+	{10, 19}, {16, 18, 20, 22}, {13, 19},
+	{9, 22}, {19, 21, 23}, {14, 22},
+}
+
+var morrisMills = [16][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {9, 10, 11},
+	{12, 13, 14}, {15, 16, 17}, {18, 19, 20}, {21, 22, 23},
+	{0, 9, 21}, {3, 10, 18}, {6, 11, 15}, {1, 4, 7},
+	{16, 19, 22}, {8, 12, 17}, {5, 13, 20}, {2, 14, 23},
+}
+
+// NineMensMorris is played in placing, moving and flying phases, tracked per
+// player in Phases. Closing a mill sets PendingRemoval and the same player
+// keeps the turn until they remove an opposing piece.
+type NineMensMorris struct {
+	Board          [morrisPoints]string `json:"board"`
+	CurrentTurn    int                  `json:"currentTurn"`
+	Winner         string               `json:"winner"`
+	InHand         [2]int               `json:"inHand"`
+	OnBoard        [2]int               `json:"onBoard"`
+	Phases         [2]string            `json:"phases"`
+	PendingRemoval bool                 `json:"pendingRemoval"`
+	LastMill       []int                `json:"lastMill"`
+	playerSymbols  [2]string
+	quietMoves     int
+	positions      map[string]int
+}
+
+func NewNineMensMorris() (Game, error) {
+	n := &NineMensMorris{}
+	n.Reset()
+	return n, nil
+}
+
+func (n *NineMensMorris) HandleMove(playerIndex int, move any) error {
+	if n.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != n.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	moveType, ok := moveData["type"].(string)
+	if !ok {
+		return fmt.Errorf("type must be a string")
+	}
+
+	if n.PendingRemoval && moveType != "remove" {
+		return fmt.Errorf("you must remove one of your opponent's pieces")
+	}
+
+	symbol := n.playerSymbols[playerIndex]
+
+	switch moveType {
+	case "place":
+		if n.Phases[playerIndex] != morrisPhasePlacing {
+			return fmt.Errorf("you have no pieces left to place")
+		}
+		point, err := parseMorrisPoint(moveData, "point")
+		if err != nil {
+			return err
+		}
+		if n.Board[point] != "" {
+			return fmt.Errorf("point is already occupied")
+		}
+		n.Board[point] = symbol
+		n.InHand[playerIndex]--
+		n.OnBoard[playerIndex]++
+		n.finishTurn(playerIndex, point)
+
+	case "move":
+		if n.Phases[playerIndex] == morrisPhasePlacing {
+			return fmt.Errorf("you must place all your pieces before moving")
+		}
+		from, err := parseMorrisPoint(moveData, "from")
+		if err != nil {
+			return err
+		}
+		to, err := parseMorrisPoint(moveData, "to")
+		if err != nil {
+			return err
+		}
+		if n.Board[from] != symbol {
+			return fmt.Errorf("you must move one of your own pieces")
+		}
+		if n.Board[to] != "" {
+			return fmt.Errorf("point is already occupied")
+		}
+		if n.Phases[playerIndex] != morrisPhaseFlying && !isMorrisAdjacent(from, to) {
+			return fmt.Errorf("pieces can only move to an adjacent point")
+		}
+		n.Board[from] = ""
+		n.Board[to] = symbol
+		n.finishTurn(playerIndex, to)
+
+	case "remove":
+		if !n.PendingRemoval {
+			return fmt.Errorf("you have not formed a mill")
+		}
+		point, err := parseMorrisPoint(moveData, "point")
+		if err != nil {
+			return err
+		}
+		if err := n.removePiece(playerIndex, point); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("invalid move type: must be 'place', 'move' or 'remove'")
+	}
+
+	return nil
+}
+
+// finishTurn checks whether the piece that arrived on point closed a mill. If
+// it did, the player keeps the turn to remove a piece; otherwise the turn
+// passes and the end of game conditions are evaluated.
+func (n *NineMensMorris) finishTurn(playerIndex, point int) {
+	if mill := n.millAt(point); mill != nil {
+		n.LastMill = mill
+		n.PendingRemoval = true
+		n.quietMoves = 0
+		return
+	}
+
+	// Only moves on the board count towards the draw; placing is bound to
+	// run out.
+	n.LastMill = nil
+	if n.Phases[playerIndex] != morrisPhasePlacing {
+		n.quietMoves++
+	}
+	n.endTurn(playerIndex)
+}
+
+func (n *NineMensMorris) removePiece(playerIndex, point int) error {
+	opponent := 1 - playerIndex
+	if n.Board[point] != n.playerSymbols[opponent] {
+		return fmt.Errorf("you must remove one of your opponent's pieces")
+	}
+	if n.millAt(point) != nil && !n.allInMills(opponent) {
+		return fmt.Errorf("pieces in a mill cannot be removed while other pieces are available")
+	}
+
+	n.Board[point] = ""
+	n.OnBoard[opponent]--
+	n.PendingRemoval = false
+	n.positions = make(map[string]int)
+
+	if n.InHand[opponent] == 0 && n.OnBoard[opponent] < 3 {
+		n.Winner = n.playerSymbols[playerIndex]
+		n.updatePhases()
+		return nil
+	}
+
+	n.endTurn(playerIndex)
+	return nil
+}
+
+func (n *NineMensMorris) endTurn(playerIndex int) {
+	n.updatePhases()
+	n.CurrentTurn = 1 - playerIndex
+
+	if !n.hasValidMoves(n.CurrentTurn) {
+		n.Winner = n.playerSymbols[playerIndex]
+		return
+	}
+
+	if n.Phases[0] == morrisPhasePlacing || n.Phases[1] == morrisPhasePlacing {
+		return
+	}
+
+	key := n.positionKey()
+	n.positions[key]++
+	if n.positions[key] >= 3 || n.quietMoves >= morrisQuietLimit {
+		n.Winner = "draw"
+	}
+}
+
+// millAt returns the mill through point formed by the piece standing on it,
+// or nil if there is none.
+func (n *NineMensMorris) millAt(point int) []int {
+	symbol := n.Board[point]
+	if symbol == "" {
+		return nil
+	}
+	for _, mill := range morrisMills {
+		if mill[0] != point && mill[1] != point && mill[2] != point {
+			continue
+		}
+		if n.Board[mill[0]] == symbol && n.Board[mill[1]] == symbol && n.Board[mill[2]] == symbol {
+			return mill[:]
+		}
+	}
+	return nil
+}
+
+func (n *NineMensMorris) allInMills(playerIndex int) bool {
+	for point, symbol := range n.Board {
+		if symbol == n.playerSymbols[playerIndex] && n.millAt(point) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *NineMensMorris) hasValidMoves(playerIndex int) bool {
+	if n.Phases[playerIndex] != morrisPhaseMoving {
+		return true
+	}
+	for point, symbol := range n.Board {
+		if symbol != n.playerSymbols[playerIndex] {
+			continue
+		}
+		for _, next := range morrisAdjacency[point] {
+			if n.Board[next] == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n *NineMensMorris) updatePhases() {
+	for i := range n.Phases {
+		switch {
+		case n.InHand[i] > 0:
+			n.Phases[i] = morrisPhasePlacing
+		case n.OnBoard[i] == 3:
+			n.Phases[i] = morrisPhaseFlying
+		default:
+			n.Phases[i] = morrisPhaseMoving
+		}
+	}
+}
+
+func (n *NineMensMorris) positionKey() string {
+	key := make([]byte, 0, morrisPoints+1)
+	for _, symbol := range n.Board {
+		if symbol == "" {
+			key = append(key, '.')
+		} else {
+			key = append(key, symbol...)
+		}
+	}
+	return string(append(key, byte('0'+n.CurrentTurn)))
+}
+
+func isMorrisAdjacent(from, to int) bool {
+	for _, next := range morrisAdjacency[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func parseMorrisPoint(moveData map[string]any, field string) (int, error) {
+	pointFloat, ok := moveData[field].(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a number", field)
+	}
+	point := int(pointFloat)
+	if point < 0 || point >= morrisPoints {
+		return 0, fmt.Errorf("point out of bounds")
+	}
+	return point, nil
+}
+
+func (n *NineMensMorris) GetGameState() any {
+	return n
+}
+
+func (n *NineMensMorris) IsGameOver() bool {
+	return n.Winner != ""
+}
+
+func (n *NineMensMorris) GetWinner() string {
+	return n.Winner
+}
+
+func (n *NineMensMorris) Reset() {
+	n.Board = [morrisPoints]string{}
+	n.CurrentTurn = 0
+	n.Winner = ""
+	n.InHand = [2]int{morrisPiecesPerSide, morrisPiecesPerSide}
+	n.OnBoard = [2]int{}
+	n.PendingRemoval = false
+	n.LastMill = nil
+	n.playerSymbols = [2]string{"W", "B"}
+	n.quietMoves = 0
+	n.positions = make(map[string]int)
+	n.updatePhases()
+}
+
+type nineMensMorrisPrivate struct {
+	PlayerSymbols [2]string      `json:"playerSymbols"`
+	QuietMoves    int            `json:"quietMoves"`
+	Positions     map[string]int `json:"positions"`
+}
+
+func (n *NineMensMorris) Clone() Game {
+	cloned := *n
+	cloned.LastMill = slices.Clone(n.LastMill)
+	cloned.positions = maps.Clone(n.positions)
+	return &cloned
+}
+
+func (n *NineMensMorris) MarshalState() ([]byte, error) {
+	return marshalState(n, nineMensMorrisPrivate{
+		PlayerSymbols: n.playerSymbols,
+		QuietMoves:    n.quietMoves,
+		Positions:     n.positions,
+	})
+}
+
+func (n *NineMensMorris) UnmarshalState(data []byte) error {
+	var restored NineMensMorris
+	var private nineMensMorrisPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.quietMoves = private.QuietMoves
+	restored.positions = private.Positions
+	if restored.positions == nil {
+		restored.positions = make(map[string]int)
+	}
+	*n = restored
+	return nil
+}
+
+// afterSetup recounts the pieces on the board, works out the phases from
+// them and starts the draw counters from the set-up position. The game is
+// over if the player to move is down to two pieces or cannot move.
+func (n *NineMensMorris) afterSetup() {
+	n.OnBoard = [2]int{}
+	for _, point := range n.Board {
+		for i, symbol := range n.playerSymbols {
+			if point == symbol {
+				n.OnBoard[i]++
+			}
+		}
+	}
+	n.updatePhases()
+	n.quietMoves = 0
+	n.positions = make(map[string]int)
+
+	turn := n.CurrentTurn
+	if (n.InHand[turn] == 0 && n.OnBoard[turn] < 3) || !n.hasValidMoves(turn) {
+		n.Winner = n.playerSymbols[1-turn]
+	}
+}
+
+// ValidatePosition checks the pieces in hand against those on the board.
+// Each player has nine pieces and loses below three, so only the player to
+// move, with none left in hand, can be down to two. While pieces are still
+// being placed the players take turns, so the first player has placed as
+// many as the second or one more.
+func (n *NineMensMorris) ValidatePosition() error {
+	if err := checkPieces(n.Board[:], n.playerSymbols[:]...); err != nil {
+		return err
+	}
+	for i, symbol := range n.playerSymbols {
+		onBoard := countPieces(n.Board[:], symbol)
+		if n.InHand[i] < 0 || onBoard+n.InHand[i] > morrisPiecesPerSide {
+			return fmt.Errorf("%s cannot have more than %d pieces", symbol, morrisPiecesPerSide)
+		}
+		least := 3
+		if i == n.CurrentTurn && n.InHand[i] == 0 {
+			least = 2
+		}
+		if onBoard+n.InHand[i] < least {
+			return fmt.Errorf("%s must have at least %d pieces", symbol, least)
+		}
+	}
+	if n.InHand != [2]int{} {
+		return checkAlternation([2]int{morrisPiecesPerSide - n.InHand[0], morrisPiecesPerSide - n.InHand[1]}, n.CurrentTurn)
+	}
+	return nil
+}
+
+func (n *NineMensMorris) CurrentPlayer() int {
+	return n.CurrentTurn
+}
+
+func (n *NineMensMorris) LegalMoves() []any {
+	if n.IsGameOver() {
+		return nil
+	}
+
+	symbol := n.playerSymbols[n.CurrentTurn]
+	opponent := 1 - n.CurrentTurn
+	var moves []any
+	switch {
+	case n.PendingRemoval:
+		for point := range n.Board {
+			if n.Board[point] == n.playerSymbols[opponent] && (n.millAt(point) == nil || n.allInMills(opponent)) {
+				moves = append(moves, map[string]any{"type": "remove", "point": float64(point)})
+			}
+		}
+	case n.Phases[n.CurrentTurn] == morrisPhasePlacing:
+		for point := range n.Board {
+			if n.Board[point] == "" {
+				moves = append(moves, map[string]any{"type": "place", "point": float64(point)})
+			}
+		}
+	default:
+		flying := n.Phases[n.CurrentTurn] == morrisPhaseFlying
+		for from := range n.Board {
+			if n.Board[from] != symbol {
+				continue
+			}
+			for to := range n.Board {
+				if n.Board[to] == "" && (flying || isMorrisAdjacent(from, to)) {
+					moves = append(moves, map[string]any{"type": "move", "from": float64(from), "to": float64(to)})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (n *NineMensMorris) PlayerSymbol(playerIndex int) string {
+	return n.playerSymbols[playerIndex]
+}
+
+func (n *NineMensMorris) PositionKey() string {
+	return stateKey(n)
+}
+
+// morrisPointNames gives the usual coordinates of each point, with files a
+// to g from the left and ranks 1 to 7 from the bottom of the board.
+var morrisPointNames = [morrisPoints]string{
+	"a7", "d7", "g7",
+	"b6", "d6", "f6",
+	"c5", "d5", "e5",
+	"a4", "b4", "c4", "e4", "f4", "g4",
+	"c3", "d3", "e3",
+	"b2", "d2", "f2",
+	"a1", "d1", "g1",
+}
+
+// FormatMove uses the usual coordinates of the points: a placement is the
+// point, a move joins two points with "-", as in "d2-d3", and a removal is
+// the point after an "x".
+func (n *NineMensMorris) FormatMove(move any) (string, error) {
+	kind, err := moveType(move)
+	if err != nil {
+		return "", err
+	}
+	moveData := move.(map[string]any)
+
+	switch kind {
+	case "place", "remove":
+		point, err := parseMorrisPoint(moveData, "point")
+		if err != nil {
+			return "", err
+		}
+		if kind == "remove" {
+			return "x" + morrisPointNames[point], nil
+		}
+		return morrisPointNames[point], nil
+	case "move":
+		from, err := parseMorrisPoint(moveData, "from")
+		if err != nil {
+			return "", err
+		}
+		to, err := parseMorrisPoint(moveData, "to")
+		if err != nil {
+			return "", err
+		}
+		return morrisPointNames[from] + "-" + morrisPointNames[to], nil
+	default:
+		return "", fmt.Errorf("invalid move type: %s", kind)
+	}
+}
+
+func (n *NineMensMorris) ParseMove(text string) (any, error) {
+	text = strings.ToLower(text)
+	if name, ok := strings.CutPrefix(text, "x"); ok {
+		point, err := parseMorrisPointName(name)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "remove", "point": float64(point)}, nil
+	}
+	if fromName, toName, ok := strings.Cut(text, "-"); ok {
+		from, err := parseMorrisPointName(fromName)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseMorrisPointName(toName)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "move", "from": float64(from), "to": float64(to)}, nil
+	}
+	point, err := parseMorrisPointName(text)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": "place", "point": float64(point)}, nil
+}
+
+func parseMorrisPointName(name string) (int, error) {
+	point := slices.Index(morrisPointNames[:], name)
+	if point == -1 {
+		return 0, fmt.Errorf("%q is not a point on the board", name)
+	}
+	return point, nil
+}
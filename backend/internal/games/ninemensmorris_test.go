@@ -0,0 +1,147 @@
+package games
+
+import (
+	"testing"
+)
+
+func makeMorrisPlacePayload(point int) any {
+	return map[string]any{"type": "place", "point": float64(point)}
+}
+
+func makeMorrisMovePayload(from int, to int) any {
+	return map[string]any{"type": "move", "from": float64(from), "to": float64(to)}
+}
+
+func makeMorrisRemovePayload(point int) any {
+	return map[string]any{"type": "remove", "point": float64(point)}
+}
+
+func TestNineMensMorris_MillRequiresRemoval(t *testing.T) {
+	game, _ := NewNineMensMorris()
+	n := game.(*NineMensMorris)
+
+	moves := []struct {
+		playerIndex int
+		point       int
+	}{
+		{0, 0}, {1, 9},
+		{0, 1}, {1, 21},
+		{0, 2}, // W closes the top mill
+	}
+	for _, move := range moves {
+		if err := game.HandleMove(move.playerIndex, makeMorrisPlacePayload(move.point)); err != nil {
+			t.Fatalf("Move sequence failed at player %d, point %d: %v", move.playerIndex, move.point, err)
+		}
+	}
+
+	if !n.PendingRemoval || n.CurrentTurn != 0 {
+		t.Fatalf("Expected W to keep the turn to remove a piece, but got turn %d", n.CurrentTurn)
+	}
+
+	if err := game.HandleMove(0, makeMorrisPlacePayload(5)); err == nil {
+		t.Fatal("Expected an error when placing instead of removing, but got nil")
+	}
+
+	if err := game.HandleMove(0, makeMorrisRemovePayload(9)); err != nil {
+		t.Fatalf("Expected no error removing a piece, but got %v", err)
+	}
+	if n.Board[9] != "" || n.OnBoard[1] != 1 {
+		t.Errorf("Expected B's piece at 9 to be removed, but got '%s'", n.Board[9])
+	}
+	if n.CurrentTurn != 1 {
+		t.Errorf("Expected CurrentTurn to be 1 after the removal, but got %d", n.CurrentTurn)
+	}
+}
+
+func TestNineMensMorris_CannotRemoveFromMill(t *testing.T) {
+	game, _ := NewNineMensMorris()
+	n := game.(*NineMensMorris)
+	n.Board[21], n.Board[22], n.Board[23] = "B", "B", "B"
+	n.Board[5] = "B"
+	n.Board[0], n.Board[1] = "W", "W"
+	n.OnBoard = [2]int{2, 4}
+	n.InHand = [2]int{7, 5}
+
+	if err := game.HandleMove(0, makeMorrisPlacePayload(2)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if err := game.HandleMove(0, makeMorrisRemovePayload(22)); err == nil {
+		t.Fatal("Expected an error when removing a piece from a mill, but got nil")
+	}
+	if err := game.HandleMove(0, makeMorrisRemovePayload(5)); err != nil {
+		t.Fatalf("Expected no error removing a loose piece, but got %v", err)
+	}
+}
+
+func TestNineMensMorris_MovingAndFlying(t *testing.T) {
+	game, _ := NewNineMensMorris()
+	n := game.(*NineMensMorris)
+	n.Board[0], n.Board[4], n.Board[10] = "W", "W", "W"
+	n.Board[3], n.Board[23], n.Board[16], n.Board[17] = "B", "B", "B", "B"
+	n.InHand = [2]int{}
+	n.OnBoard = [2]int{3, 4}
+	n.updatePhases()
+
+	if n.Phases[0] != "flying" || n.Phases[1] != "moving" {
+		t.Fatalf("Expected phases flying and moving, but got %v", n.Phases)
+	}
+
+	if err := game.HandleMove(0, makeMorrisMovePayload(0, 20)); err != nil {
+		t.Fatalf("Expected a flying move to be allowed, but got %v", err)
+	}
+
+	if err := game.HandleMove(1, makeMorrisMovePayload(23, 2)); err == nil {
+		t.Fatal("Expected an error for a non-adjacent move while not flying, but got nil")
+	}
+	if err := game.HandleMove(1, makeMorrisMovePayload(23, 22)); err != nil {
+		t.Fatalf("Expected no error for an adjacent move, but got %v", err)
+	}
+}
+
+func TestNineMensMorris_PlacingIsNotQuiet(t *testing.T) {
+	game, _ := NewNineMensMorris()
+	n := game.(*NineMensMorris)
+
+	for i, point := range []int{0, 9, 4, 21} {
+		if err := game.HandleMove(i%2, makeMorrisPlacePayload(point)); err != nil {
+			t.Fatalf("Expected no error placing on %d, but got %v", point, err)
+		}
+	}
+	if n.quietMoves != 0 {
+		t.Fatalf("Expected placements not to count towards the draw, but got %d quiet moves", n.quietMoves)
+	}
+
+	n.InHand = [2]int{}
+	n.OnBoard = [2]int{5, 5}
+	n.Board[6], n.Board[13], n.Board[16] = "W", "W", "W"
+	n.Board[12], n.Board[18], n.Board[20] = "B", "B", "B"
+	n.updatePhases()
+	if err := game.HandleMove(0, makeMorrisMovePayload(4, 5)); err != nil {
+		t.Fatalf("Expected no error moving, but got %v", err)
+	}
+	if n.quietMoves != 1 {
+		t.Errorf("Expected a move without a mill to count, but got %d quiet moves", n.quietMoves)
+	}
+}
+
+func TestNineMensMorris_WinByReducingToTwo(t *testing.T) {
+	game, _ := NewNineMensMorris()
+	n := game.(*NineMensMorris)
+	n.Board[0], n.Board[1], n.Board[14] = "W", "W", "W"
+	n.Board[9], n.Board[21], n.Board[19] = "B", "B", "B"
+	n.InHand = [2]int{}
+	n.OnBoard = [2]int{3, 3}
+	n.updatePhases()
+
+	if err := game.HandleMove(0, makeMorrisMovePayload(14, 2)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := game.HandleMove(0, makeMorrisRemovePayload(19)); err != nil {
+		t.Fatalf("Expected no error removing a piece, but got %v", err)
+	}
+
+	if game.GetWinner() != "W" {
+		t.Errorf("Expected winner to be 'W', but got '%s'", game.GetWinner())
+	}
+}
@@ -0,0 +1,194 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Notation is implemented by games with a compact text form for moves,
+// like algebraic notation in chess. A position is written as the list of
+// moves leading to it from the start, separated by spaces, so a finished
+// game can be exported and replayed and a room can start part way through.
+type Notation interface {
+	// FormatMove writes a move given in the format HandleMove accepts.
+	FormatMove(move any) (string, error)
+
+	// ParseMove reads a single move into the format HandleMove accepts. It
+	// only checks the syntax; whether the move is legal is up to HandleMove.
+	ParseMove(text string) (any, error)
+}
+
+// ParseMoveList reads a list of moves separated by spaces. Moves that are a
+// single character each may also be run together, as in the Connect Four
+// move string "4453".
+func ParseMoveList(game Game, text string) ([]any, error) {
+	notation, ok := game.(Notation)
+	if !ok {
+		return nil, fmt.Errorf("this game has no move notation")
+	}
+
+	var moves []any
+	for _, token := range strings.Fields(text) {
+		move, err := notation.ParseMove(token)
+		if err == nil {
+			moves = append(moves, move)
+			continue
+		}
+
+		run := make([]any, 0, len(token))
+		for _, char := range token {
+			single, runErr := notation.ParseMove(string(char))
+			if runErr != nil {
+				return nil, fmt.Errorf("move %d (%s): %w", len(moves)+1, token, err)
+			}
+			run = append(run, single)
+		}
+		moves = append(moves, run...)
+	}
+	return moves, nil
+}
+
+// FormatMoveList writes moves separated by spaces.
+func FormatMoveList(game Game, moves []any) (string, error) {
+	notation, ok := game.(Notation)
+	if !ok {
+		return "", fmt.Errorf("this game has no move notation")
+	}
+
+	tokens := make([]string, len(moves))
+	for i, move := range moves {
+		token, err := notation.FormatMove(move)
+		if err != nil {
+			return "", fmt.Errorf("move %d: %w", i+1, err)
+		}
+		tokens[i] = token
+	}
+	return strings.Join(tokens, " "), nil
+}
+
+// PlayMoveList plays a list of moves from the game's current position, each
+// by the player to move. The game is left untouched if any move fails.
+func PlayMoveList(game Game, text string) error {
+	moves, err := ParseMoveList(game, text)
+	if err != nil {
+		return err
+	}
+
+	played, ok := game.Clone().(MoveGenerator)
+	if !ok {
+		return fmt.Errorf("this game has no move notation")
+	}
+	for i, move := range moves {
+		if err := played.HandleMove(played.CurrentPlayer(), move); err != nil {
+			return fmt.Errorf("move %d: %w", i+1, err)
+		}
+	}
+
+	state, err := played.MarshalState()
+	if err != nil {
+		return err
+	}
+	return game.UnmarshalState(state)
+}
+
+// NewGameAtPosition creates a game and plays the moves of position, written
+// in the game's notation. An empty position is the usual start.
+func NewGameAtPosition(gameType string, options json.RawMessage, position string) (Game, error) {
+	game, err := NewGame(gameType, options)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(position) == "" {
+		return game, nil
+	}
+	if err := PlayMoveList(game, position); err != nil {
+		return nil, fmt.Errorf("invalid position: %w", err)
+	}
+	return game, nil
+}
+
+// cellName names a cell by its column letter and its row counted from 1 at
+// the top, as in Hex, so the top-left cell is "a1".
+func cellName(row, col int) string {
+	return fmt.Sprintf("%c%d", 'a'+col, row+1)
+}
+
+// parseCellName reads a cell written by cellName, in either case.
+func parseCellName(text string) (row, col int, err error) {
+	text = strings.ToLower(text)
+	if len(text) < 2 || text[0] < 'a' || text[0] > 'z' {
+		return 0, 0, fmt.Errorf("%q is not a cell such as a1", text)
+	}
+	number, err := strconv.Atoi(text[1:])
+	if err != nil || number < 1 || text[1] == '+' {
+		return 0, 0, fmt.Errorf("%q is not a cell such as a1", text)
+	}
+	return number - 1, int(text[0] - 'a'), nil
+}
+
+// squareName names a square as in chess: rows are counted from 1 at the
+// bottom, where player 0 sits.
+func squareName(square [2]int, rows int) string {
+	return cellName(rows-1-square[0], square[1])
+}
+
+func parseSquareName(text string, rows int) ([2]int, error) {
+	row, col, err := parseCellName(text)
+	return [2]int{rows - 1 - row, col}, err
+}
+
+// moveField reads a whole-number field of a move in HandleMove's format.
+func moveField(move any, field string) (int, error) {
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return 0, fmt.Errorf("invalid move format")
+	}
+	value, ok := moveData[field].(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a number", field)
+	}
+	return int(value), nil
+}
+
+// moveType reads the type of a move in HandleMove's format, which is empty
+// when the move has none.
+func moveType(move any) (string, error) {
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid move format")
+	}
+	value, _ := moveData["type"].(string)
+	return value, nil
+}
+
+// moveSquare reads a [row, col] field of a move in HandleMove's format.
+func moveSquare(move any, field string) ([2]int, error) {
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return [2]int{}, fmt.Errorf("invalid move format")
+	}
+	square, err := parseSquare(moveData[field])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("%s: %w", field, err)
+	}
+	return square, nil
+}
+
+// parseStep reads a move from one square to another written as the two
+// square names joined by "-", or by "x" for a capture.
+func parseStep(text string, rows int) (from, to [2]int, err error) {
+	fromText, toText, ok := strings.Cut(text, "-")
+	if !ok {
+		fromText, toText, ok = strings.Cut(text, "x")
+	}
+	if !ok {
+		return from, to, fmt.Errorf("%q is not a move such as a1-a2", text)
+	}
+	if from, err = parseSquareName(fromText, rows); err != nil {
+		return from, to, err
+	}
+	to, err = parseSquareName(toText, rows)
+	return from, to, err
+}
@@ -0,0 +1,128 @@
+package games
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// TestNotation_RoundTrip checks along random playouts that every legal move
+// can be written and read back, and that the move read back leads to the
+// same position.
+func TestNotation_RoundTrip(t *testing.T) {
+	for gameType := range gameFactories {
+		game, _ := NewGame(gameType, nil)
+		if _, ok := game.(Notation); !ok {
+			continue
+		}
+
+		t.Run(gameType, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(3, 3))
+			gen := game.(MoveGenerator)
+			notation := game.(Notation)
+
+			for ply := 0; ply < moveGenPlies && !gen.IsGameOver(); ply++ {
+				player := gen.CurrentPlayer()
+				moves := gen.LegalMoves()
+				for _, move := range moves {
+					text, err := notation.FormatMove(move)
+					if err != nil {
+						t.Fatalf("Expected %v to be written, but got %v", move, err)
+					}
+					parsed, err := notation.ParseMove(text)
+					if err != nil {
+						t.Fatalf("Expected %q to be read back, but got %v", text, err)
+					}
+
+					want := gen.Clone().(MoveGenerator)
+					want.HandleMove(player, move)
+					got := gen.Clone().(MoveGenerator)
+					if err := got.HandleMove(player, parsed); err != nil {
+						t.Fatalf("Expected %q to be accepted, but got %v", text, err)
+					}
+					if got.PositionKey() != want.PositionKey() {
+						t.Fatalf("Expected %q to play %v, but got %v", text, move, parsed)
+					}
+				}
+
+				if err := gen.HandleMove(player, moves[r.IntN(len(moves))]); err != nil {
+					t.Fatalf("Expected no error, but got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMoveList_RunTogether(t *testing.T) {
+	game, _ := NewGame("connect-four", nil)
+
+	moves, err := ParseMoveList(game, "4453 p1/2")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(moves) != 5 {
+		t.Fatalf("Expected 5 moves, but got %d", len(moves))
+	}
+	if col := moves[3].(map[string]any)["column"]; col != float64(2) {
+		t.Errorf("Expected the fourth move in column index 2, but got %v", col)
+	}
+	if drop := moves[4].(map[string]any)["dropColumn"]; drop != float64(1) {
+		t.Errorf("Expected the last move to drop in column index 1, but got %v", drop)
+	}
+
+	if _, err := ParseMoveList(game, "44q3"); err == nil {
+		t.Error("Expected an error for an unknown move, but got nil")
+	}
+}
+
+func TestFormatMoveList(t *testing.T) {
+	game, _ := NewGame("hex", nil)
+	text, err := FormatMoveList(game, []any{
+		map[string]any{"row": float64(2), "col": float64(3)},
+		map[string]any{"type": "swap"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if text != "d3 swap" {
+		t.Errorf("Expected \"d3 swap\", but got %q", text)
+	}
+}
+
+func TestPlayMoveList_LeavesGameUntouchedOnError(t *testing.T) {
+	game, _ := NewGame("tic-tac-toe", nil)
+
+	if err := PlayMoveList(game, "5 1 5"); err == nil {
+		t.Fatal("Expected an error for playing an occupied cell, but got nil")
+	}
+	if board := game.(*TicTacToe).Board; board != [9]string{} {
+		t.Errorf("Expected an empty board, but got %v", board)
+	}
+
+	if err := PlayMoveList(game, "5 1"); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if board := game.(*TicTacToe).Board; board[4] != "X" || board[0] != "O" {
+		t.Errorf("Expected X in the centre and O in the corner, but got %v", board)
+	}
+}
+
+func TestNewGameAtPosition(t *testing.T) {
+	game, err := NewGameAtPosition("go", []byte(`{"size": 9}`), "e5 c3 pass")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	board := game.(*Go).Board
+	if board[4][4] != "B" || board[6][2] != "W" {
+		t.Errorf("Expected black on e5 and white on c3, but got %v", board)
+	}
+	if turn := game.(MoveGenerator).CurrentPlayer(); turn != 1 {
+		t.Errorf("Expected white to move after black passed, but got player %d", turn)
+	}
+
+	if _, err := NewGameAtPosition("go", nil, "e5 e5"); err == nil {
+		t.Error("Expected an error for an illegal position, but got nil")
+	}
+	if _, err := NewGameAtPosition("battleship", nil, "a1"); err == nil {
+		t.Error("Expected an error for a game without notation, but got nil")
+	}
+}
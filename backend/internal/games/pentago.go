@@ -0,0 +1,338 @@
+package games
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	pentagoSize      = 6
+	pentagoQuadrant  = 3
+	pentagoWinLength = 5
+)
+
+func init() {
+	RegisterGame("pentago", NewPentago)
+	RegisterMetadata("pentago", pentagoMetadata)
+}
+
+var pentagoMetadata = Metadata{
+	DisplayName: "Pentago",
+	Description: "Place a marble, then twist one of the four quadrants. Get five in a row to win.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Place a marble on any empty cell, then rotate one quadrant a quarter turn",
+		"Five in a row after the rotation wins, in any direction",
+		"If the rotation makes five in a row for both players the game is drawn",
+		"A full board with no five in a row is a draw",
+	},
+}
+
+// Pentago is played on a 6x6 board made of four 3x3 quadrants, numbered
+// 0-3 from the top left in reading order. A move places a marble and then
+// rotates one quadrant a quarter turn, "cw" or "ccw". Lines are only checked
+// once the rotation is done, and if it leaves five in a row for both players
+// the game is a draw.
+type Pentago struct {
+	Board         [pentagoSize][pentagoSize]string `json:"board"`
+	CurrentTurn   int                              `json:"currentTurn"`
+	Winner        string                           `json:"winner"`
+	WinningCells  [][2]int                         `json:"winningCells"`
+	playerSymbols [2]string
+	moves         int
+}
+
+func NewPentago() (Game, error) {
+	p := &Pentago{}
+	p.Reset()
+	return p, nil
+}
+
+func (p *Pentago) HandleMove(playerIndex int, move any) error {
+	if p.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != p.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	rowFloat, ok := moveData["row"].(float64)
+	if !ok {
+		return fmt.Errorf("row must be a number")
+	}
+	colFloat, ok := moveData["col"].(float64)
+	if !ok {
+		return fmt.Errorf("col must be a number")
+	}
+	quadrantFloat, ok := moveData["quadrant"].(float64)
+	if !ok {
+		return fmt.Errorf("quadrant must be a number")
+	}
+	direction, ok := moveData["direction"].(string)
+	if !ok || (direction != "cw" && direction != "ccw") {
+		return fmt.Errorf("direction must be 'cw' or 'ccw'")
+	}
+
+	row, col, quadrant := int(rowFloat), int(colFloat), int(quadrantFloat)
+
+	if row < 0 || row >= pentagoSize || col < 0 || col >= pentagoSize {
+		return fmt.Errorf("out of bounds")
+	}
+	if quadrant < 0 || quadrant > 3 {
+		return fmt.Errorf("quadrant must be between 0 and 3")
+	}
+	if p.Board[row][col] != "" {
+		return fmt.Errorf("cell is already occupied")
+	}
+
+	symbol := p.playerSymbols[playerIndex]
+	p.Board[row][col] = symbol
+	p.rotateQuadrant(quadrant, direction == "cw")
+	p.moves++
+	p.judge(playerIndex)
+
+	p.CurrentTurn = (p.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+// judge ends the game after a move by playerIndex. Five in a row wins even
+// for the opponent, whose line a rotation can complete too.
+func (p *Pentago) judge(playerIndex int) {
+	ownCells := p.checkWinner(p.playerSymbols[playerIndex])
+	otherCells := p.checkWinner(p.playerSymbols[1-playerIndex])
+
+	switch {
+	case ownCells != nil && otherCells != nil:
+		p.Winner = "draw"
+		p.WinningCells = append(ownCells, otherCells...)
+	case ownCells != nil:
+		p.Winner = p.playerSymbols[playerIndex]
+		p.WinningCells = ownCells
+	case otherCells != nil:
+		p.Winner = p.playerSymbols[1-playerIndex]
+		p.WinningCells = otherCells
+	case p.moves == pentagoSize*pentagoSize:
+		p.Winner = "draw"
+	}
+}
+
+func (p *Pentago) rotateQuadrant(quadrant int, clockwise bool) {
+	top := (quadrant / 2) * pentagoQuadrant
+	left := (quadrant % 2) * pentagoQuadrant
+
+	var old [pentagoQuadrant][pentagoQuadrant]string
+	for r := range pentagoQuadrant {
+		for c := range pentagoQuadrant {
+			old[r][c] = p.Board[top+r][left+c]
+		}
+	}
+
+	last := pentagoQuadrant - 1
+	for r := range pentagoQuadrant {
+		for c := range pentagoQuadrant {
+			if clockwise {
+				p.Board[top+r][left+c] = old[last-c][r]
+			} else {
+				p.Board[top+r][left+c] = old[c][last-r]
+			}
+		}
+	}
+}
+
+// checkWinner scans the whole board for five in a row of symbol, since a
+// rotation can complete lines for either player anywhere.
+func (p *Pentago) checkWinner(symbol string) [][2]int {
+	directions := [][2]int{
+		{0, 1},
+		{1, 0},
+		{1, 1},
+		{1, -1},
+	}
+
+	for row := range pentagoSize {
+		for col := range pentagoSize {
+			for _, dir := range directions {
+				cells := make([][2]int, 0, pentagoWinLength)
+				for i := range pentagoWinLength {
+					r, c := row+dir[0]*i, col+dir[1]*i
+					if r < 0 || r >= pentagoSize || c < 0 || c >= pentagoSize || p.Board[r][c] != symbol {
+						break
+					}
+					cells = append(cells, [2]int{r, c})
+				}
+				if len(cells) == pentagoWinLength {
+					return cells
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Pentago) GetGameState() any {
+	return p
+}
+
+func (p *Pentago) IsGameOver() bool {
+	return p.Winner != ""
+}
+
+func (p *Pentago) GetWinner() string {
+	return p.Winner
+}
+
+func (p *Pentago) Reset() {
+	p.Board = [pentagoSize][pentagoSize]string{}
+	p.CurrentTurn = 0
+	p.Winner = ""
+	p.WinningCells = nil
+	p.playerSymbols = [2]string{"W", "B"}
+	p.moves = 0
+}
+
+type pentagoPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+	Moves         int       `json:"moves"`
+}
+
+func (p *Pentago) Clone() Game {
+	cloned := *p
+	cloned.WinningCells = slices.Clone(p.WinningCells)
+	return &cloned
+}
+
+func (p *Pentago) MarshalState() ([]byte, error) {
+	return marshalState(p, pentagoPrivate{PlayerSymbols: p.playerSymbols, Moves: p.moves})
+}
+
+func (p *Pentago) UnmarshalState(data []byte) error {
+	var restored Pentago
+	var private pentagoPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.moves = private.Moves
+	*p = restored
+	return nil
+}
+
+// afterSetup recounts the marbles, which tell when a full board is a draw,
+// and judges the position as if the last player to move had just moved.
+func (p *Pentago) afterSetup() {
+	p.moves = 0
+	for _, row := range p.Board {
+		for _, cell := range row {
+			if cell != "" {
+				p.moves++
+			}
+		}
+	}
+	p.judge(1 - p.CurrentTurn)
+}
+
+func (p *Pentago) ValidatePosition() error {
+	var counts [2]int
+	for _, row := range p.Board {
+		if err := checkPieces(row[:], p.playerSymbols[:]...); err != nil {
+			return err
+		}
+		for i, symbol := range p.playerSymbols {
+			counts[i] += countPieces(row[:], symbol)
+		}
+	}
+	return checkAlternation(counts, p.CurrentTurn)
+}
+
+func (p *Pentago) CurrentPlayer() int {
+	return p.CurrentTurn
+}
+
+func (p *Pentago) LegalMoves() []any {
+	if p.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	for r := range p.Board {
+		for c := range p.Board[r] {
+			if p.Board[r][c] != "" {
+				continue
+			}
+			for quadrant := range 4 {
+				for _, direction := range []string{"cw", "ccw"} {
+					moves = append(moves, map[string]any{
+						"row":       float64(r),
+						"col":       float64(c),
+						"quadrant":  float64(quadrant),
+						"direction": direction,
+					})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func (p *Pentago) PlayerSymbol(playerIndex int) string {
+	return p.playerSymbols[playerIndex]
+}
+
+func (p *Pentago) PositionKey() string {
+	return stateKey(p)
+}
+
+// FormatMove names the cell by column letter and row number from the
+// top-left corner, then after a slash the quadrant, numbered 1 to 4 row by
+// row, and "R" for a clockwise or "L" for an anticlockwise turn, as in
+// "b2/1R".
+func (p *Pentago) FormatMove(move any) (string, error) {
+	row, err := moveField(move, "row")
+	if err != nil {
+		return "", err
+	}
+	col, err := moveField(move, "col")
+	if err != nil {
+		return "", err
+	}
+	quadrant, err := moveField(move, "quadrant")
+	if err != nil {
+		return "", err
+	}
+	moveData := move.(map[string]any)
+	turn := map[any]string{"cw": "R", "ccw": "L"}[moveData["direction"]]
+	if turn == "" {
+		return "", fmt.Errorf("direction must be 'cw' or 'ccw'")
+	}
+	return fmt.Sprintf("%s/%d%s", cellName(row, col), quadrant+1, turn), nil
+}
+
+func (p *Pentago) ParseMove(text string) (any, error) {
+	cellText, turnText, ok := strings.Cut(strings.ToUpper(text), "/")
+	if !ok || len(turnText) != 2 || turnText[0] < '1' || turnText[0] > '4' {
+		return nil, fmt.Errorf("%q is not a move such as b2/1R", text)
+	}
+	direction := map[byte]string{'R': "cw", 'L': "ccw"}[turnText[1]]
+	if direction == "" {
+		return nil, fmt.Errorf("%q is not a move such as b2/1R", text)
+	}
+	row, col, err := parseCellName(cellText)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"row":       float64(row),
+		"col":       float64(col),
+		"quadrant":  float64(turnText[0] - '1'),
+		"direction": direction,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package games
+
+import (
+	"testing"
+)
+
+func makePentagoMovePayload(row, col, quadrant int, direction string) any {
+	return map[string]any{
+		"row":       float64(row),
+		"col":       float64(col),
+		"quadrant":  float64(quadrant),
+		"direction": direction,
+	}
+}
+
+func TestPentago_PlaceAndRotate(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction string
+		want      [2]int
+	}{
+		{"clockwise", "cw", [2]int{0, 2}},
+		{"counter-clockwise", "ccw", [2]int{2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, _ := NewPentago()
+			p := game.(*Pentago)
+
+			if err := game.HandleMove(0, makePentagoMovePayload(0, 0, 0, tt.direction)); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if p.Board[tt.want[0]][tt.want[1]] != "W" || p.Board[0][0] != "" {
+				t.Errorf("Expected the marble to rotate to %v", tt.want)
+			}
+			if p.CurrentTurn != 1 {
+				t.Errorf("Expected CurrentTurn to be 1, but got %d", p.CurrentTurn)
+			}
+		})
+	}
+}
+
+func TestPentago_InvalidMoves(t *testing.T) {
+	game, _ := NewPentago()
+	game.HandleMove(0, makePentagoMovePayload(4, 4, 0, "cw"))
+
+	invalid := []struct {
+		name string
+		move any
+	}{
+		{"occupied cell", makePentagoMovePayload(4, 4, 0, "cw")},
+		{"out of bounds", makePentagoMovePayload(6, 0, 0, "cw")},
+		{"bad quadrant", makePentagoMovePayload(0, 0, 4, "cw")},
+		{"bad direction", makePentagoMovePayload(0, 0, 0, "left")},
+		{"missing rotation", map[string]any{"row": float64(0), "col": float64(0)}},
+	}
+
+	for _, tt := range invalid {
+		if err := game.HandleMove(1, tt.move); err == nil {
+			t.Errorf("Expected an error for %s, but got nil", tt.name)
+		}
+	}
+}
+
+func TestPentago_WinConditions(t *testing.T) {
+	tests := []struct {
+		name string
+		move any
+		want string
+	}{
+		{"mover completes five", makePentagoMovePayload(0, 4, 2, "cw"), "W"},
+		{"rotation completes the opponent's five", makePentagoMovePayload(1, 0, 3, "ccw"), "B"},
+		{"both complete five", makePentagoMovePayload(0, 4, 3, "ccw"), "draw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, _ := NewPentago()
+			p := game.(*Pentago)
+			for col := range 4 {
+				p.Board[0][col] = "W"
+			}
+			for col := range 3 {
+				p.Board[5][col] = "B"
+			}
+			p.Board[3][3] = "B"
+			p.Board[4][3] = "B"
+
+			if err := game.HandleMove(0, tt.move); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if winner := game.GetWinner(); winner != tt.want {
+				t.Errorf("Expected winner to be '%s', but got '%s'", tt.want, winner)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// positionSetup is implemented by games that keep bookkeeping derived from
+// the board, such as move counters, union-find trees or repetition history.
+// afterSetup rebuilds it once SetPosition has replaced the board, and ends
+// the game if the position is already decided, judging it as if the player
+// who is not to move had just moved.
+type positionSetup interface {
+	afterSetup()
+}
+
+// PositionValidator is implemented by games whose positions can be set up
+// by hand. ValidatePosition checks a set-up position against the rules of
+// the game: which pieces may stand where, how many of them there can be and
+// whether they fit the player to move.
+type PositionValidator interface {
+	ValidatePosition() error
+}
+
+// SetPosition sets up a position by hand. position is a JSON object with
+// some of the fields of the game's state as clients see it. Only
+// "currentTurn" and the fixed-size arrays the game starts with, such as the
+// board, may be given, and each must have the same shape as in a new game.
+// Everything else keeps its value from a new game, except what the game
+// derives from the board in afterSetup, which includes the winner of a
+// finished position. The position must pass the game's ValidatePosition
+// and, unless it is finished, leave the player to move a legal move. The
+// game is left untouched if the position is rejected.
+func SetPosition(game Game, position json.RawMessage) error {
+	if _, ok := game.(PositionValidator); !ok {
+		return fmt.Errorf("positions cannot be set up for this game")
+	}
+
+	var edit map[string]json.RawMessage
+	if err := json.Unmarshal(position, &edit); err != nil || edit == nil {
+		return fmt.Errorf("position must be a JSON object")
+	}
+
+	fresh := game.Clone()
+	fresh.Reset()
+	data, err := fresh.MarshalState()
+	if err != nil {
+		return err
+	}
+	var saved savedState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(saved.State, &state); err != nil {
+		return err
+	}
+
+	for key, value := range edit {
+		if err := checkPositionField(key, state[key], value); err != nil {
+			return err
+		}
+		state[key] = value
+	}
+
+	if saved.State, err = json.Marshal(state); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(saved); err != nil {
+		return err
+	}
+
+	if err := fresh.UnmarshalState(data); err != nil {
+		return err
+	}
+	if err := fresh.(PositionValidator).ValidatePosition(); err != nil {
+		return err
+	}
+	if setup, ok := fresh.(positionSetup); ok {
+		setup.afterSetup()
+	}
+	if moves, ok := fresh.(MoveGenerator); ok && !fresh.IsGameOver() && len(moves.LegalMoves()) == 0 {
+		return fmt.Errorf("the player to move has no legal move in this position")
+	}
+	if data, err = fresh.MarshalState(); err != nil {
+		return err
+	}
+	return game.UnmarshalState(data)
+}
+
+func checkPositionField(key string, current, value json.RawMessage) error {
+	if key == "currentTurn" {
+		var turn int
+		if err := json.Unmarshal(value, &turn); err != nil || (turn != 0 && turn != 1) {
+			return fmt.Errorf("currentTurn must be 0 or 1")
+		}
+		return nil
+	}
+
+	// Fields missing from a new game decode to nil and are rejected here.
+	var want, got any
+	json.Unmarshal(current, &want)
+	if rows, ok := want.([]any); !ok || len(rows) == 0 {
+		return fmt.Errorf("%s cannot be set in a position", key)
+	}
+	if err := json.Unmarshal(value, &got); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if !sameShape(want, got) {
+		return fmt.Errorf("%s does not have the shape of the board", key)
+	}
+	return nil
+}
+
+// sameShape reports whether got has the array lengths and value kinds of
+// want. Numbers must be whole, since every numeric board value is a count
+// or an index.
+func sameShape(want, got any) bool {
+	switch want := want.(type) {
+	case []any:
+		got, ok := got.([]any)
+		if !ok || len(got) != len(want) {
+			return false
+		}
+		for i := range want {
+			if !sameShape(want[i], got[i]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		_, ok := got.(string)
+		return ok
+	case bool:
+		_, ok := got.(bool)
+		return ok
+	case float64:
+		n, ok := got.(float64)
+		return ok && n == math.Trunc(n) && math.Abs(n) <= 1000
+	default:
+		return false
+	}
+}
+
+// checkPieces rejects cells that hold anything but one of pieces or nothing.
+func checkPieces(cells []string, pieces ...string) error {
+	for _, cell := range cells {
+		if cell != "" && !slices.Contains(pieces, cell) {
+			return fmt.Errorf("%q is not a piece in this game", cell)
+		}
+	}
+	return nil
+}
+
+// countPieces counts the cells holding one of pieces.
+func countPieces(cells []string, pieces ...string) int {
+	count := 0
+	for _, cell := range cells {
+		if slices.Contains(pieces, cell) {
+			count++
+		}
+	}
+	return count
+}
+
+// checkAlternation checks the player to move in games where every move adds
+// pieces of the player making it, counted in moves, starting with player 0
+// on an empty board. The first player is then either level or one ahead.
+func checkAlternation(moves [2]int, turn int) error {
+	if moves[0]-moves[1] != turn {
+		return fmt.Errorf("player %d cannot be to move after %d and %d moves", turn+1, moves[0], moves[1])
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package games
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSetPosition_RejectsBadPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+	}{
+		{"not an object", `["X"]`},
+		{"wrong board length", `{"board": ["X"]}`},
+		{"wrong cell kind", `{"board": [1, "", "", "", "", "", "", "", ""]}`},
+		{"scalar field", `{"winner": "X"}`},
+		{"unknown field", `{"pieces": ["X"]}`},
+		{"bad turn", `{"currentTurn": 2}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, _ := NewTicTacToe()
+			before := mustMarshalState(t, game)
+			if err := SetPosition(game, json.RawMessage(tt.position)); err == nil {
+				t.Fatal("Expected an error, but got nil")
+			}
+			if !bytes.Equal(before, mustMarshalState(t, game)) {
+				t.Error("Expected a rejected position to leave the game untouched")
+			}
+		})
+	}
+}
+
+// grid builds a board of rows by cols empty cells with pieces placed on it.
+func grid(rows, cols int, pieces map[[2]int]string) [][]string {
+	board := make([][]string, rows)
+	for r := range board {
+		board[r] = make([]string, cols)
+	}
+	for at, piece := range pieces {
+		board[at[0]][at[1]] = piece
+	}
+	return board
+}
+
+func TestSetPosition_RejectsInvalidPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType string
+		position map[string]any
+	}{
+		{"unknown piece", "tic-tac-toe", map[string]any{"board": []string{"Q", "", "", "", "", "", "", "", ""}}},
+		{"counts do not fit the turn", "tic-tac-toe", map[string]any{"board": []string{"X", "X", "O", "", "", "", "", "", ""}, "currentTurn": 0}},
+		{"meta board does not match", "ultimate-tic-tac-toe", map[string]any{"metaBoard": []string{"X", "", "", "", "", "", "", "", ""}}},
+		{"floating disc", "connect-four", map[string]any{"board": grid(6, 7, map[[2]int]string{{0, 3}: "R"})}},
+		{"unpaired domino", "domineering", map[string]any{"board": grid(8, 8, map[[2]int]string{{0, 0}: "H"})}},
+		{"missing amazons", "amazons", map[string]any{"board": grid(10, 10, nil)}},
+		{"man on a light square", "checkers", map[string]any{"board": grid(8, 8, map[[2]int]string{{0, 0}: "W", {7, 0}: "B"})}},
+		{"man on the crowning row", "checkers", map[string]any{"board": grid(8, 8, map[[2]int]string{{0, 1}: "B", {7, 0}: "W"})}},
+		{"piece on its goal row", "breakthrough", map[string]any{"board": grid(8, 8, map[[2]int]string{{0, 0}: "W", {3, 3}: "B"})}},
+		{"box claimed without its sides", "dots-and-boxes", map[string]any{"boxes": grid(4, 4, map[[2]int]string{{0, 0}: "P1"})}},
+		{"group without liberties", "go", map[string]any{"board": grid(9, 9, map[[2]int]string{{0, 0}: "B", {0, 1}: "W", {1, 0}: "W"})}},
+		{"dead stones", "go", map[string]any{"accepted": []bool{true, false}}},
+		{"stones on the board twice", "hex", map[string]any{"board": grid(11, 11, map[[2]int]string{{0, 0}: "R", {0, 1}: "R"})}},
+		{"seeds missing", "kalah", map[string]any{"pits": [2][6]int{}}},
+		{"too many pieces", "nine-mens-morris", map[string]any{"board": []string{"W", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", ""}}},
+		{"walls do not add up", "quoridor", map[string]any{"wallsLeft": []int{9, 10}}},
+		{"pawn on its goal row", "quoridor", map[string]any{"pawns": [][]int{{0, 4}, {0, 3}}}},
+		{"worker placed out of turn", "santorini", map[string]any{"workers": [2][2][2]int{{{-1, -1}, {-1, -1}}, {{0, 0}, {-1, -1}}}}},
+		{"bad subtraction set", "nim", map[string]any{"subtraction": []int{0, 2, 3}}},
+		{"player to move has a line", "tic-tac-toe", map[string]any{"board": []string{"X", "X", "X", "O", "O", "", "O", "", ""}, "currentTurn": 0}},
+		{"player to move on the goal row", "breakthrough", map[string]any{"board": grid(8, 8, map[[2]int]string{{7, 0}: "B", {3, 3}: "W"}), "currentTurn": 1}},
+		{"hidden information", "battleship", map[string]any{"currentTurn": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := NewGame(tt.gameType, nil)
+			if err != nil {
+				t.Fatalf("Expected no error creating %s, but got %v", tt.gameType, err)
+			}
+			position, _ := json.Marshal(tt.position)
+			before := mustMarshalState(t, game)
+			if err := SetPosition(game, position); err == nil {
+				t.Fatal("Expected an error, but got nil")
+			}
+			if !bytes.Equal(before, mustMarshalState(t, game)) {
+				t.Error("Expected a rejected position to leave the game untouched")
+			}
+		})
+	}
+}
+
+func TestSetPosition_EndsFinishedPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType string
+		options  string
+		position map[string]any
+		winner   string
+	}{
+		{"line", "tic-tac-toe", "", map[string]any{"board": []string{"X", "X", "X", "O", "O", "", "", "", ""}, "currentTurn": 1}, "X"},
+		{"full board", "tic-tac-toe", "", map[string]any{"board": []string{"X", "O", "X", "X", "O", "O", "O", "X", "X"}, "currentTurn": 1}, "draw"},
+		{"no room for a domino", "domineering", `{"rows": 2, "cols": 2}`, map[string]any{"board": grid(2, 2, map[[2]int]string{{0, 0}: "H", {0, 1}: "H"}), "currentTurn": 1}, "H"},
+		{"four in a column", "connect-four", "", map[string]any{"board": grid(6, 7, map[[2]int]string{
+			{2, 0}: "R", {3, 0}: "R", {4, 0}: "R", {5, 0}: "R", {3, 1}: "B", {4, 1}: "B", {5, 1}: "B",
+		}), "currentTurn": 1}, "R"},
+		{"pawn on its goal row", "quoridor", "", map[string]any{"pawns": [][]int{{0, 4}, {3, 4}}, "currentTurn": 1}, "P1"},
+		{"piece on its goal row", "breakthrough", "", map[string]any{"board": grid(8, 8, map[[2]int]string{{0, 0}: "W", {3, 3}: "B"}), "currentTurn": 1}, "W"},
+		{"last stick taken", "nim", "", map[string]any{"heaps": []int{0}}, "P1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options json.RawMessage
+			if tt.options != "" {
+				options = json.RawMessage(tt.options)
+			}
+			game, err := NewGame(tt.gameType, options)
+			if err != nil {
+				t.Fatalf("Expected no error creating %s, but got %v", tt.gameType, err)
+			}
+			position, _ := json.Marshal(tt.position)
+			if err := SetPosition(game, position); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if game.GetWinner() != tt.winner {
+				t.Errorf("Expected winner %q, but got %q", tt.winner, game.GetWinner())
+			}
+		})
+	}
+}
+
+func TestSetPosition_StartsSantoriniPlay(t *testing.T) {
+	game, _ := NewSantorini(nil)
+	position := `{"workers": [[[2, 2], [4, 4]], [[0, 0], [0, 4]]], "levels": [
+		[0, 0, 0, 0, 0],
+		[0, 1, 0, 0, 0],
+		[0, 0, 0, 0, 0],
+		[0, 0, 0, 0, 0],
+		[0, 0, 0, 0, 4]
+	]}`
+	if err := SetPosition(game, json.RawMessage(position)); err == nil {
+		t.Fatal("Expected an error for a worker on a dome, but got nil")
+	}
+
+	position = `{"workers": [[[2, 2], [4, 4]], [[0, 0], [0, 4]]]}`
+	if err := SetPosition(game, json.RawMessage(position)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if s := game.(*Santorini); s.Phase != "play" {
+		t.Errorf("Expected play to start with all workers placed, but got phase %s", s.Phase)
+	}
+}
+
+func TestSetPosition_RebuildsHexConnections(t *testing.T) {
+	game, _ := NewHex(json.RawMessage(`{"size": 5}`))
+	position := `{"board": [
+		["R", "", "", "", ""],
+		["R", "", "", "", ""],
+		["R", "", "", "", ""],
+		["R", "", "", "B", "B"],
+		["", "B", "B", "", ""]
+	]}`
+	if err := SetPosition(game, json.RawMessage(position)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if err := game.HandleMove(0, makeHexMovePayload(4, 0)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if game.GetWinner() != "R" {
+		t.Errorf("Expected the set-up stones to connect for R, but got winner %q", game.GetWinner())
+	}
+}
+
+func TestSetPosition_RecountsDotsAndBoxesScores(t *testing.T) {
+	game, _ := NewDotsAndBoxes(json.RawMessage(`{"rows": 2, "cols": 2}`))
+	position := `{
+		"hLines": [["P1", ""], ["P1", ""], ["", ""]],
+		"vLines": [["P1", "P2", ""], ["", "", ""]],
+		"boxes": [["P2", ""], ["", ""]]
+	}`
+	if err := SetPosition(game, json.RawMessage(position)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	d := game.(*DotsAndBoxes)
+	if d.Scores != [2]int{0, 1} || d.BoxesCompleted != 1 {
+		t.Errorf("Expected the claimed box to count for P2, but got scores %v", d.Scores)
+	}
+}
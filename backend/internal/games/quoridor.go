@@ -0,0 +1,471 @@
+package games
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	quoridorSize  = 9
+	quoridorWalls = 10
+)
+
+func init() {
+	RegisterGame("quoridor", NewQuoridor)
+	RegisterMetadata("quoridor", quoridorMetadata)
+}
+
+var quoridorMetadata = Metadata{
+	DisplayName: "Quoridor",
+	Description: "Race your pawn to the opposite side of the board while placing walls to slow your opponent down.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"On your turn, either move your pawn one square or place a wall",
+		"A pawn facing the other pawn may jump over it, or diagonally when a wall is behind it",
+		"Each player has 10 walls, each two squares long",
+		"A wall may never cut a pawn off from its goal row",
+		"The first pawn to reach the opposite row wins",
+	},
+}
+
+var quoridorDirections = [4][2]int{
+	{-1, 0},
+	{1, 0},
+	{0, -1},
+	{0, 1},
+}
+
+// Quoridor is played on a 9x9 board. Walls are two cells long and are
+// identified by the intersection at their top-left corner: HWalls[r][c]
+// separates rows r and r+1 across columns c and c+1, VWalls[r][c] separates
+// columns c and c+1 across rows r and r+1. Player 0 starts at the bottom and
+// must reach row 0; player 1 starts at the top and must reach row 8.
+type Quoridor struct {
+	Pawns         [2][2]int                                `json:"pawns"`
+	HWalls        [quoridorSize - 1][quoridorSize - 1]bool `json:"hWalls"`
+	VWalls        [quoridorSize - 1][quoridorSize - 1]bool `json:"vWalls"`
+	WallsLeft     [2]int                                   `json:"wallsLeft"`
+	CurrentTurn   int                                      `json:"currentTurn"`
+	Winner        string                                   `json:"winner"`
+	playerSymbols [2]string
+}
+
+func NewQuoridor() (Game, error) {
+	q := &Quoridor{}
+	q.Reset()
+	return q, nil
+}
+
+func (q *Quoridor) HandleMove(playerIndex int, move any) error {
+	if q.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != q.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	moveType, ok := moveData["type"].(string)
+	if !ok {
+		return fmt.Errorf("type must be a string")
+	}
+
+	rowFloat, ok := moveData["row"].(float64)
+	if !ok {
+		return fmt.Errorf("row must be a number")
+	}
+
+	colFloat, ok := moveData["col"].(float64)
+	if !ok {
+		return fmt.Errorf("col must be a number")
+	}
+
+	row := int(rowFloat)
+	col := int(colFloat)
+
+	switch moveType {
+	case "move":
+		if err := q.movePawn(playerIndex, row, col); err != nil {
+			return err
+		}
+		if row == q.goalRow(playerIndex) {
+			q.Winner = q.playerSymbols[playerIndex]
+		}
+
+	case "wall":
+		orientation, ok := moveData["orientation"].(string)
+		if !ok {
+			return fmt.Errorf("orientation must be a string")
+		}
+		if err := q.placeWall(playerIndex, row, col, orientation); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("invalid move type: must be 'move' or 'wall'")
+	}
+
+	q.CurrentTurn = (q.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+func (q *Quoridor) movePawn(playerIndex, row, col int) error {
+	if row < 0 || row >= quoridorSize || col < 0 || col >= quoridorSize {
+		return fmt.Errorf("out of bounds")
+	}
+
+	for _, dest := range q.pawnMoves(playerIndex) {
+		if dest == [2]int{row, col} {
+			q.Pawns[playerIndex] = dest
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal pawn move")
+}
+
+// pawnMoves lists the cells the player's pawn can reach this turn. A pawn
+// facing the opponent jumps straight over it, or diagonally to either side
+// when a wall or the board edge is behind the opponent.
+func (q *Quoridor) pawnMoves(playerIndex int) [][2]int {
+	pawn := q.Pawns[playerIndex]
+	opponent := q.Pawns[1-playerIndex]
+	var moves [][2]int
+
+	for _, dir := range quoridorDirections {
+		next := [2]int{pawn[0] + dir[0], pawn[1] + dir[1]}
+		if !q.canStep(pawn, next) {
+			continue
+		}
+		if next != opponent {
+			moves = append(moves, next)
+			continue
+		}
+
+		behind := [2]int{next[0] + dir[0], next[1] + dir[1]}
+		if q.canStep(next, behind) {
+			moves = append(moves, behind)
+			continue
+		}
+
+		sides := [2][2]int{{dir[1], dir[0]}, {-dir[1], -dir[0]}}
+		for _, side := range sides {
+			diagonal := [2]int{next[0] + side[0], next[1] + side[1]}
+			if q.canStep(next, diagonal) {
+				moves = append(moves, diagonal)
+			}
+		}
+	}
+	return moves
+}
+
+// canStep reports whether a pawn may step between two orthogonally adjacent
+// cells, ignoring other pawns.
+func (q *Quoridor) canStep(from, to [2]int) bool {
+	if to[0] < 0 || to[0] >= quoridorSize || to[1] < 0 || to[1] >= quoridorSize {
+		return false
+	}
+
+	if from[1] == to[1] {
+		upper, col := min(from[0], to[0]), from[1]
+		if col < quoridorSize-1 && q.HWalls[upper][col] {
+			return false
+		}
+		if col > 0 && q.HWalls[upper][col-1] {
+			return false
+		}
+		return true
+	}
+
+	row, left := from[0], min(from[1], to[1])
+	if row < quoridorSize-1 && q.VWalls[row][left] {
+		return false
+	}
+	if row > 0 && q.VWalls[row-1][left] {
+		return false
+	}
+	return true
+}
+
+func (q *Quoridor) placeWall(playerIndex, row, col int, orientation string) error {
+	if q.WallsLeft[playerIndex] == 0 {
+		return fmt.Errorf("you have no walls left")
+	}
+
+	if row < 0 || row >= quoridorSize-1 || col < 0 || col >= quoridorSize-1 {
+		return fmt.Errorf("wall out of bounds")
+	}
+
+	if q.HWalls[row][col] || q.VWalls[row][col] {
+		return fmt.Errorf("wall crosses or overlaps another wall")
+	}
+
+	switch orientation {
+	case "h":
+		if (col > 0 && q.HWalls[row][col-1]) || (col < quoridorSize-2 && q.HWalls[row][col+1]) {
+			return fmt.Errorf("wall crosses or overlaps another wall")
+		}
+		q.HWalls[row][col] = true
+		if !q.hasPathToGoal(0) || !q.hasPathToGoal(1) {
+			q.HWalls[row][col] = false
+			return fmt.Errorf("wall would block a player's path to their goal")
+		}
+
+	case "v":
+		if (row > 0 && q.VWalls[row-1][col]) || (row < quoridorSize-2 && q.VWalls[row+1][col]) {
+			return fmt.Errorf("wall crosses or overlaps another wall")
+		}
+		q.VWalls[row][col] = true
+		if !q.hasPathToGoal(0) || !q.hasPathToGoal(1) {
+			q.VWalls[row][col] = false
+			return fmt.Errorf("wall would block a player's path to their goal")
+		}
+
+	default:
+		return fmt.Errorf("invalid orientation: must be 'h' or 'v'")
+	}
+
+	q.WallsLeft[playerIndex]--
+	return nil
+}
+
+// hasPathToGoal runs a breadth-first search from the player's pawn to any
+// cell of their goal row. Pawns never block a path, only walls do.
+func (q *Quoridor) hasPathToGoal(playerIndex int) bool {
+	var visited [quoridorSize][quoridorSize]bool
+	start := q.Pawns[playerIndex]
+	visited[start[0]][start[1]] = true
+	queue := [][2]int{start}
+	goal := q.goalRow(playerIndex)
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		if cell[0] == goal {
+			return true
+		}
+
+		for _, dir := range quoridorDirections {
+			next := [2]int{cell[0] + dir[0], cell[1] + dir[1]}
+			if !q.canStep(cell, next) || visited[next[0]][next[1]] {
+				continue
+			}
+			visited[next[0]][next[1]] = true
+			queue = append(queue, next)
+		}
+	}
+	return false
+}
+
+func (q *Quoridor) goalRow(playerIndex int) int {
+	if playerIndex == 0 {
+		return 0
+	}
+	return quoridorSize - 1
+}
+
+func (q *Quoridor) GetGameState() any {
+	return q
+}
+
+func (q *Quoridor) IsGameOver() bool {
+	return q.Winner != ""
+}
+
+func (q *Quoridor) GetWinner() string {
+	return q.Winner
+}
+
+func (q *Quoridor) Reset() {
+	q.Pawns = [2][2]int{{quoridorSize - 1, quoridorSize / 2}, {0, quoridorSize / 2}}
+	q.HWalls = [quoridorSize - 1][quoridorSize - 1]bool{}
+	q.VWalls = [quoridorSize - 1][quoridorSize - 1]bool{}
+	q.WallsLeft = [2]int{quoridorWalls, quoridorWalls}
+	q.CurrentTurn = 0
+	q.Winner = ""
+	q.playerSymbols = [2]string{"P1", "P2"}
+}
+
+type quoridorPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+}
+
+func (q *Quoridor) Clone() Game {
+	cloned := *q
+	return &cloned
+}
+
+func (q *Quoridor) MarshalState() ([]byte, error) {
+	return marshalState(q, quoridorPrivate{PlayerSymbols: q.playerSymbols})
+}
+
+func (q *Quoridor) UnmarshalState(data []byte) error {
+	var restored Quoridor
+	var private quoridorPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	*q = restored
+	return nil
+}
+
+// ValidatePosition checks the pawns and walls as placeWall would have: the
+// walls on the board and those left add up to the walls of both players, no
+// two walls overlap or cross, and each pawn can still reach its goal row,
+// which it must not have reached already.
+// afterSetup ends the game if the last player to move reached their goal
+// row.
+func (q *Quoridor) afterSetup() {
+	previous := 1 - q.CurrentTurn
+	if q.Pawns[previous][0] == q.goalRow(previous) {
+		q.Winner = q.playerSymbols[previous]
+	}
+}
+
+func (q *Quoridor) ValidatePosition() error {
+	for i, pawn := range q.Pawns {
+		if pawn[0] < 0 || pawn[0] >= quoridorSize || pawn[1] < 0 || pawn[1] >= quoridorSize {
+			return fmt.Errorf("pawn %d is out of bounds", i+1)
+		}
+		if pawn[0] == q.goalRow(i) && i == q.CurrentTurn {
+			return fmt.Errorf("pawn %d cannot be to move on its goal row", i+1)
+		}
+	}
+	if q.Pawns[0] == q.Pawns[1] {
+		return fmt.Errorf("pawns cannot share a square")
+	}
+
+	placed := 0
+	for row := range quoridorSize - 1 {
+		for col := range quoridorSize - 1 {
+			h, v := q.HWalls[row][col], q.VWalls[row][col]
+			if h && v {
+				return fmt.Errorf("walls cross at %d,%d", row+1, col+1)
+			}
+			if (h && col > 0 && q.HWalls[row][col-1]) || (v && row > 0 && q.VWalls[row-1][col]) {
+				return fmt.Errorf("walls overlap at %d,%d", row+1, col+1)
+			}
+			if h || v {
+				placed++
+			}
+		}
+	}
+	for _, left := range q.WallsLeft {
+		if left < 0 || left > quoridorWalls {
+			return fmt.Errorf("players have between 0 and %d walls left", quoridorWalls)
+		}
+	}
+	if placed+q.WallsLeft[0]+q.WallsLeft[1] != 2*quoridorWalls {
+		return fmt.Errorf("%d walls on the board do not match %d and %d walls left", placed, q.WallsLeft[0], q.WallsLeft[1])
+	}
+
+	if !q.hasPathToGoal(0) || !q.hasPathToGoal(1) {
+		return fmt.Errorf("walls block a player's path to their goal")
+	}
+	return nil
+}
+
+func (q *Quoridor) CurrentPlayer() int {
+	return q.CurrentTurn
+}
+
+// LegalMoves lists the pawn moves first, then every wall that passes the
+// overlap and path checks.
+func (q *Quoridor) LegalMoves() []any {
+	if q.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	for _, dest := range q.pawnMoves(q.CurrentTurn) {
+		moves = append(moves, map[string]any{"type": "move", "row": float64(dest[0]), "col": float64(dest[1])})
+	}
+	if q.WallsLeft[q.CurrentTurn] == 0 {
+		return moves
+	}
+
+	var walls []any
+	for r := range quoridorSize - 1 {
+		for c := range quoridorSize - 1 {
+			for _, orientation := range []string{"h", "v"} {
+				walls = append(walls, map[string]any{"type": "wall", "row": float64(r), "col": float64(c), "orientation": orientation})
+			}
+		}
+	}
+	return append(moves, legalAmong(q, walls)...)
+}
+
+func (q *Quoridor) PlayerSymbol(playerIndex int) string {
+	return q.playerSymbols[playerIndex]
+}
+
+func (q *Quoridor) PositionKey() string {
+	return stateKey(q)
+}
+
+// FormatMove names cells as in chess, with row 1 on player 0's side, so
+// player 0 starts on e1. A pawn move is the cell moved to, and a wall is the
+// lower-left of the four cells it touches followed by "h" or "v", as in
+// "e3h".
+func (q *Quoridor) FormatMove(move any) (string, error) {
+	kind, err := moveType(move)
+	if err != nil {
+		return "", err
+	}
+	row, err := moveField(move, "row")
+	if err != nil {
+		return "", err
+	}
+	col, err := moveField(move, "col")
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case "move":
+		return squareName([2]int{row, col}, quoridorSize), nil
+	case "wall":
+		orientation, _ := move.(map[string]any)["orientation"].(string)
+		if orientation != "h" && orientation != "v" {
+			return "", fmt.Errorf("invalid orientation: must be 'h' or 'v'")
+		}
+		return squareName([2]int{row + 1, col}, quoridorSize) + orientation, nil
+	default:
+		return "", fmt.Errorf("invalid move type: must be 'move' or 'wall'")
+	}
+}
+
+func (q *Quoridor) ParseMove(text string) (any, error) {
+	text = strings.ToLower(text)
+	if orientation, ok := strings.CutSuffix(text, "h"); ok {
+		return q.parseWall(orientation, "h")
+	}
+	if orientation, ok := strings.CutSuffix(text, "v"); ok {
+		return q.parseWall(orientation, "v")
+	}
+	cell, err := parseSquareName(text, quoridorSize)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"type": "move", "row": float64(cell[0]), "col": float64(cell[1])}, nil
+}
+
+func (q *Quoridor) parseWall(text, orientation string) (any, error) {
+	cell, err := parseSquareName(text, quoridorSize)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"type":        "wall",
+		"row":         float64(cell[0] - 1),
+		"col":         float64(cell[1]),
+		"orientation": orientation,
+	}, nil
+}
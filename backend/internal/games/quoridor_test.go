@@ -0,0 +1,133 @@
+package games
+
+import (
+	"testing"
+)
+
+func makeQuoridorPawnPayload(row int, col int) any {
+	return map[string]any{"type": "move", "row": float64(row), "col": float64(col)}
+}
+
+func makeQuoridorWallPayload(row int, col int, orientation string) any {
+	return map[string]any{"type": "wall", "row": float64(row), "col": float64(col), "orientation": orientation}
+}
+
+func TestQuoridor_PawnMove(t *testing.T) {
+	game, _ := NewQuoridor()
+	q := game.(*Quoridor)
+
+	if err := game.HandleMove(0, makeQuoridorPawnPayload(7, 4)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+	if q.Pawns[0] != [2]int{7, 4} {
+		t.Errorf("Expected pawn at 7,4, but got %v", q.Pawns[0])
+	}
+
+	err := game.HandleMove(1, makeQuoridorPawnPayload(2, 4))
+	if err == nil {
+		t.Fatal("Expected an error when moving two cells, but got nil")
+	}
+}
+
+func TestQuoridor_Jumps(t *testing.T) {
+	game, _ := NewQuoridor()
+	q := game.(*Quoridor)
+	q.Pawns = [2][2]int{{4, 4}, {3, 4}}
+
+	if err := game.HandleMove(0, makeQuoridorPawnPayload(2, 4)); err != nil {
+		t.Fatalf("Expected no error for a straight jump, but got %v", err)
+	}
+
+	q.Pawns = [2][2]int{{4, 4}, {3, 4}}
+	q.CurrentTurn = 0
+	q.HWalls[2][4] = true
+
+	err := game.HandleMove(0, makeQuoridorPawnPayload(2, 4))
+	if err == nil {
+		t.Fatal("Expected an error when jumping through a wall, but got nil")
+	}
+	if err := game.HandleMove(0, makeQuoridorPawnPayload(3, 3)); err != nil {
+		t.Fatalf("Expected no error for a diagonal jump, but got %v", err)
+	}
+}
+
+func TestQuoridor_WallBlocksMovement(t *testing.T) {
+	game, _ := NewQuoridor()
+	q := game.(*Quoridor)
+
+	if err := game.HandleMove(0, makeQuoridorWallPayload(0, 3, "h")); err != nil {
+		t.Fatalf("Expected no error placing a wall, but got %v", err)
+	}
+	if q.WallsLeft[0] != 9 {
+		t.Errorf("Expected 9 walls left, but got %d", q.WallsLeft[0])
+	}
+
+	err := game.HandleMove(1, makeQuoridorPawnPayload(1, 4))
+	if err == nil {
+		t.Fatal("Expected an error when moving through a wall, but got nil")
+	}
+}
+
+func TestQuoridor_OverlappingWall(t *testing.T) {
+	game, _ := NewQuoridor()
+
+	game.HandleMove(0, makeQuoridorWallPayload(4, 4, "h"))
+
+	for _, wall := range []struct {
+		row, col    int
+		orientation string
+	}{
+		{4, 4, "h"},
+		{4, 5, "h"},
+		{4, 3, "h"},
+		{4, 4, "v"},
+	} {
+		err := game.HandleMove(1, makeQuoridorWallPayload(wall.row, wall.col, wall.orientation))
+		if err == nil {
+			t.Errorf("Expected an error for wall %v, but got nil", wall)
+		}
+	}
+
+	if err := game.HandleMove(1, makeQuoridorWallPayload(3, 4, "v")); err != nil {
+		t.Fatalf("Expected no error for a touching but not crossing wall, but got %v", err)
+	}
+}
+
+func TestQuoridor_BlockedPathRejected(t *testing.T) {
+	game, _ := NewQuoridor()
+	q := game.(*Quoridor)
+
+	// Seal row 0 off from the rest of the board except through column 8.
+	q.HWalls[0][0] = true
+	q.HWalls[0][2] = true
+	q.HWalls[0][4] = true
+	q.HWalls[0][6] = true
+
+	err := game.HandleMove(0, makeQuoridorWallPayload(0, 7, "v"))
+	if err == nil {
+		t.Fatal("Expected an error when a wall cuts off a player's path, but got nil")
+	}
+	if q.VWalls[0][7] {
+		t.Error("Expected the rejected wall to be removed from the board")
+	}
+	if q.WallsLeft[0] != quoridorWalls || q.CurrentTurn != 0 {
+		t.Error("Expected a rejected wall to leave the wall count and turn unchanged")
+	}
+
+	if err := game.HandleMove(0, makeQuoridorWallPayload(1, 7, "v")); err != nil {
+		t.Fatalf("Expected no error for a wall that leaves a path, but got %v", err)
+	}
+}
+
+func TestQuoridor_WinCondition(t *testing.T) {
+	game, _ := NewQuoridor()
+	q := game.(*Quoridor)
+	q.Pawns = [2][2]int{{1, 0}, {5, 5}}
+
+	if err := game.HandleMove(0, makeQuoridorPawnPayload(0, 0)); err != nil {
+		t.Fatalf("Expected no error for the winning move, but got %v", err)
+	}
+	if game.GetWinner() != "P1" {
+		t.Errorf("Expected winner to be 'P1', but got '%s'", game.GetWinner())
+	}
+}
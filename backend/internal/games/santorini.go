@@ -0,0 +1,640 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	santoriniSize = 5
+	santoriniDome = 4
+)
+
+func init() {
+	RegisterGameWithOptions("santorini", NewSantorini)
+	RegisterMetadata("santorini", santoriniMetadata)
+}
+
+var santoriniMetadata = Metadata{
+	DisplayName: "Santorini",
+	Description: "Move your builders and raise towers. The first to climb onto the third level wins, with optional god powers.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	Options: []Option{
+		{Name: "gods", Label: "God powers", Type: OptionChoiceList, Default: [2]string{}, Choices: []any{"", "apollo", "artemis", "athena", "atlas", "demeter", "hephaestus", "minotaur", "pan", "prometheus"}, MinItems: 2, MaxItems: 2},
+	},
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"Each player places two workers on empty squares",
+		"A turn moves one worker to an adjacent square, then builds next to it",
+		"Workers may climb at most one level but may step down any number",
+		"Building on the third level places a dome, and domes block the square",
+		"Moving up onto the third level wins, as does leaving your opponent unable to move",
+	},
+}
+
+// Santorini is played on a 5x5 grid of building levels, where level 4 is a
+// dome. Each player places two workers, then every turn moves one worker to
+// a neighbouring square, climbing at most one level, and builds next to it
+// with the same worker. Moving up onto level 3 wins, and a player who cannot
+// complete a turn loses.
+//
+// A turn is sent as separate "move" and "build" actions so that god powers
+// can add optional extra ones; "end" passes on an optional action. Each
+// player may hold a god power chosen in the room options, which alters the
+// base rules through the santoriniPower hooks.
+type Santorini struct {
+	Levels        [santoriniSize][santoriniSize]int `json:"levels"`
+	Workers       [2][2][2]int                      `json:"workers"`
+	Gods          [2]string                         `json:"gods"`
+	Phase         string                            `json:"phase"`
+	CurrentTurn   int                               `json:"currentTurn"`
+	Turn          SantoriniTurn                     `json:"turn"`
+	UpBlocked     bool                              `json:"upBlocked"`
+	Winner        string                            `json:"winner"`
+	playerSymbols [2]string
+	powers        [2]santoriniPower
+}
+
+// SantoriniTurn tracks the actions taken so far in the current turn. Worker
+// is -1 until the player commits to a worker.
+type SantoriniTurn struct {
+	Worker          int    `json:"worker"`
+	Start           [2]int `json:"start"`
+	Moves           int    `json:"moves"`
+	Builds          int    `json:"builds"`
+	LastBuild       [2]int `json:"lastBuild"`
+	BuiltBeforeMove bool   `json:"builtBeforeMove"`
+	BuiltAfterMove  bool   `json:"builtAfterMove"`
+	MovedUp         bool   `json:"movedUp"`
+}
+
+type santoriniOptions struct {
+	Gods [2]string `json:"gods"`
+}
+
+func NewSantorini(options json.RawMessage) (Game, error) {
+	var opts santoriniOptions
+	if err := decodeOptions(options, &opts); err != nil {
+		return nil, err
+	}
+
+	s := &Santorini{Gods: opts.Gods}
+	if err := s.setPowers(opts.Gods); err != nil {
+		return nil, err
+	}
+
+	s.Reset()
+	return s, nil
+}
+
+func (s *Santorini) setPowers(gods [2]string) error {
+	for i, god := range gods {
+		if god == "" {
+			s.powers[i] = basePower{}
+			continue
+		}
+		power, ok := santoriniPowers[god]
+		if !ok {
+			return fmt.Errorf("unknown god power: %s", god)
+		}
+		s.powers[i] = power
+	}
+	return nil
+}
+
+func (s *Santorini) HandleMove(playerIndex int, move any) error {
+	if s.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != s.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	moveType, _ := moveData["type"].(string)
+
+	if s.Phase == "placement" {
+		if moveType != "place" {
+			return fmt.Errorf("workers must be placed first")
+		}
+		return s.place(playerIndex, moveData)
+	}
+
+	switch moveType {
+	case "move":
+		return s.move(playerIndex, moveData)
+	case "build":
+		return s.build(playerIndex, moveData)
+	case "end":
+		// a build before moving, as Prometheus may make, does not count
+		if s.Turn.Moves == 0 || !s.Turn.BuiltAfterMove {
+			return fmt.Errorf("you must move and build before ending your turn")
+		}
+		s.endTurn()
+		return nil
+	default:
+		return fmt.Errorf("invalid move type: must be 'move', 'build' or 'end'")
+	}
+}
+
+func (s *Santorini) place(playerIndex int, moveData map[string]any) error {
+	at, err := parseSquare(moveData["at"])
+	if err != nil {
+		return fmt.Errorf("at: %w", err)
+	}
+	if !s.inBounds(at) {
+		return fmt.Errorf("out of bounds")
+	}
+	if player, _ := s.occupant(at); player != -1 {
+		return fmt.Errorf("square is already occupied")
+	}
+
+	worker := 0
+	if s.Workers[playerIndex][0][0] != -1 {
+		worker = 1
+	}
+	s.Workers[playerIndex][worker] = at
+
+	if worker == 1 {
+		if playerIndex == 1 {
+			s.Phase = "play"
+		}
+		s.CurrentTurn = 1 - playerIndex
+	}
+
+	return nil
+}
+
+func (s *Santorini) move(playerIndex int, moveData map[string]any) error {
+	worker, err := s.turnWorker(moveData)
+	if err != nil {
+		return err
+	}
+	to, err := parseSquare(moveData["to"])
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+
+	power := s.powers[playerIndex]
+	if s.Turn.Moves >= power.maxMoves(s) || (s.Turn.Builds > 0 && !s.Turn.BuiltBeforeMove) {
+		return fmt.Errorf("you cannot move again this turn")
+	}
+
+	from := s.Workers[playerIndex][worker]
+	if !s.canMoveTo(playerIndex, from, to) {
+		return fmt.Errorf("illegal move")
+	}
+
+	if s.Turn.Worker == -1 {
+		s.Turn.Worker = worker
+		s.Turn.Start = from
+	}
+
+	if player, other := s.occupant(to); player != -1 {
+		dest, _ := power.displace(s, from, to)
+		s.Workers[player][other] = dest
+	}
+	s.Workers[playerIndex][worker] = to
+	s.Turn.Moves++
+	if s.level(to) > s.level(from) {
+		s.Turn.MovedUp = true
+	}
+
+	if power.wins(s, from, to) {
+		s.Winner = s.playerSymbols[playerIndex]
+		return nil
+	}
+
+	if !s.canBuildAny(playerIndex) && !s.canMoveAgain(playerIndex) {
+		s.Winner = s.playerSymbols[1-playerIndex]
+	}
+
+	return nil
+}
+
+func (s *Santorini) build(playerIndex int, moveData map[string]any) error {
+	power := s.powers[playerIndex]
+
+	worker := s.Turn.Worker
+	if s.Turn.Moves == 0 {
+		if !power.canBuildBeforeMove() || s.Turn.Builds > 0 {
+			return fmt.Errorf("you must move before building")
+		}
+		var err error
+		if worker, err = s.turnWorker(moveData); err != nil {
+			return err
+		}
+	}
+
+	at, err := parseSquare(moveData["at"])
+	if err != nil {
+		return fmt.Errorf("at: %w", err)
+	}
+	dome, _ := moveData["dome"].(bool)
+
+	if s.Turn.Builds >= power.maxBuilds(s) {
+		return fmt.Errorf("you cannot build again this turn")
+	}
+
+	pos := s.Workers[playerIndex][worker]
+	if !s.canBuildAt(playerIndex, pos, at, dome) {
+		return fmt.Errorf("illegal build")
+	}
+
+	previous := s.Levels[at[0]][at[1]]
+	if dome {
+		s.Levels[at[0]][at[1]] = santoriniDome
+	} else {
+		s.Levels[at[0]][at[1]]++
+	}
+
+	if s.Turn.Moves == 0 {
+		s.Turn.Worker = worker
+		s.Turn.Start = pos
+		s.Turn.BuiltBeforeMove = true
+		if !s.canMoveAgain(playerIndex) {
+			s.Levels[at[0]][at[1]] = previous
+			s.Turn = SantoriniTurn{Worker: -1}
+			return fmt.Errorf("your worker must still be able to move after building")
+		}
+	}
+
+	s.Turn.Builds++
+	s.Turn.LastBuild = at
+	if s.Turn.Moves > 0 {
+		s.Turn.BuiltAfterMove = true
+	}
+
+	if s.Turn.Moves > 0 && (s.Turn.Builds >= power.maxBuilds(s) || !s.canBuildAny(playerIndex)) {
+		s.endTurn()
+	}
+
+	return nil
+}
+
+// turnWorker returns the worker acting this turn, taking it from the move
+// when the player has not committed to one yet.
+func (s *Santorini) turnWorker(moveData map[string]any) (int, error) {
+	if s.Turn.Worker != -1 {
+		if value, ok := moveData["worker"].(float64); ok && int(value) != s.Turn.Worker {
+			return 0, fmt.Errorf("you must keep using the same worker this turn")
+		}
+		return s.Turn.Worker, nil
+	}
+
+	value, ok := moveData["worker"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("worker must be a number")
+	}
+	worker := int(value)
+	if worker < 0 || worker > 1 {
+		return 0, fmt.Errorf("worker must be 0 or 1")
+	}
+	return worker, nil
+}
+
+func (s *Santorini) endTurn() {
+	playerIndex := s.CurrentTurn
+	s.UpBlocked = false
+	s.powers[playerIndex].afterTurn(s)
+
+	s.CurrentTurn = 1 - playerIndex
+	s.Turn = SantoriniTurn{Worker: -1}
+
+	if !s.hasAnyMove(s.CurrentTurn) {
+		s.Winner = s.playerSymbols[playerIndex]
+	}
+}
+
+func (s *Santorini) canMoveTo(playerIndex int, from, to [2]int) bool {
+	if !s.inBounds(to) || !isNeighbour(from, to) {
+		return false
+	}
+	if s.level(to) == santoriniDome || s.level(to)-s.level(from) > 1 {
+		return false
+	}
+	if s.UpBlocked && s.level(to) > s.level(from) {
+		return false
+	}
+
+	power := s.powers[playerIndex]
+	if !power.allowMove(s, from, to) {
+		return false
+	}
+
+	player, _ := s.occupant(to)
+	if player == -1 {
+		return true
+	}
+	if player == playerIndex {
+		return false
+	}
+	_, ok := power.displace(s, from, to)
+	return ok
+}
+
+func (s *Santorini) canBuildAt(playerIndex int, pos, at [2]int, dome bool) bool {
+	if !s.inBounds(at) || !isNeighbour(pos, at) || s.level(at) == santoriniDome {
+		return false
+	}
+	if player, _ := s.occupant(at); player != -1 {
+		return false
+	}
+	return s.powers[playerIndex].allowBuild(s, at, dome)
+}
+
+func (s *Santorini) canBuildAny(playerIndex int) bool {
+	pos := s.Workers[playerIndex][s.Turn.Worker]
+	for _, dir := range queenDirections {
+		at := [2]int{pos[0] + dir[0], pos[1] + dir[1]}
+		if s.canBuildAt(playerIndex, pos, at, false) || s.canBuildAt(playerIndex, pos, at, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Santorini) canMoveAgain(playerIndex int) bool {
+	if s.Turn.Moves >= s.powers[playerIndex].maxMoves(s) {
+		return false
+	}
+	return s.hasMoveFrom(playerIndex, s.Turn.Worker)
+}
+
+func (s *Santorini) hasMoveFrom(playerIndex, worker int) bool {
+	from := s.Workers[playerIndex][worker]
+	for _, dir := range queenDirections {
+		if s.canMoveTo(playerIndex, from, [2]int{from[0] + dir[0], from[1] + dir[1]}) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Santorini) hasAnyMove(playerIndex int) bool {
+	return s.hasMoveFrom(playerIndex, 0) || s.hasMoveFrom(playerIndex, 1)
+}
+
+func (s *Santorini) occupant(square [2]int) (player, worker int) {
+	for p := range s.Workers {
+		for w := range s.Workers[p] {
+			if s.Workers[p][w] == square {
+				return p, w
+			}
+		}
+	}
+	return -1, -1
+}
+
+func (s *Santorini) level(square [2]int) int {
+	return s.Levels[square[0]][square[1]]
+}
+
+func (s *Santorini) inBounds(square [2]int) bool {
+	return square[0] >= 0 && square[0] < santoriniSize && square[1] >= 0 && square[1] < santoriniSize
+}
+
+func isNeighbour(a, b [2]int) bool {
+	return a != b && abs(a[0]-b[0]) <= 1 && abs(a[1]-b[1]) <= 1
+}
+
+func (s *Santorini) GetGameState() any {
+	return s
+}
+
+func (s *Santorini) IsGameOver() bool {
+	return s.Winner != ""
+}
+
+func (s *Santorini) GetWinner() string {
+	return s.Winner
+}
+
+func (s *Santorini) Reset() {
+	s.Levels = [santoriniSize][santoriniSize]int{}
+	for p := range s.Workers {
+		for w := range s.Workers[p] {
+			s.Workers[p][w] = [2]int{-1, -1}
+		}
+	}
+	s.Phase = "placement"
+	s.CurrentTurn = 0
+	s.Turn = SantoriniTurn{Worker: -1}
+	s.UpBlocked = false
+	s.Winner = ""
+	s.playerSymbols = [2]string{"P1", "P2"}
+}
+
+type santoriniPrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+}
+
+func (s *Santorini) Clone() Game {
+	cloned := *s
+	return &cloned
+}
+
+func (s *Santorini) MarshalState() ([]byte, error) {
+	return marshalState(s, santoriniPrivate{PlayerSymbols: s.playerSymbols})
+}
+
+func (s *Santorini) UnmarshalState(data []byte) error {
+	var restored Santorini
+	var private santoriniPrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	if err := restored.setPowers(restored.Gods); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	*s = restored
+	return nil
+}
+
+// ValidatePosition checks the levels and the workers. Workers are placed in
+// turn, two by the first player and then two by the second, so the placed
+// ones must come in that order and fit the player to move; a set-up position
+// with all four workers placed goes straight to play.
+func (s *Santorini) ValidatePosition() error {
+	for _, row := range s.Levels {
+		for _, level := range row {
+			if level < 0 || level > santoriniDome {
+				return fmt.Errorf("levels must be between 0 and %d", santoriniDome)
+			}
+		}
+	}
+
+	placed := 0
+	seen := make(map[[2]int]bool)
+	for p := range s.Workers {
+		for w, pos := range s.Workers[p] {
+			if pos == [2]int{-1, -1} {
+				continue
+			}
+			if placed != 2*p+w {
+				return fmt.Errorf("workers must be placed in turn, two by each player")
+			}
+			if !s.inBounds(pos) {
+				return fmt.Errorf("worker %d of player %d is out of bounds", w+1, p+1)
+			}
+			if seen[pos] || s.level(pos) == santoriniDome {
+				return fmt.Errorf("worker %d of player %d must stand on a free square", w+1, p+1)
+			}
+			seen[pos] = true
+			placed++
+		}
+	}
+	if placed < 4 && s.CurrentTurn != placed/2 {
+		return fmt.Errorf("player %d places the next worker", placed/2+1)
+	}
+	return nil
+}
+
+// afterSetup starts play once all the workers are on the board, and ends
+// the game if the player to move is stuck.
+func (s *Santorini) afterSetup() {
+	if s.Workers[1][1] == [2]int{-1, -1} {
+		return
+	}
+	s.Phase = "play"
+	if !s.hasAnyMove(s.CurrentTurn) {
+		s.Winner = s.playerSymbols[1-s.CurrentTurn]
+	}
+}
+
+func (s *Santorini) CurrentPlayer() int {
+	return s.CurrentTurn
+}
+
+// LegalMoves tries every action a god power could allow around the
+// player's workers: each step and build next to a worker, domes on lower
+// levels, and ending the turn.
+func (s *Santorini) LegalMoves() []any {
+	if s.IsGameOver() {
+		return nil
+	}
+
+	var candidates []any
+	if s.Phase == "placement" {
+		for r := range santoriniSize {
+			for c := range santoriniSize {
+				candidates = append(candidates, map[string]any{"type": "place", "at": squareValue([2]int{r, c})})
+			}
+		}
+		return legalAmong(s, candidates)
+	}
+
+	for worker, pos := range s.Workers[s.CurrentTurn] {
+		if s.Turn.Worker != -1 && worker != s.Turn.Worker {
+			continue
+		}
+		for _, dir := range queenDirections {
+			square := [2]int{pos[0] + dir[0], pos[1] + dir[1]}
+			if !s.inBounds(square) {
+				continue
+			}
+			at := squareValue(square)
+			candidates = append(candidates,
+				map[string]any{"type": "move", "worker": float64(worker), "to": at},
+				map[string]any{"type": "build", "worker": float64(worker), "at": at, "dome": false},
+			)
+			// Building on level 3 makes a dome anyway.
+			if s.level(square) < 3 {
+				candidates = append(candidates, map[string]any{"type": "build", "worker": float64(worker), "at": at, "dome": true})
+			}
+		}
+	}
+	candidates = append(candidates, map[string]any{"type": "end"})
+	return legalAmong(s, candidates)
+}
+
+func (s *Santorini) PlayerSymbol(playerIndex int) string {
+	return s.playerSymbols[playerIndex]
+}
+
+func (s *Santorini) PositionKey() string {
+	return stateKey(s)
+}
+
+// FormatMove names squares as in chess, with row 1 on player 0's side. A
+// placement is the bare square, a move is the worker numbered 1 or 2 and the
+// square after "-", as in "1-c3", a build puts "+" before the square and "D"
+// after it for a dome, as in "1+c4D", and "end" ends the turn. The worker may
+// be left out once the turn has settled on one.
+func (s *Santorini) FormatMove(move any) (string, error) {
+	kind, err := moveType(move)
+	if err != nil {
+		return "", err
+	}
+	if kind == "end" {
+		return kind, nil
+	}
+
+	moveData := move.(map[string]any)
+	field, op := "at", "+"
+	switch kind {
+	case "place":
+		op = ""
+	case "move":
+		field, op = "to", "-"
+	case "build":
+	default:
+		return "", fmt.Errorf("invalid move type: must be 'move', 'build' or 'end'")
+	}
+	square, err := moveSquare(move, field)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if worker, ok := moveData["worker"].(float64); ok && op != "" {
+		fmt.Fprintf(&sb, "%d", int(worker)+1)
+	}
+	sb.WriteString(op)
+	sb.WriteString(squareName(square, santoriniSize))
+	if dome, _ := moveData["dome"].(bool); dome {
+		sb.WriteString("D")
+	}
+	return sb.String(), nil
+}
+
+func (s *Santorini) ParseMove(text string) (any, error) {
+	if strings.ToLower(text) == "end" {
+		return map[string]any{"type": "end"}, nil
+	}
+
+	move := map[string]any{}
+	rest := text
+	if rest != "" && (rest[0] == '1' || rest[0] == '2') {
+		move["worker"] = float64(rest[0] - '1')
+		rest = rest[1:]
+	}
+
+	field := "at"
+	switch {
+	case strings.HasPrefix(rest, "-"):
+		move["type"], field, rest = "move", "to", rest[1:]
+	case strings.HasPrefix(rest, "+"):
+		move["type"], rest = "build", rest[1:]
+		rest, move["dome"] = strings.CutSuffix(rest, "D")
+	case len(move) == 0:
+		move["type"] = "place"
+	default:
+		return nil, fmt.Errorf("%q is not a move such as 1-c3 or 1+c4", text)
+	}
+
+	square, err := parseSquareName(rest, santoriniSize)
+	if err != nil {
+		return nil, err
+	}
+	move[field] = squareValue(square)
+	return move, nil
+}
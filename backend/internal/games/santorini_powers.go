@@ -0,0 +1,149 @@
+package games
+
+// santoriniPower is a set of rule modifiers for the player holding a god
+// power. Hooks read the game and the current turn but never change them,
+// except afterTurn, which runs as the holder's turn ends.
+type santoriniPower interface {
+	maxMoves(s *Santorini) int
+	maxBuilds(s *Santorini) int
+	canBuildBeforeMove() bool
+	allowMove(s *Santorini, from, to [2]int) bool
+	// displace reports whether the worker moving from from may enter to
+	// while an opponent stands there, and where that opponent goes.
+	displace(s *Santorini, from, to [2]int) ([2]int, bool)
+	allowBuild(s *Santorini, at [2]int, dome bool) bool
+	wins(s *Santorini, from, to [2]int) bool
+	afterTurn(s *Santorini)
+}
+
+var santoriniPowers = map[string]santoriniPower{
+	"apollo":     apolloPower{},
+	"artemis":    artemisPower{},
+	"athena":     athenaPower{},
+	"atlas":      atlasPower{},
+	"demeter":    demeterPower{},
+	"hephaestus": hephaestusPower{},
+	"minotaur":   minotaurPower{},
+	"pan":        panPower{},
+	"prometheus": prometheusPower{},
+}
+
+// basePower implements the rules without a god power. Gods embed it and
+// override the hooks they change.
+type basePower struct{}
+
+func (basePower) maxMoves(*Santorini) int  { return 1 }
+func (basePower) maxBuilds(*Santorini) int { return 1 }
+func (basePower) canBuildBeforeMove() bool { return false }
+
+func (basePower) allowMove(*Santorini, [2]int, [2]int) bool { return true }
+
+func (basePower) displace(*Santorini, [2]int, [2]int) ([2]int, bool) {
+	return [2]int{}, false
+}
+
+// Building on level 3 always makes a dome; only Atlas may dome lower.
+func (basePower) allowBuild(s *Santorini, at [2]int, dome bool) bool {
+	return !dome || s.level(at) == 3
+}
+
+func (basePower) wins(s *Santorini, from, to [2]int) bool {
+	return s.level(to) == 3 && s.level(from) < 3
+}
+
+func (basePower) afterTurn(*Santorini) {}
+
+// Apollo may move into an opponent's square, swapping the two workers.
+type apolloPower struct{ basePower }
+
+func (apolloPower) displace(_ *Santorini, from, _ [2]int) ([2]int, bool) {
+	return from, true
+}
+
+// Artemis may move one additional time, but not back to where she started.
+type artemisPower struct{ basePower }
+
+func (artemisPower) maxMoves(*Santorini) int { return 2 }
+
+func (artemisPower) allowMove(s *Santorini, _, to [2]int) bool {
+	return s.Turn.Moves == 0 || to != s.Turn.Start
+}
+
+// Athena stops the opponent moving up on their next turn if one of her
+// workers moved up this turn.
+type athenaPower struct{ basePower }
+
+func (athenaPower) afterTurn(s *Santorini) {
+	if s.Turn.MovedUp {
+		s.UpBlocked = true
+	}
+}
+
+// Atlas may build a dome at any level.
+type atlasPower struct{ basePower }
+
+func (atlasPower) allowBuild(*Santorini, [2]int, bool) bool { return true }
+
+// Demeter may build one additional time, but not on the same space.
+type demeterPower struct{ basePower }
+
+func (demeterPower) maxBuilds(*Santorini) int { return 2 }
+
+func (p demeterPower) allowBuild(s *Santorini, at [2]int, dome bool) bool {
+	if s.Turn.Builds == 1 && at == s.Turn.LastBuild {
+		return false
+	}
+	return p.basePower.allowBuild(s, at, dome)
+}
+
+// Hephaestus may build one additional block, not a dome, on top of his
+// first block.
+type hephaestusPower struct{ basePower }
+
+func (hephaestusPower) maxBuilds(*Santorini) int { return 2 }
+
+func (p hephaestusPower) allowBuild(s *Santorini, at [2]int, dome bool) bool {
+	if s.Turn.Builds == 1 {
+		return at == s.Turn.LastBuild && !dome && s.level(at) < 3
+	}
+	return p.basePower.allowBuild(s, at, dome)
+}
+
+// Minotaur may move into an opponent's square if that worker can be pushed
+// one square further in the same direction onto a free, undomed space.
+type minotaurPower struct{ basePower }
+
+func (minotaurPower) displace(s *Santorini, from, to [2]int) ([2]int, bool) {
+	dest := [2]int{2*to[0] - from[0], 2*to[1] - from[1]}
+	if !s.inBounds(dest) || s.level(dest) == santoriniDome {
+		return dest, false
+	}
+	if player, _ := s.occupant(dest); player != -1 {
+		return dest, false
+	}
+	return dest, true
+}
+
+// Pan also wins by moving down two or more levels.
+type panPower struct{ basePower }
+
+func (p panPower) wins(s *Santorini, from, to [2]int) bool {
+	return p.basePower.wins(s, from, to) || s.level(from)-s.level(to) >= 2
+}
+
+// Prometheus may build before moving, in which case he builds again after
+// moving but may not move up that turn.
+type prometheusPower struct{ basePower }
+
+func (prometheusPower) canBuildBeforeMove() bool { return true }
+
+func (prometheusPower) maxBuilds(s *Santorini) int {
+	if s.Turn.BuiltBeforeMove {
+		return 2
+	}
+	return 1
+}
+
+func (prometheusPower) allowMove(s *Santorini, from, to [2]int) bool {
+	return !s.Turn.BuiltBeforeMove || s.level(to) <= s.level(from)
+}
@@ -0,0 +1,341 @@
+package games
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func makeSantoriniPlacePayload(row, col int) any {
+	return map[string]any{"type": "place", "at": []any{float64(row), float64(col)}}
+}
+
+func makeSantoriniMovePayload(worker, row, col int) any {
+	return map[string]any{"type": "move", "worker": float64(worker), "to": []any{float64(row), float64(col)}}
+}
+
+func makeSantoriniBuildPayload(row, col int, dome bool) any {
+	return map[string]any{"type": "build", "at": []any{float64(row), float64(col)}, "dome": dome}
+}
+
+// newSantoriniInPlay skips the placement phase, putting player 0's workers
+// on workers[0] and player 1's on workers[1].
+func newSantoriniInPlay(t *testing.T, gods [2]string, workers [2][2][2]int) *Santorini {
+	t.Helper()
+	options, _ := json.Marshal(map[string]any{"gods": gods})
+	game, err := NewSantorini(options)
+	if err != nil {
+		t.Fatalf("Expected no error creating santorini game, but got %v", err)
+	}
+	s := game.(*Santorini)
+	s.Workers = workers
+	s.Phase = "play"
+	return s
+}
+
+var santoriniTestWorkers = [2][2][2]int{{{2, 2}, {4, 4}}, {{0, 0}, {0, 4}}}
+
+func TestSantorini_Placement(t *testing.T) {
+	game, _ := NewSantorini(nil)
+	s := game.(*Santorini)
+
+	if err := game.HandleMove(0, makeSantoriniMovePayload(0, 1, 1)); err == nil {
+		t.Fatal("Expected an error for moving during placement, but got nil")
+	}
+
+	placements := []struct {
+		playerIndex int
+		row, col    int
+	}{
+		{0, 1, 1}, {0, 3, 3}, {1, 1, 3}, {1, 3, 1},
+	}
+	for _, p := range placements {
+		if err := game.HandleMove(p.playerIndex, makeSantoriniPlacePayload(p.row, p.col)); err != nil {
+			t.Fatalf("Placement failed at player %d, %d,%d: %v", p.playerIndex, p.row, p.col, err)
+		}
+	}
+
+	if s.Phase != "play" || s.CurrentTurn != 0 {
+		t.Errorf("Expected play to start with player 0, but got phase %s and turn %d", s.Phase, s.CurrentTurn)
+	}
+}
+
+func TestSantorini_MoveThenBuild(t *testing.T) {
+	s := newSantoriniInPlay(t, [2]string{}, santoriniTestWorkers)
+	s.Levels[1][1] = 2
+
+	if err := s.HandleMove(0, makeSantoriniBuildPayload(2, 3, false)); err == nil {
+		t.Fatal("Expected an error for building before moving, but got nil")
+	}
+	if err := s.HandleMove(0, makeSantoriniMovePayload(0, 1, 1)); err == nil {
+		t.Fatal("Expected an error for climbing two levels, but got nil")
+	}
+	if err := s.HandleMove(0, makeSantoriniMovePayload(0, 2, 3)); err != nil {
+		t.Fatalf("Expected no error moving, but got %v", err)
+	}
+	if err := s.HandleMove(0, makeSantoriniMovePayload(0, 2, 4)); err == nil {
+		t.Fatal("Expected an error for a second move, but got nil")
+	}
+	if err := s.HandleMove(0, makeSantoriniBuildPayload(4, 4, false)); err == nil {
+		t.Fatal("Expected an error for building away from the worker, but got nil")
+	}
+	if err := s.HandleMove(0, makeSantoriniBuildPayload(2, 4, true)); err == nil {
+		t.Fatal("Expected an error for a dome on level 0, but got nil")
+	}
+	if err := s.HandleMove(0, makeSantoriniBuildPayload(2, 4, false)); err != nil {
+		t.Fatalf("Expected no error building, but got %v", err)
+	}
+
+	if s.Levels[2][4] != 1 || s.CurrentTurn != 1 {
+		t.Errorf("Expected a level 1 block and player 1 to move, but got level %d and turn %d", s.Levels[2][4], s.CurrentTurn)
+	}
+}
+
+func TestSantorini_ClimbToLevelThreeWins(t *testing.T) {
+	s := newSantoriniInPlay(t, [2]string{}, santoriniTestWorkers)
+	s.Levels[2][2] = 2
+	s.Levels[2][3] = 3
+
+	if err := s.HandleMove(0, makeSantoriniMovePayload(0, 2, 3)); err != nil {
+		t.Fatalf("Expected no error moving, but got %v", err)
+	}
+	if s.GetWinner() != "P1" {
+		t.Errorf("Expected winner to be 'P1', but got '%s'", s.GetWinner())
+	}
+}
+
+func TestSantorini_LevelThreeBuildIsDome(t *testing.T) {
+	s := newSantoriniInPlay(t, [2]string{}, santoriniTestWorkers)
+	s.Levels[1][2] = 3
+
+	if err := s.HandleMove(0, makeSantoriniMovePayload(0, 2, 3)); err != nil {
+		t.Fatalf("Expected no error moving, but got %v", err)
+	}
+	if err := s.HandleMove(0, makeSantoriniBuildPayload(1, 2, false)); err != nil {
+		t.Fatalf("Expected no error building, but got %v", err)
+	}
+	if s.Levels[1][2] != santoriniDome {
+		t.Errorf("Expected a dome at 1,2, but got level %d", s.Levels[1][2])
+	}
+}
+
+func TestSantorini_UnknownGod(t *testing.T) {
+	if _, err := NewSantorini(json.RawMessage(`{"gods": ["zeus"]}`)); err == nil {
+		t.Fatal("Expected an error for an unknown god power, but got nil")
+	}
+}
+
+func TestSantorini_GodPowers(t *testing.T) {
+	tests := []struct {
+		name    string
+		god     string
+		setup   func(s *Santorini)
+		actions []any
+		wantErr bool
+		check   func(t *testing.T, s *Santorini)
+	}{
+		{
+			name:    "apollo swaps with an opponent",
+			god:     "apollo",
+			setup:   func(s *Santorini) { s.Workers[1][0] = [2]int{2, 3} },
+			actions: []any{makeSantoriniMovePayload(0, 2, 3)},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Workers[1][0] != [2]int{2, 2} {
+					t.Errorf("Expected the opponent to be swapped to 2,2, but got %v", s.Workers[1][0])
+				}
+			},
+		},
+		{
+			name:    "no power cannot enter an occupied square",
+			setup:   func(s *Santorini) { s.Workers[1][0] = [2]int{2, 3} },
+			actions: []any{makeSantoriniMovePayload(0, 2, 3)},
+			wantErr: true,
+		},
+		{
+			name:    "minotaur pushes an opponent",
+			god:     "minotaur",
+			setup:   func(s *Santorini) { s.Workers[1][0] = [2]int{2, 3} },
+			actions: []any{makeSantoriniMovePayload(0, 2, 3)},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Workers[1][0] != [2]int{2, 4} {
+					t.Errorf("Expected the opponent to be pushed to 2,4, but got %v", s.Workers[1][0])
+				}
+			},
+		},
+		{
+			name: "minotaur cannot push into a dome",
+			god:  "minotaur",
+			setup: func(s *Santorini) {
+				s.Workers[1][0] = [2]int{2, 3}
+				s.Levels[2][4] = santoriniDome
+			},
+			actions: []any{makeSantoriniMovePayload(0, 2, 3)},
+			wantErr: true,
+		},
+		{
+			name:    "artemis moves twice",
+			god:     "artemis",
+			actions: []any{makeSantoriniMovePayload(0, 2, 3), makeSantoriniMovePayload(0, 2, 4)},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Workers[0][0] != [2]int{2, 4} {
+					t.Errorf("Expected the worker at 2,4, but got %v", s.Workers[0][0])
+				}
+			},
+		},
+		{
+			name:    "artemis cannot return to her start",
+			god:     "artemis",
+			actions: []any{makeSantoriniMovePayload(0, 2, 3), makeSantoriniMovePayload(0, 2, 2)},
+			wantErr: true,
+		},
+		{
+			name:    "atlas builds a dome on level 0",
+			god:     "atlas",
+			actions: []any{makeSantoriniMovePayload(0, 2, 3), makeSantoriniBuildPayload(2, 4, true)},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Levels[2][4] != santoriniDome || s.CurrentTurn != 1 {
+					t.Errorf("Expected a dome at 2,4, but got level %d", s.Levels[2][4])
+				}
+			},
+		},
+		{
+			name: "demeter builds twice on different spaces",
+			god:  "demeter",
+			actions: []any{
+				makeSantoriniMovePayload(0, 2, 3),
+				makeSantoriniBuildPayload(2, 4, false),
+				makeSantoriniBuildPayload(1, 4, false),
+			},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Levels[2][4] != 1 || s.Levels[1][4] != 1 || s.CurrentTurn != 1 {
+					t.Error("Expected two level 1 blocks and the turn to pass")
+				}
+			},
+		},
+		{
+			name: "demeter cannot build twice on the same space",
+			god:  "demeter",
+			actions: []any{
+				makeSantoriniMovePayload(0, 2, 3),
+				makeSantoriniBuildPayload(2, 4, false),
+				makeSantoriniBuildPayload(2, 4, false),
+			},
+			wantErr: true,
+		},
+		{
+			name: "demeter may end after one build",
+			god:  "demeter",
+			actions: []any{
+				makeSantoriniMovePayload(0, 2, 3),
+				makeSantoriniBuildPayload(2, 4, false),
+				map[string]any{"type": "end"},
+			},
+			check: func(t *testing.T, s *Santorini) {
+				if s.CurrentTurn != 1 {
+					t.Errorf("Expected the turn to pass, but got %d", s.CurrentTurn)
+				}
+			},
+		},
+		{
+			name: "hephaestus builds twice on the same space",
+			god:  "hephaestus",
+			actions: []any{
+				makeSantoriniMovePayload(0, 2, 3),
+				makeSantoriniBuildPayload(2, 4, false),
+				makeSantoriniBuildPayload(2, 4, false),
+			},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Levels[2][4] != 2 {
+					t.Errorf("Expected a level 2 tower, but got level %d", s.Levels[2][4])
+				}
+			},
+		},
+		{
+			name:    "pan wins by dropping two levels",
+			god:     "pan",
+			setup:   func(s *Santorini) { s.Levels[2][2] = 2 },
+			actions: []any{makeSantoriniMovePayload(0, 2, 3)},
+			check: func(t *testing.T, s *Santorini) {
+				if s.GetWinner() != "P1" {
+					t.Errorf("Expected winner to be 'P1', but got '%s'", s.GetWinner())
+				}
+			},
+		},
+		{
+			name: "athena stops the opponent moving up",
+			god:  "athena",
+			setup: func(s *Santorini) {
+				s.Levels[2][3] = 1
+				s.Levels[0][1] = 1
+			},
+			actions: []any{
+				makeSantoriniMovePayload(0, 2, 3),
+				makeSantoriniBuildPayload(2, 4, false),
+				makeSantoriniMovePayload(0, 0, 1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "prometheus builds before moving",
+			god:  "prometheus",
+			actions: []any{
+				map[string]any{"type": "build", "worker": float64(0), "at": []any{float64(1), float64(1)}},
+				makeSantoriniMovePayload(0, 2, 3),
+				makeSantoriniBuildPayload(2, 4, false),
+			},
+			check: func(t *testing.T, s *Santorini) {
+				if s.Levels[1][1] != 1 || s.Levels[2][4] != 1 || s.CurrentTurn != 1 {
+					t.Error("Expected a build on each side of the move and the turn to pass")
+				}
+			},
+		},
+		{
+			name: "prometheus must still build after moving",
+			god:  "prometheus",
+			actions: []any{
+				map[string]any{"type": "build", "worker": float64(0), "at": []any{float64(1), float64(1)}},
+				makeSantoriniMovePayload(0, 2, 3),
+				map[string]any{"type": "end"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prometheus cannot move up after building first",
+			god:  "prometheus",
+			setup: func(s *Santorini) {
+				s.Levels[2][3] = 1
+			},
+			actions: []any{
+				map[string]any{"type": "build", "worker": float64(0), "at": []any{float64(1), float64(1)}},
+				makeSantoriniMovePayload(0, 2, 3),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSantoriniInPlay(t, [2]string{tt.god}, santoriniTestWorkers)
+			if tt.setup != nil {
+				tt.setup(s)
+			}
+
+			var err error
+			for _, action := range tt.actions {
+				if err = s.HandleMove(s.CurrentTurn, action); err != nil {
+					break
+				}
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			tt.check(t, s)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// savedState is the serialized form produced by MarshalState. State holds
+// the game's exported fields as clients see them and Private holds the
+// unexported fields it needs to resume play, such as repetition history.
+type savedState struct {
+	State   json.RawMessage `json:"state"`
+	Private json.RawMessage `json:"private"`
+}
+
+// marshalState encodes a game's exported fields together with a struct of
+// its unexported ones. encoding/json writes struct fields in declaration
+// order and sorts map keys, so equal states always encode to equal bytes.
+func marshalState(game any, private any) ([]byte, error) {
+	state, err := json.Marshal(game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal game state: %w", err)
+	}
+	privateState, err := json.Marshal(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal game state: %w", err)
+	}
+	return json.Marshal(savedState{State: state, Private: privateState})
+}
+
+// unmarshalState decodes data produced by marshalState into a zero game
+// value and a private struct. Callers decode into fresh values and only
+// replace the receiver once everything decoded, so a failed restore leaves
+// the game untouched.
+func unmarshalState(data []byte, game any, private any) error {
+	var saved savedState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("invalid game state: %w", err)
+	}
+	if err := json.Unmarshal(saved.State, game); err != nil {
+		return fmt.Errorf("invalid game state: %w", err)
+	}
+	if err := json.Unmarshal(saved.Private, private); err != nil {
+		return fmt.Errorf("invalid game state: %w", err)
+	}
+	return nil
+}
+
+// cloneGrid returns a deep copy of a two-dimensional slice.
+func cloneGrid[T any](grid [][]T) [][]T {
+	if grid == nil {
+		return nil
+	}
+	cloned := make([][]T, len(grid))
+	for i, row := range grid {
+		cloned[i] = append([]T(nil), row...)
+	}
+	return cloned
+}
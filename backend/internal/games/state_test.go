@@ -0,0 +1,188 @@
+package games
+
+import (
+	"bytes"
+	"testing"
+)
+
+type scriptedMove struct {
+	playerIndex int
+	move        any
+}
+
+// gameOpenings lists a short legal opening for every registered game type.
+var gameOpenings = map[string][]scriptedMove{
+	"tic-tac-toe": {
+		{0, makeTicTacToeMovePayload(4)},
+		{1, makeTicTacToeMovePayload(0)},
+	},
+	"domineering": {
+		{0, makeDomineeringMovePayload(0, 0)},
+		{1, makeDomineeringMovePayload(0, 2)},
+	},
+	"connect-four": {
+		{0, makeConnectFourMovePayload(3)},
+		{1, makeConnectFourMovePayload(3)},
+	},
+	"dots-and-boxes": {
+		{0, makeDotsAndBoxesMovePayload("h", 0, 0)},
+		{1, makeDotsAndBoxesMovePayload("v", 0, 0)},
+	},
+	"nim": {
+		{0, map[string]any{"sticks": float64(2)}},
+		{1, map[string]any{"sticks": float64(3)}},
+	},
+	"hex": {
+		{0, makeHexMovePayload(0, 0)},
+		{1, makeHexMovePayload(1, 1)},
+	},
+	"go": {
+		{0, makeGoMovePayload(2, 2)},
+		{1, makeGoMovePayload(3, 3)},
+	},
+	"checkers": {
+		{0, makeCheckersMovePayload([2]int{5, 0}, [2]int{4, 1})},
+		{1, makeCheckersMovePayload([2]int{2, 1}, [2]int{3, 2})},
+	},
+	"kalah": {
+		{0, makeMancalaMovePayload(0)},
+		{1, makeMancalaMovePayload(0)},
+	},
+	"oware": {
+		{0, makeMancalaMovePayload(0)},
+		{1, makeMancalaMovePayload(0)},
+	},
+	"ultimate-tic-tac-toe": {
+		{0, makeUltimateTicTacToeMovePayload(4, 0)},
+		{1, makeUltimateTicTacToeMovePayload(0, 4)},
+	},
+	"quoridor": {
+		{0, makeQuoridorPawnPayload(7, 4)},
+		{1, makeQuoridorWallPayload(6, 3, "h")},
+	},
+	"battleship": {
+		{0, makeBattleshipFleetPayload(0)},
+		{1, makeBattleshipFleetPayload(2)},
+		{0, makeBattleshipShotPayload(0, 2)},
+	},
+	"nine-mens-morris": {
+		{0, makeMorrisPlacePayload(0)},
+		{1, makeMorrisPlacePayload(9)},
+	},
+	"amazons": {
+		{0, makeAmazonsMovePayload([2]int{6, 0}, [2]int{6, 4}, [2]int{6, 0})},
+		{1, makeAmazonsMovePayload([2]int{3, 9}, [2]int{4, 9}, [2]int{5, 9})},
+	},
+	"breakthrough": {
+		{0, makeBreakthroughMovePayload([2]int{6, 0}, [2]int{5, 0})},
+		{1, makeBreakthroughMovePayload([2]int{1, 0}, [2]int{2, 1})},
+	},
+	"lines-of-action": {
+		{0, makeLinesOfActionMovePayload([2]int{0, 1}, [2]int{2, 1})},
+		{1, makeLinesOfActionMovePayload([2]int{1, 0}, [2]int{1, 2})},
+	},
+	"santorini": {
+		{0, makeSantoriniPlacePayload(0, 0)},
+		{0, makeSantoriniPlacePayload(4, 4)},
+		{1, makeSantoriniPlacePayload(0, 4)},
+		{1, makeSantoriniPlacePayload(4, 0)},
+		{0, makeSantoriniMovePayload(0, 1, 1)},
+		{0, makeSantoriniBuildPayload(2, 2, false)},
+	},
+	"pentago": {
+		{0, makePentagoMovePayload(0, 0, 0, "cw")},
+		{1, makePentagoMovePayload(5, 5, 3, "ccw")},
+	},
+}
+
+func TestGameOpenings_CoverEveryGame(t *testing.T) {
+	for gameType := range gameFactories {
+		if _, ok := gameOpenings[gameType]; !ok {
+			t.Errorf("Expected an opening for %s, but found none", gameType)
+		}
+	}
+}
+
+func playOpening(t *testing.T, game Game, moves []scriptedMove) {
+	t.Helper()
+	for i, m := range moves {
+		if err := game.HandleMove(m.playerIndex, m.move); err != nil {
+			t.Fatalf("Opening failed at move %d: %v", i, err)
+		}
+	}
+}
+
+func mustMarshalState(t *testing.T, game Game) []byte {
+	t.Helper()
+	data, err := game.MarshalState()
+	if err != nil {
+		t.Fatalf("Expected no error marshaling state, but got %v", err)
+	}
+	return data
+}
+
+func TestGames_MarshalStateRoundTrip(t *testing.T) {
+	for gameType, opening := range gameOpenings {
+		t.Run(gameType, func(t *testing.T) {
+			game, err := NewGame(gameType, nil)
+			if err != nil {
+				t.Fatalf("Expected no error creating %s, but got %v", gameType, err)
+			}
+			last := len(opening) - 1
+			playOpening(t, game, opening[:last])
+
+			data := mustMarshalState(t, game)
+			if again := mustMarshalState(t, game); !bytes.Equal(data, again) {
+				t.Fatal("Expected marshaling the same state twice to give the same bytes")
+			}
+
+			restored, _ := NewGame(gameType, nil)
+			if err := restored.UnmarshalState(data); err != nil {
+				t.Fatalf("Expected no error restoring state, but got %v", err)
+			}
+			if got := mustMarshalState(t, restored); !bytes.Equal(data, got) {
+				t.Fatalf("Expected the restored state to match\nwant %s\ngot  %s", data, got)
+			}
+
+			// The restored game must carry the hidden state needed to go on.
+			playOpening(t, game, opening[last:])
+			playOpening(t, restored, opening[last:])
+			if !bytes.Equal(mustMarshalState(t, game), mustMarshalState(t, restored)) {
+				t.Error("Expected the original and restored games to stay in step")
+			}
+		})
+	}
+}
+
+func TestGames_CloneIsIndependent(t *testing.T) {
+	for gameType, opening := range gameOpenings {
+		t.Run(gameType, func(t *testing.T) {
+			game, _ := NewGame(gameType, nil)
+			last := len(opening) - 1
+			playOpening(t, game, opening[:last])
+
+			before := mustMarshalState(t, game)
+			clone := game.Clone()
+			if !bytes.Equal(before, mustMarshalState(t, clone)) {
+				t.Fatal("Expected the clone to start in the same state")
+			}
+
+			playOpening(t, clone, opening[last:])
+			if !bytes.Equal(before, mustMarshalState(t, game)) {
+				t.Error("Expected a move on the clone to leave the original untouched")
+			}
+		})
+	}
+}
+
+func TestGames_UnmarshalStateRejectsGarbage(t *testing.T) {
+	game, _ := NewGame("tic-tac-toe", nil)
+	before := mustMarshalState(t, game)
+
+	if err := game.UnmarshalState([]byte(`{"state": 42}`)); err == nil {
+		t.Fatal("Expected an error for an invalid state, but got nil")
+	}
+	if !bytes.Equal(before, mustMarshalState(t, game)) {
+		t.Error("Expected a failed restore to leave the game untouched")
+	}
+}
@@ -2,10 +2,26 @@ package games
 
 import (
 	"fmt"
+	"strconv"
 )
 
 func init() {
 	RegisterGame("tic-tac-toe", NewTicTacToe)
+	RegisterMetadata("tic-tac-toe", ticTacToeMetadata)
+}
+
+var ticTacToeMetadata = Metadata{
+	DisplayName: "Tic-Tac-Toe",
+	Description: "The classic 3x3 grid game where players alternate placing X's and O's, aiming to get three in a row.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: quickTimeControl,
+	Rules: []string{
+		"You must place your symbol in an empty square",
+		"You must make a move on your turn",
+		"Three in a row wins (horizontal, vertical, or diagonal)",
+		"Game ends in a draw if the board is full with no winner",
+	},
 }
 
 type TicTacToe struct {
@@ -88,22 +104,135 @@ func (t *TicTacToe) Reset() {
 	t.moves = 0
 }
 
+// ticTacToeLines lists every winning line on a 3x3 grid.
+var ticTacToeLines = [8][3]int{
+	{0, 1, 2},
+	{3, 4, 5},
+	{6, 7, 8},
+	{0, 3, 6},
+	{1, 4, 7},
+	{2, 5, 8},
+	{0, 4, 8},
+	{2, 4, 6},
+}
+
 func (t *TicTacToe) checkWinner(symbol string) bool {
-	lines := [8][3]int{
-		{0, 1, 2},
-		{3, 4, 5},
-		{6, 7, 8},
-		{0, 3, 6},
-		{1, 4, 7},
-		{2, 5, 8},
-		{0, 4, 8},
-		{2, 4, 6},
-	}
-
-	for _, line := range lines {
-		if t.Board[line[0]] == symbol && t.Board[line[1]] == symbol && t.Board[line[2]] == symbol {
+	return checkWinner(t.Board, symbol)
+}
+
+// checkWinner reports whether symbol holds a complete line on a 3x3 grid.
+func checkWinner(board [9]string, symbol string) bool {
+	for _, line := range ticTacToeLines {
+		if board[line[0]] == symbol && board[line[1]] == symbol && board[line[2]] == symbol {
 			return true
 		}
 	}
 	return false
 }
+
+type ticTacToePrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+	Moves         int       `json:"moves"`
+}
+
+func (t *TicTacToe) Clone() Game {
+	cloned := *t
+	return &cloned
+}
+
+func (t *TicTacToe) MarshalState() ([]byte, error) {
+	return marshalState(t, ticTacToePrivate{PlayerSymbols: t.playerSymbols, Moves: t.moves})
+}
+
+func (t *TicTacToe) UnmarshalState(data []byte) error {
+	var restored TicTacToe
+	var private ticTacToePrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.moves = private.Moves
+	*t = restored
+	return nil
+}
+
+// afterSetup recounts the moves, which tell when a full board is a draw,
+// and ends the game if the last player to move completed a line.
+func (t *TicTacToe) afterSetup() {
+	t.moves = 0
+	for _, cell := range t.Board {
+		if cell != "" {
+			t.moves++
+		}
+	}
+
+	previous := t.playerSymbols[1-t.CurrentTurn]
+	if t.checkWinner(previous) {
+		t.Winner = previous
+	} else if t.moves == 9 {
+		t.Winner = "draw"
+	}
+}
+
+func (t *TicTacToe) ValidatePosition() error {
+	if err := checkPieces(t.Board[:], t.playerSymbols[:]...); err != nil {
+		return err
+	}
+	if t.checkWinner(t.playerSymbols[t.CurrentTurn]) {
+		return fmt.Errorf("the player to move cannot have a line already")
+	}
+	return checkAlternation([2]int{
+		countPieces(t.Board[:], t.playerSymbols[0]),
+		countPieces(t.Board[:], t.playerSymbols[1]),
+	}, t.CurrentTurn)
+}
+
+func (t *TicTacToe) CurrentPlayer() int {
+	return t.CurrentTurn
+}
+
+func (t *TicTacToe) LegalMoves() []any {
+	if t.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	for i, cell := range t.Board {
+		if cell == "" {
+			moves = append(moves, map[string]any{"cellIndex": float64(i)})
+		}
+	}
+	return moves
+}
+
+func (t *TicTacToe) PlayerSymbol(playerIndex int) string {
+	return t.playerSymbols[playerIndex]
+}
+
+func (t *TicTacToe) PositionKey() string {
+	key := make([]byte, 0, len(t.Board)+1)
+	for _, cell := range t.Board {
+		if cell == "" {
+			key = append(key, '.')
+		} else {
+			key = append(key, cell...)
+		}
+	}
+	return string(append(key, byte('0'+t.CurrentTurn)))
+}
+
+// FormatMove numbers the cells 1 to 9 row by row, so "5" is the centre.
+func (t *TicTacToe) FormatMove(move any) (string, error) {
+	cellIndex, err := moveField(move, "cellIndex")
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(cellIndex + 1), nil
+}
+
+func (t *TicTacToe) ParseMove(text string) (any, error) {
+	cell, err := strconv.Atoi(text)
+	if err != nil || cell < 1 || cell > 9 {
+		return nil, fmt.Errorf("%q is not a cell from 1 to 9", text)
+	}
+	return map[string]any{"cellIndex": float64(cell - 1)}, nil
+}
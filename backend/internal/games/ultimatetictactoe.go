@@ -0,0 +1,284 @@
+package games
+
+import (
+	"fmt"
+)
+
+func init() {
+	RegisterGame("ultimate-tic-tac-toe", NewUltimateTicTacToe)
+	RegisterMetadata("ultimate-tic-tac-toe", ultimateTicTacToeMetadata)
+}
+
+var ultimateTicTacToeMetadata = Metadata{
+	DisplayName: "Ultimate Tic-Tac-Toe",
+	Description: "Nine games of tic-tac-toe in one. Win small boards to claim their cells on the big board.",
+	MinPlayers:  2,
+	MaxPlayers:  2,
+	TimeControl: normalTimeControl,
+	Rules: []string{
+		"The cell you play in sends your opponent to the small board in the same position",
+		"If that board is already decided, your opponent may play on any open board",
+		"Three in a row on a small board wins it",
+		"Win three small boards in a row to win the game",
+	},
+}
+
+// UltimateTicTacToe is played on nine tic-tac-toe boards arranged in a 3x3
+// meta-board. The cell a player picks sends the opponent to the board with
+// the same index; if that board is already decided the opponent may play on
+// any open board. ActiveBoard is -1 when the choice is free.
+type UltimateTicTacToe struct {
+	Boards        [9][9]string `json:"boards"`
+	MetaBoard     [9]string    `json:"metaBoard"`
+	ActiveBoard   int          `json:"activeBoard"`
+	CurrentTurn   int          `json:"currentTurn"`
+	Winner        string       `json:"winner"`
+	playerSymbols [2]string
+	moves         int
+}
+
+func NewUltimateTicTacToe() (Game, error) {
+	u := &UltimateTicTacToe{}
+	u.Reset()
+	return u, nil
+}
+
+func (u *UltimateTicTacToe) HandleMove(playerIndex int, move any) error {
+	if u.IsGameOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if playerIndex != u.CurrentTurn {
+		return fmt.Errorf("it's not your turn")
+	}
+
+	moveData, ok := move.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid move format")
+	}
+
+	boardFloat, ok := moveData["board"].(float64)
+	if !ok {
+		return fmt.Errorf("board must be a number")
+	}
+	board := int(boardFloat)
+
+	cellIndexFloat, ok := moveData["cellIndex"].(float64)
+	if !ok {
+		return fmt.Errorf("cellIndex must be a number")
+	}
+	cellIndex := int(cellIndexFloat)
+
+	if board < 0 || board > 8 || cellIndex < 0 || cellIndex > 8 {
+		return fmt.Errorf("cell index out of bounds")
+	}
+
+	if u.MetaBoard[board] != "" {
+		return fmt.Errorf("that board is already decided")
+	}
+
+	if u.ActiveBoard != -1 && board != u.ActiveBoard {
+		return fmt.Errorf("you must play on board %d", u.ActiveBoard)
+	}
+
+	if u.Boards[board][cellIndex] != "" {
+		return fmt.Errorf("cell is already occupied")
+	}
+
+	symbol := u.playerSymbols[playerIndex]
+	u.Boards[board][cellIndex] = symbol
+	u.moves++
+
+	if checkWinner(u.Boards[board], symbol) {
+		u.MetaBoard[board] = symbol
+	} else if u.isBoardFull(board) {
+		u.MetaBoard[board] = "draw"
+	}
+
+	if checkWinner(u.MetaBoard, symbol) {
+		u.Winner = symbol
+	} else if u.isMetaBoardDecided() {
+		u.Winner = "draw"
+	}
+
+	if u.MetaBoard[cellIndex] == "" {
+		u.ActiveBoard = cellIndex
+	} else {
+		u.ActiveBoard = -1
+	}
+
+	u.CurrentTurn = (u.CurrentTurn + 1) % 2
+
+	return nil
+}
+
+func (u *UltimateTicTacToe) isBoardFull(board int) bool {
+	for _, cell := range u.Boards[board] {
+		if cell == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (u *UltimateTicTacToe) isMetaBoardDecided() bool {
+	for _, result := range u.MetaBoard {
+		if result == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (u *UltimateTicTacToe) GetGameState() any {
+	return u
+}
+
+func (u *UltimateTicTacToe) IsGameOver() bool {
+	return u.Winner != ""
+}
+
+func (u *UltimateTicTacToe) GetWinner() string {
+	return u.Winner
+}
+
+func (u *UltimateTicTacToe) Reset() {
+	u.Boards = [9][9]string{}
+	u.MetaBoard = [9]string{}
+	u.ActiveBoard = -1
+	u.CurrentTurn = 0
+	u.Winner = ""
+	u.playerSymbols = [2]string{"X", "O"}
+	u.moves = 0
+}
+
+type ultimateTicTacToePrivate struct {
+	PlayerSymbols [2]string `json:"playerSymbols"`
+	Moves         int       `json:"moves"`
+}
+
+func (u *UltimateTicTacToe) Clone() Game {
+	cloned := *u
+	return &cloned
+}
+
+func (u *UltimateTicTacToe) MarshalState() ([]byte, error) {
+	return marshalState(u, ultimateTicTacToePrivate{PlayerSymbols: u.playerSymbols, Moves: u.moves})
+}
+
+func (u *UltimateTicTacToe) UnmarshalState(data []byte) error {
+	var restored UltimateTicTacToe
+	var private ultimateTicTacToePrivate
+	if err := unmarshalState(data, &restored, &private); err != nil {
+		return err
+	}
+	restored.playerSymbols = private.PlayerSymbols
+	restored.moves = private.Moves
+	*u = restored
+	return nil
+}
+
+// ValidatePosition also checks that the meta board records the result of
+// every small board.
+// afterSetup recounts the moves and ends the game if the last player to
+// move won the meta board or no board is left to play on.
+func (u *UltimateTicTacToe) afterSetup() {
+	u.moves = 0
+	for _, cells := range u.Boards {
+		for _, cell := range cells {
+			if cell != "" {
+				u.moves++
+			}
+		}
+	}
+
+	previous := u.playerSymbols[1-u.CurrentTurn]
+	if checkWinner(u.MetaBoard, previous) {
+		u.Winner = previous
+	} else if u.isMetaBoardDecided() {
+		u.Winner = "draw"
+	}
+}
+
+func (u *UltimateTicTacToe) ValidatePosition() error {
+	var counts [2]int
+	for board, cells := range u.Boards {
+		if err := checkPieces(cells[:], u.playerSymbols[:]...); err != nil {
+			return err
+		}
+		for i, symbol := range u.playerSymbols {
+			counts[i] += countPieces(cells[:], symbol)
+		}
+
+		result := ""
+		for _, symbol := range u.playerSymbols {
+			if checkWinner(cells, symbol) {
+				if result != "" {
+					return fmt.Errorf("board %d cannot be won by both players", board+1)
+				}
+				result = symbol
+			}
+		}
+		if result == "" && u.isBoardFull(board) {
+			result = "draw"
+		}
+		if u.MetaBoard[board] != result {
+			return fmt.Errorf("the meta board does not match the result of board %d", board+1)
+		}
+	}
+	if checkWinner(u.MetaBoard, u.playerSymbols[u.CurrentTurn]) {
+		return fmt.Errorf("the player to move cannot have won the meta board already")
+	}
+	return checkAlternation(counts, u.CurrentTurn)
+}
+
+func (u *UltimateTicTacToe) CurrentPlayer() int {
+	return u.CurrentTurn
+}
+
+func (u *UltimateTicTacToe) LegalMoves() []any {
+	if u.IsGameOver() {
+		return nil
+	}
+	var moves []any
+	for board := range u.Boards {
+		if u.MetaBoard[board] != "" || (u.ActiveBoard != -1 && board != u.ActiveBoard) {
+			continue
+		}
+		for cellIndex, cell := range u.Boards[board] {
+			if cell == "" {
+				moves = append(moves, map[string]any{"board": float64(board), "cellIndex": float64(cellIndex)})
+			}
+		}
+	}
+	return moves
+}
+
+func (u *UltimateTicTacToe) PlayerSymbol(playerIndex int) string {
+	return u.playerSymbols[playerIndex]
+}
+
+func (u *UltimateTicTacToe) PositionKey() string {
+	return stateKey(u)
+}
+
+// FormatMove writes the board and then the cell, each numbered 1 to 9 row
+// by row, so "55" is the centre of the centre board.
+func (u *UltimateTicTacToe) FormatMove(move any) (string, error) {
+	board, err := moveField(move, "board")
+	if err != nil {
+		return "", err
+	}
+	cellIndex, err := moveField(move, "cellIndex")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d%d", board+1, cellIndex+1), nil
+}
+
+func (u *UltimateTicTacToe) ParseMove(text string) (any, error) {
+	if len(text) != 2 || text[0] < '1' || text[0] > '9' || text[1] < '1' || text[1] > '9' {
+		return nil, fmt.Errorf("%q is not a board and cell such as 55", text)
+	}
+	return map[string]any{"board": float64(text[0] - '1'), "cellIndex": float64(text[1] - '1')}, nil
+}
@@ -0,0 +1,78 @@
+package games
+
+import (
+	"testing"
+)
+
+func makeUltimateTicTacToeMovePayload(board int, cellIndex int) any {
+	return map[string]any{"board": float64(board), "cellIndex": float64(cellIndex)}
+}
+
+func TestUltimateTicTacToe_ForcedBoard(t *testing.T) {
+	game, _ := NewUltimateTicTacToe()
+
+	if err := game.HandleMove(0, makeUltimateTicTacToeMovePayload(4, 2)); err != nil {
+		t.Fatalf("Expected no error for a valid move, but got %v", err)
+	}
+
+	err := game.HandleMove(1, makeUltimateTicTacToeMovePayload(5, 0))
+	if err == nil {
+		t.Fatal("Expected an error when playing outside the forced board, but got nil")
+	}
+
+	if err := game.HandleMove(1, makeUltimateTicTacToeMovePayload(2, 0)); err != nil {
+		t.Fatalf("Expected no error on the forced board, but got %v", err)
+	}
+}
+
+func TestUltimateTicTacToe_SubBoardWinFreesChoice(t *testing.T) {
+	game, _ := NewUltimateTicTacToe()
+	u := game.(*UltimateTicTacToe)
+
+	moves := []struct {
+		playerIndex int
+		board, cell int
+	}{
+		{0, 0, 0}, {1, 0, 3},
+		{0, 3, 0}, {1, 0, 6},
+		{0, 6, 0}, {1, 0, 4},
+		{0, 4, 0}, {1, 0, 5}, // O wins board 0
+		{0, 5, 0}, // X sends O back to the decided board
+	}
+
+	for _, move := range moves {
+		if err := game.HandleMove(move.playerIndex, makeUltimateTicTacToeMovePayload(move.board, move.cell)); err != nil {
+			t.Fatalf("Move sequence failed at board %d, cell %d: %v", move.board, move.cell, err)
+		}
+	}
+
+	if u.MetaBoard[0] != "O" {
+		t.Fatalf("Expected O to win board 0, but got '%s'", u.MetaBoard[0])
+	}
+	if u.ActiveBoard != -1 {
+		t.Errorf("Expected a free choice after being sent to a decided board, but got board %d", u.ActiveBoard)
+	}
+
+	err := game.HandleMove(1, makeUltimateTicTacToeMovePayload(0, 1))
+	if err == nil {
+		t.Fatal("Expected an error when playing on a decided board, but got nil")
+	}
+}
+
+func TestUltimateTicTacToe_MetaWin(t *testing.T) {
+	game, _ := NewUltimateTicTacToe()
+	u := game.(*UltimateTicTacToe)
+
+	u.MetaBoard[0] = "X"
+	u.MetaBoard[1] = "X"
+	u.Boards[2] = [9]string{"X", "X", "", "O", "O", "", "", "", ""}
+	u.ActiveBoard = 2
+
+	if err := game.HandleMove(0, makeUltimateTicTacToeMovePayload(2, 2)); err != nil {
+		t.Fatalf("Expected no error for the winning move, but got %v", err)
+	}
+
+	if game.GetWinner() != "X" {
+		t.Errorf("Expected winner to be 'X', but got '%s'", game.GetWinner())
+	}
+}
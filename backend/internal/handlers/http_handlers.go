@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 
+	db "github.com/DCCXXV/twoplayers/backend/db/sqlc"
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
 	appLogger "github.com/DCCXXV/twoplayers/backend/internal/logger"
 	"github.com/DCCXXV/twoplayers/backend/internal/service"
 	"github.com/gin-gonic/gin"
@@ -16,14 +20,18 @@ type HTTPHandler struct {
 	roomService       service.RoomService
 	playerService     service.PlayerService
 	connectionService service.ConnectionService
+	puzzleService     service.PuzzleService
+	gameRecordService service.GameRecordService
 	logger            *slog.Logger
 }
 
-func NewHTTPHandler(rs service.RoomService, ps service.PlayerService, cs service.ConnectionService) *HTTPHandler {
+func NewHTTPHandler(rs service.RoomService, ps service.PlayerService, cs service.ConnectionService, pzs service.PuzzleService, grs service.GameRecordService) *HTTPHandler {
 	return &HTTPHandler{
 		roomService:       rs,
 		playerService:     ps,
 		connectionService: cs,
+		puzzleService:     pzs,
+		gameRecordService: grs,
 		logger:            appLogger.Get(),
 	}
 }
@@ -33,6 +41,14 @@ type CreateRoomRequest struct {
 	GameType    string           `json:"game_type" binding:"required"`
 	IsPrivate   bool             `json:"is_private"`
 	GameOptions *json.RawMessage `json:"game_options,omitempty"`
+	Mode        string           `json:"mode,omitempty"`
+	// HintsEnabled defaults to true in analysis rooms and false otherwise.
+	HintsEnabled *bool `json:"hints_enabled,omitempty"`
+	// StartPosition is a list of moves in the game's notation, such as
+	// "4453" for Connect Four, to start the game part way through.
+	StartPosition string `json:"start_position,omitempty"`
+	// PuzzleID picks the puzzle of a puzzle room, which sets the position.
+	PuzzleID string `json:"puzzle_id,omitempty"`
 }
 
 func (h *HTTPHandler) CreateRoom(c *gin.Context) {
@@ -51,6 +67,59 @@ func (h *HTTPHandler) CreateRoom(c *gin.Context) {
 		return
 	}
 
+	switch req.Mode {
+	case "":
+		req.Mode = service.RoomModePlay
+	case service.RoomModePlay, service.RoomModeAnalysis, service.RoomModePuzzle:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be 'play', 'analysis' or 'puzzle'"})
+		return
+	}
+
+	hintsEnabled := req.Mode == service.RoomModeAnalysis
+	if req.HintsEnabled != nil {
+		hintsEnabled = *req.HintsEnabled
+	}
+
+	var gameOptions json.RawMessage
+	if req.GameOptions != nil {
+		gameOptions = *req.GameOptions
+	}
+	var puzzleID uuid.UUID
+	if req.Mode == service.RoomModePuzzle {
+		var err error
+		if puzzleID, err = uuid.Parse(req.PuzzleID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A valid puzzle_id is required for puzzle rooms"})
+			return
+		}
+		puzzle, err := h.puzzleService.GetPuzzleByID(ctx, puzzleID)
+		if err != nil {
+			if err == service.ErrPuzzleNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Puzzle not found"})
+			} else {
+				h.logger.Error("Failed to get puzzle", "puzzle_id", puzzleID, "error", err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve puzzle"})
+			}
+			return
+		}
+		if puzzle.GameType != req.GameType {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "game_type does not match the puzzle"})
+			return
+		}
+
+		// The puzzle sets the position, and a hint would give it away.
+		gameOptions = puzzle.GameOptions
+		req.StartPosition = puzzle.Position
+		req.IsPrivate = true
+		hintsEnabled = false
+	}
+
+	if _, err := games.NewGameAtPosition(req.GameType, gameOptions, req.StartPosition); err != nil {
+		h.logger.Warn("Invalid game configuration for new room", "game_type", req.GameType, "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	_, err := h.connectionService.CreateConnection(ctx, service.CreateConnectionParams{DisplayName: displayName})
 	if err != nil {
 		if err == service.ErrDisplayNameTaken {
@@ -67,9 +136,11 @@ func (h *HTTPHandler) CreateRoom(c *gin.Context) {
 		GameType:        req.GameType,
 		IsPrivate:       req.IsPrivate,
 		HostDisplayName: displayName,
-	}
-	if req.GameOptions != nil {
-		serviceParams.GameOptions = *req.GameOptions
+		GameOptions:     gameOptions,
+		Mode:            req.Mode,
+		HintsEnabled:    hintsEnabled,
+		StartPosition:   req.StartPosition,
+		PuzzleID:        puzzleID,
 	}
 
 	createdRoom, err := h.roomService.CreateRoom(ctx, serviceParams)
@@ -191,6 +262,158 @@ func (h *HTTPHandler) ListPublicRooms(c *gin.Context) {
 	c.JSON(http.StatusOK, previews)
 }
 
+// ListGames serves the game catalog: every game with its rules, player
+// counts, option schema and suggested clock.
+func (h *HTTPHandler) ListGames(c *gin.Context) {
+	c.JSON(http.StatusOK, games.Catalog())
+}
+
+// ListPuzzles lists puzzles from the easiest up, optionally of one game
+// only. Solutions are left out.
+func (h *HTTPHandler) ListPuzzles(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	gameType := c.Query("game_type")
+	limit, offset, err := parsePage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	puzzles, err := h.puzzleService.ListPuzzles(ctx, gameType, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve puzzles"})
+		return
+	}
+
+	type PuzzlePreview struct {
+		ID          string          `json:"id"`
+		Title       string          `json:"title"`
+		GameType    string          `json:"game_type"`
+		GameOptions json.RawMessage `json:"game_options,omitempty"`
+		Position    string          `json:"position"`
+		Rating      int32           `json:"rating"`
+		Attempts    int32           `json:"attempts"`
+		Solves      int32           `json:"solves"`
+	}
+
+	previews := make([]PuzzlePreview, 0, len(puzzles))
+	for _, p := range puzzles {
+		previews = append(previews, PuzzlePreview{
+			ID:          p.ID.String(),
+			Title:       p.Title,
+			GameType:    p.GameType,
+			GameOptions: p.GameOptions,
+			Position:    p.Position,
+			Rating:      p.Rating,
+			Attempts:    p.Attempts,
+			Solves:      p.Solves,
+		})
+	}
+
+	c.JSON(http.StatusOK, previews)
+}
+
+// GameRecordResponse is a finished game with its post-game summary.
+type GameRecordResponse struct {
+	ID            string          `json:"id"`
+	RoomID        string          `json:"room_id"`
+	GameType      string          `json:"game_type"`
+	GameOptions   json.RawMessage `json:"game_options,omitempty"`
+	StartPosition string          `json:"start_position"`
+	Moves         string          `json:"moves"`
+	Players       [2]string       `json:"players"`
+	Winner        string          `json:"winner"`
+	Summary       json.RawMessage `json:"summary"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+// parsePage reads the limit and offset query parameters of a list. The
+// limit defaults to 20 and is clamped to 1..100; the offset cannot be
+// negative.
+func parsePage(c *gin.Context) (limit, offset int32, err error) {
+	limit = 20
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid limit: %q", l)
+		}
+		limit = int32(min(max(n, 1), 100))
+	}
+	if o := c.Query("offset"); o != "" {
+		n, err := strconv.Atoi(o)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid offset: %q", o)
+		}
+		offset = int32(min(max(n, 0), math.MaxInt32))
+	}
+	return limit, offset, nil
+}
+
+func newGameRecordResponse(r db.GameRecord) GameRecordResponse {
+	return GameRecordResponse{
+		ID:            r.ID.String(),
+		RoomID:        r.RoomID.String(),
+		GameType:      r.GameType,
+		GameOptions:   r.GameOptions,
+		StartPosition: r.StartPosition,
+		Moves:         r.Moves,
+		Players:       [2]string{r.Player0, r.Player1},
+		Winner:        r.Winner,
+		Summary:       r.Summary,
+		CreatedAt:     r.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func (h *HTTPHandler) GetGameRecord(c *gin.Context) {
+	ctx := c.Request.Context()
+	recordIDStr := c.Param("recordId")
+
+	recordID, err := uuid.Parse(recordIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game record ID format"})
+		return
+	}
+
+	record, err := h.gameRecordService.GetGameRecordByID(ctx, recordID)
+	if err != nil {
+		if err == service.ErrGameRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game record not found"})
+		} else {
+			h.logger.Error("Failed to get game record", "record_id", recordIDStr, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve game record"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newGameRecordResponse(record))
+}
+
+// ListRoomGameRecords lists the games finished in a room, the latest first.
+func (h *HTTPHandler) ListRoomGameRecords(c *gin.Context) {
+	ctx := c.Request.Context()
+	roomIDStr := c.Param("roomId")
+
+	roomID, err := uuid.Parse(roomIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID format"})
+		return
+	}
+
+	records, err := h.gameRecordService.ListGameRecordsByRoomID(ctx, roomID)
+	if err != nil {
+		h.logger.Error("Failed to list game records", "room_id", roomIDStr, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve game records"})
+		return
+	}
+
+	responses := make([]GameRecordResponse, 0, len(records))
+	for _, r := range records {
+		responses = append(responses, newGameRecordResponse(r))
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
 func (h *HTTPHandler) ListActiveConnections(c *gin.Context) {
 	ctx := c.Request.Context()
 
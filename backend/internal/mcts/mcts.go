@@ -0,0 +1,407 @@
+// Package mcts plays games by Monte Carlo tree search with the UCT
+// selection rule. It works with any game that implements
+// games.MoveGenerator, needs no knowledge of the game beyond its rules, and
+// suits games far too large for the exhaustive solver package.
+//
+// A search runs random playouts from the position on several goroutines
+// that share one tree, spreading out with virtual losses. The tree is kept
+// between searches, so that an engine following a game with Advance starts
+// each search with the playouts already spent on the new position.
+package mcts
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+const defaultMaxRolloutPlies = 500
+
+// Config sets the budget and behaviour of an engine. At least one of
+// Iterations and Duration must be set; with both, the search stops at
+// whichever runs out first.
+type Config struct {
+	// Iterations is the number of playouts per search.
+	Iterations int
+	// Duration is the wall-clock time per search.
+	Duration time.Duration
+	// Workers is the number of goroutines running playouts. It defaults to
+	// GOMAXPROCS.
+	Workers int
+	// Exploration is the UCT exploration constant. It defaults to √2.
+	Exploration float64
+	// Policy picks the moves of each rollout. It defaults to Random.
+	Policy Policy
+	// MaxRolloutPlies cuts off rollouts of games that can go on for a long
+	// time, such as Go; a cut-off rollout counts as a draw. It defaults to
+	// 500.
+	MaxRolloutPlies int
+	// Seed makes searches with a single worker and an iteration budget
+	// repeatable.
+	Seed uint64
+}
+
+// MoveStats describes one move from the searched position. Score is the
+// average playout result for the player making the move, counting a win as
+// 1 and a draw as 0.5.
+type MoveStats struct {
+	Move   any     `json:"move"`
+	Visits int     `json:"visits"`
+	Score  float64 `json:"score"`
+}
+
+// Result is the outcome of a search. Move is the most visited move, and
+// Moves lists every explored move from the most visited down. Visits counts
+// the playouts through the position including those kept from earlier
+// searches, while Playouts counts only the ones run by this search.
+type Result struct {
+	Move     any           `json:"move"`
+	Score    float64       `json:"score"`
+	Visits   int           `json:"visits"`
+	Playouts int           `json:"playouts"`
+	Moves    []MoveStats   `json:"moves"`
+	Elapsed  time.Duration `json:"elapsed"`
+}
+
+// node is a position in the search tree. player made move to reach it and
+// wins holds that player's playout results; toMove and untried are filled in
+// when the node is expanded. A node that is expanded without moves is the
+// end of the game.
+type node struct {
+	move     any
+	moveKey  string
+	player   int
+	toMove   int
+	children []*node
+	untried  []any
+	expanded bool
+	visits   int
+	wins     float64
+}
+
+// Engine searches positions of one game at a time. Its methods must not be
+// called concurrently; Search runs its own workers.
+type Engine struct {
+	config    Config
+	mu        sync.Mutex
+	root      *node
+	rootGame  games.MoveGenerator
+	rootState []byte
+	searches  uint64
+}
+
+func New(config Config) (*Engine, error) {
+	if config.Iterations < 0 || config.Duration < 0 {
+		return nil, fmt.Errorf("search budgets cannot be negative")
+	}
+	if config.Iterations == 0 && config.Duration == 0 {
+		return nil, fmt.Errorf("an iteration or time budget is required")
+	}
+	if config.Workers < 0 || config.Exploration < 0 || config.MaxRolloutPlies < 0 {
+		return nil, fmt.Errorf("workers, exploration and rollout length cannot be negative")
+	}
+
+	if config.Workers == 0 {
+		config.Workers = runtime.GOMAXPROCS(0)
+	}
+	if config.Exploration == 0 {
+		config.Exploration = math.Sqrt2
+	}
+	if config.Policy == nil {
+		config.Policy = Random
+	}
+	if config.MaxRolloutPlies == 0 {
+		config.MaxRolloutPlies = defaultMaxRolloutPlies
+	}
+	return &Engine{config: config}, nil
+}
+
+// Search picks a move for the player to move. The game itself is never
+// changed. If the position is the one the engine last searched or advanced
+// to, the existing tree is extended instead of starting over.
+func (e *Engine) Search(game games.MoveGenerator) (Result, error) {
+	if game.IsGameOver() {
+		return Result{}, fmt.Errorf("game is already over")
+	}
+	state, err := game.MarshalState()
+	if err != nil {
+		return Result{}, err
+	}
+	if e.root == nil || !bytes.Equal(state, e.rootState) {
+		e.setRoot(game.Clone().(games.MoveGenerator), state)
+	}
+
+	start := time.Now()
+	var deadline time.Time
+	if e.config.Duration > 0 {
+		deadline = start.Add(e.config.Duration)
+	}
+
+	var (
+		started   atomic.Int64
+		completed atomic.Int64
+		failed    atomic.Bool
+		errOnce   sync.Once
+		searchErr error
+		wg        sync.WaitGroup
+	)
+	e.searches++
+	for worker := range e.config.Workers {
+		r := rand.New(rand.NewPCG(e.config.Seed, e.searches<<16|uint64(worker)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !failed.Load() {
+				if e.config.Iterations > 0 && started.Add(1) > int64(e.config.Iterations) {
+					return
+				}
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					return
+				}
+				if err := e.playout(r); err != nil {
+					errOnce.Do(func() { searchErr = err })
+					failed.Store(true)
+					return
+				}
+				completed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if searchErr != nil {
+		e.root = nil
+		return Result{}, searchErr
+	}
+	if len(e.root.children) == 0 {
+		return Result{}, fmt.Errorf("search ran no playouts within its budget")
+	}
+	return e.result(int(completed.Load()), time.Since(start)), nil
+}
+
+// Advance plays a move on the position of the last search and keeps the
+// part of the tree below it. Calling it for every move of a game, both the
+// engine's and the opponent's, lets each search build on the previous one.
+// It does nothing before the first search.
+func (e *Engine) Advance(playerIndex int, move any) error {
+	if e.root == nil {
+		return nil
+	}
+	key, err := json.Marshal(move)
+	if err != nil {
+		return fmt.Errorf("invalid move format")
+	}
+	if err := e.rootGame.HandleMove(playerIndex, move); err != nil {
+		e.root = nil
+		return err
+	}
+	state, err := e.rootGame.MarshalState()
+	if err != nil {
+		e.root = nil
+		return err
+	}
+
+	for _, child := range e.root.children {
+		if child.player == playerIndex && child.moveKey == string(key) {
+			e.root = child
+			e.rootState = state
+			return nil
+		}
+	}
+	e.setRoot(e.rootGame, state)
+	return nil
+}
+
+func (e *Engine) setRoot(game games.MoveGenerator, state []byte) {
+	e.root = &node{player: -1}
+	e.rootGame = game
+	e.rootState = state
+}
+
+// playout runs one iteration: it selects a path down the tree, expands its
+// last node by one move, plays the game out with the policy and records the
+// result along the path.
+func (e *Engine) playout(r *rand.Rand) error {
+	path := e.selectPath()
+
+	game := e.rootGame.Clone().(games.MoveGenerator)
+	for _, n := range path[1:] {
+		if err := game.HandleMove(n.player, n.move); err != nil {
+			return fmt.Errorf("legal move was rejected: %w", err)
+		}
+	}
+
+	// Another worker may expand the leaf at any time, so the flag is read
+	// under the lock, which is released again for the move generation.
+	leaf := path[len(path)-1]
+	e.mu.Lock()
+	expanded := leaf.expanded
+	e.mu.Unlock()
+
+	if !expanded {
+		toMove, moves := game.CurrentPlayer(), game.LegalMoves()
+		if !game.IsGameOver() && len(moves) == 0 {
+			return fmt.Errorf("no legal moves in an unfinished game")
+		}
+
+		e.mu.Lock()
+		if !leaf.expanded {
+			leaf.expand(r, toMove, moves)
+		}
+		child := leaf.addChild()
+		e.mu.Unlock()
+
+		if child != nil {
+			path = append(path, child)
+			if err := game.HandleMove(child.player, child.move); err != nil {
+				return fmt.Errorf("legal move was rejected: %w", err)
+			}
+		}
+	}
+
+	winner, err := e.rollout(r, game)
+	if err != nil {
+		return err
+	}
+
+	var rewards [2]float64
+	for i := range rewards {
+		switch winner {
+		case game.PlayerSymbol(i):
+			rewards[i] = 1
+		case game.PlayerSymbol(1 - i):
+			rewards[i] = 0
+		default:
+			rewards[i] = 0.5
+		}
+	}
+
+	e.mu.Lock()
+	for _, n := range path {
+		if n.player >= 0 {
+			n.wins += rewards[n.player]
+		}
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// selectPath walks down the tree by UCT until it reaches a node that is
+// unexpanded, still has untried moves, or ends the game. A node with untried
+// moves gets a new child, which ends the path. Every node on the path is
+// visited straight away, so that until the playout is recorded it counts as
+// a loss and steers other workers elsewhere.
+func (e *Engine) selectPath() []*node {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := e.root
+	n.visits++
+	path := []*node{n}
+	for n.expanded {
+		if len(n.untried) > 0 {
+			return append(path, n.addChild())
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.bestChild(e.config.Exploration)
+		n.visits++
+		path = append(path, n)
+	}
+	return path
+}
+
+// rollout plays the game to the end with the policy and returns the winner.
+// It returns "" for a game cut off by MaxRolloutPlies.
+func (e *Engine) rollout(r *rand.Rand, game games.MoveGenerator) (string, error) {
+	for range e.config.MaxRolloutPlies {
+		if game.IsGameOver() {
+			return game.GetWinner(), nil
+		}
+		moves := game.LegalMoves()
+		if len(moves) == 0 {
+			return "", fmt.Errorf("no legal moves in an unfinished game")
+		}
+		move := e.config.Policy.Choose(r, game, moves)
+		if err := game.HandleMove(game.CurrentPlayer(), move); err != nil {
+			return "", fmt.Errorf("rollout move was rejected: %w", err)
+		}
+	}
+	return game.GetWinner(), nil
+}
+
+func (e *Engine) result(playouts int, elapsed time.Duration) Result {
+	moves := make([]MoveStats, 0, len(e.root.children))
+	for _, child := range e.root.children {
+		moves = append(moves, MoveStats{
+			Move:   child.move,
+			Visits: child.visits,
+			Score:  child.wins / float64(child.visits),
+		})
+	}
+	slices.SortStableFunc(moves, func(a, b MoveStats) int {
+		return cmp.Compare(b.Visits, a.Visits)
+	})
+
+	return Result{
+		Move:     moves[0].Move,
+		Score:    moves[0].Score,
+		Visits:   e.root.visits,
+		Playouts: playouts,
+		Moves:    moves,
+		Elapsed:  elapsed,
+	}
+}
+
+// expand records the player to move and the legal moves, in random order so
+// that moves are tried without bias.
+func (n *node) expand(r *rand.Rand, toMove int, moves []any) {
+	n.expanded = true
+	n.toMove = toMove
+	n.untried = slices.Clone(moves)
+	r.Shuffle(len(n.untried), func(i, j int) {
+		n.untried[i], n.untried[j] = n.untried[j], n.untried[i]
+	})
+}
+
+// addChild turns one untried move into a visited child, or returns nil when
+// every move has been tried.
+func (n *node) addChild() *node {
+	if len(n.untried) == 0 {
+		return nil
+	}
+	move := n.untried[len(n.untried)-1]
+	n.untried = n.untried[:len(n.untried)-1]
+
+	key, _ := json.Marshal(move)
+	child := &node{move: move, moveKey: string(key), player: n.toMove, visits: 1}
+	n.children = append(n.children, child)
+	return child
+}
+
+// bestChild returns the child with the highest upper confidence bound for
+// the player choosing between them.
+func (n *node) bestChild(exploration float64) *node {
+	logVisits := math.Log(float64(n.visits))
+	var best *node
+	bestValue := math.Inf(-1)
+	for _, child := range n.children {
+		visits := float64(child.visits)
+		value := child.wins/visits + exploration*math.Sqrt(logVisits/visits)
+		if value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	return best
+}
@@ -0,0 +1,197 @@
+package mcts
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"runtime"
+	"testing"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+func newMoveGenerator(t testing.TB, gameType, position string) games.MoveGenerator {
+	t.Helper()
+	game, err := games.NewGame(gameType, nil)
+	if err != nil {
+		t.Fatalf("Expected no error creating %s, but got %v", gameType, err)
+	}
+	if position != "" {
+		if err := games.SetPosition(game, json.RawMessage(position)); err != nil {
+			t.Fatalf("Expected no error setting up the position, but got %v", err)
+		}
+	}
+	return game.(games.MoveGenerator)
+}
+
+func newEngine(t *testing.T, config Config) *Engine {
+	t.Helper()
+	engine, err := New(config)
+	if err != nil {
+		t.Fatalf("Expected no error creating the engine, but got %v", err)
+	}
+	return engine
+}
+
+func TestNew_RejectsBadConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"no budget", Config{}},
+		{"negative iterations", Config{Iterations: -1}},
+		{"negative workers", Config{Iterations: 10, Workers: -2}},
+		{"negative exploration", Config{Iterations: 10, Exploration: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.config); err == nil {
+				t.Error("Expected an error, but got nil")
+			}
+		})
+	}
+}
+
+func TestSearch_TakesTheWinningMove(t *testing.T) {
+	game := newMoveGenerator(t, "tic-tac-toe", `{"board": ["X", "X", "", "O", "O", "", "", "", ""], "currentTurn": 0}`)
+	before := game.PositionKey()
+
+	result, err := newEngine(t, Config{Iterations: 2000, Workers: 1, Seed: 1}).Search(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if cell := result.Move.(map[string]any)["cellIndex"]; cell != float64(2) {
+		t.Errorf("Expected X to complete the top row at cell 2, but got %v", cell)
+	}
+	if result.Score < 0.9 {
+		t.Errorf("Expected the winning move to score close to 1, but got %.2f", result.Score)
+	}
+	if game.PositionKey() != before {
+		t.Error("Expected searching to leave the game untouched")
+	}
+}
+
+func TestSearch_ParallelWorkersShareTheBudget(t *testing.T) {
+	game := newMoveGenerator(t, "connect-four", "")
+
+	result, err := newEngine(t, Config{Iterations: 1000, Workers: 4}).Search(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Playouts != 1000 || result.Visits != 1000 {
+		t.Errorf("Expected 1000 playouts, but got %d playouts and %d visits", result.Playouts, result.Visits)
+	}
+
+	total := 0
+	for _, move := range result.Moves {
+		total += move.Visits
+	}
+	if total != 1000 {
+		t.Errorf("Expected every playout to pass through a move, but got %d", total)
+	}
+}
+
+func TestSearch_PlaysLegalMovesInEveryGame(t *testing.T) {
+	for _, gameType := range games.GameTypes() {
+		game, _ := games.NewGame(gameType, nil)
+		gen, ok := game.(games.MoveGenerator)
+		if !ok {
+			continue
+		}
+
+		t.Run(gameType, func(t *testing.T) {
+			engine := newEngine(t, Config{Iterations: 20, Workers: 2, MaxRolloutPlies: 30})
+			for ply := 0; ply < 3 && !gen.IsGameOver(); ply++ {
+				result, err := engine.Search(gen)
+				if err != nil {
+					t.Fatalf("Expected no error, but got %v", err)
+				}
+				player := gen.CurrentPlayer()
+				if err := gen.HandleMove(player, result.Move); err != nil {
+					t.Fatalf("Expected the chosen move %v to be legal, but got %v", result.Move, err)
+				}
+				if err := engine.Advance(player, result.Move); err != nil {
+					t.Fatalf("Expected no error advancing, but got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAdvance_ReusesTheTree(t *testing.T) {
+	game := newMoveGenerator(t, "connect-four", "")
+	engine := newEngine(t, Config{Iterations: 500, Workers: 1})
+
+	result, err := engine.Search(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	kept := result.Moves[0].Visits
+
+	game.HandleMove(0, result.Move)
+	if err := engine.Advance(0, result.Move); err != nil {
+		t.Fatalf("Expected no error advancing, but got %v", err)
+	}
+	result, err = engine.Search(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Visits != kept+500 {
+		t.Errorf("Expected the %d visits of the played move to be kept, but got %d in total", kept, result.Visits)
+	}
+
+	// A position the engine was not following starts a new tree.
+	result, err = engine.Search(newMoveGenerator(t, "connect-four", ""))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Visits != 500 {
+		t.Errorf("Expected a fresh tree of 500 visits, but got %d", result.Visits)
+	}
+}
+
+func TestWinFirst_PrefersImmediateWins(t *testing.T) {
+	game := newMoveGenerator(t, "tic-tac-toe", `{"board": ["X", "X", "", "O", "O", "", "X", "", ""], "currentTurn": 1}`)
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for range 10 {
+		move := WinFirst.Choose(r, game, game.LegalMoves())
+		if cell := move.(map[string]any)["cellIndex"]; cell != float64(5) {
+			t.Fatalf("Expected O to complete the middle row at cell 5, but got %v", cell)
+		}
+	}
+}
+
+// BenchmarkPlayouts reports how many playouts a single worker runs per
+// second from the starting position of each game.
+func BenchmarkPlayouts(b *testing.B) {
+	benchmarkPlayouts(b, 1)
+}
+
+// BenchmarkParallelPlayouts is BenchmarkPlayouts with one worker per CPU.
+func BenchmarkParallelPlayouts(b *testing.B) {
+	benchmarkPlayouts(b, runtime.GOMAXPROCS(0))
+}
+
+func benchmarkPlayouts(b *testing.B, workers int) {
+	for _, gameType := range games.GameTypes() {
+		game, _ := games.NewGame(gameType, nil)
+		gen, ok := game.(games.MoveGenerator)
+		if !ok {
+			continue
+		}
+
+		b.Run(gameType, func(b *testing.B) {
+			engine, err := New(Config{Iterations: b.N, Workers: workers})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			result, err := engine.Search(gen)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(result.Playouts)/b.Elapsed().Seconds(), "playouts/s")
+		})
+	}
+}
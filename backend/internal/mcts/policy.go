@@ -0,0 +1,46 @@
+package mcts
+
+import (
+	"math/rand/v2"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+// Policy chooses the moves of a rollout. Choose is given the position and
+// its legal moves, which are never empty, and must return one of them. A
+// policy is shared by every worker, so it must be safe for concurrent use;
+// each worker passes its own source of randomness.
+type Policy interface {
+	Choose(r *rand.Rand, game games.MoveGenerator, moves []any) any
+}
+
+// PolicyFunc adapts an ordinary function to the Policy interface.
+type PolicyFunc func(r *rand.Rand, game games.MoveGenerator, moves []any) any
+
+func (f PolicyFunc) Choose(r *rand.Rand, game games.MoveGenerator, moves []any) any {
+	return f(r, game, moves)
+}
+
+// Random plays uniformly random moves. It is the cheapest policy and the
+// default.
+var Random Policy = PolicyFunc(func(r *rand.Rand, _ games.MoveGenerator, moves []any) any {
+	return moves[r.IntN(len(moves))]
+})
+
+// WinFirst plays a move that wins on the spot when there is one and a
+// random move otherwise. Every rollout step tries each legal move on a
+// clone, so it suits games with few moves per turn where random play keeps
+// missing immediate wins, such as Connect Four.
+var WinFirst Policy = PolicyFunc(winFirst)
+
+func winFirst(r *rand.Rand, game games.MoveGenerator, moves []any) any {
+	player := game.CurrentPlayer()
+	symbol := game.PlayerSymbol(player)
+	for _, move := range moves {
+		child := game.Clone()
+		if child.HandleMove(player, move) == nil && child.GetWinner() == symbol {
+			return move
+		}
+	}
+	return moves[r.IntN(len(moves))]
+}
@@ -0,0 +1,27 @@
+package puzzles
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed curated.json
+var curatedJSON []byte
+
+// CuratedPuzzle is a puzzle shipped with the server. Slug identifies it
+// across restarts so that seeding the database keeps its rating.
+type CuratedPuzzle struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	Puzzle
+}
+
+// Curated returns the puzzles shipped with the server.
+func Curated() ([]CuratedPuzzle, error) {
+	var curated []CuratedPuzzle
+	if err := json.Unmarshal(curatedJSON, &curated); err != nil {
+		return nil, fmt.Errorf("invalid curated puzzles: %w", err)
+	}
+	return curated, nil
+}
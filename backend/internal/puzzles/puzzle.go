@@ -0,0 +1,263 @@
+// Package puzzles checks attempts at puzzles: positions from which one
+// player, the solver, must find the moves of a stored solution tree while
+// the other side replies from the same tree.
+package puzzles
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+	"github.com/DCCXXV/twoplayers/backend/internal/solver"
+)
+
+// The opponent asks the solver which of several replies in a solution tree
+// holds out best. The limits keep that quick; when they are not enough the
+// first reply in sorted order is played.
+const (
+	replyMaxPositions = 200_000
+	replySolveTime    = time.Second
+)
+
+// Solution is a solution tree. Its keys are moves in the game's notation by
+// whoever is to move: at the solver's turn they are the moves that are
+// accepted, and at the opponent's turn the replies it may choose from. A
+// solver move that leads to an empty tree solves the puzzle.
+type Solution map[string]Solution
+
+// Puzzle is a position, written as the moves leading to it in the game's
+// notation, and the solution from there. The solver is the player to move
+// in that position.
+type Puzzle struct {
+	GameType    string          `json:"gameType"`
+	GameOptions json.RawMessage `json:"gameOptions,omitempty"`
+	Position    string          `json:"position"`
+	Solution    Solution        `json:"solution"`
+}
+
+// Start sets up the puzzle's position.
+func (p Puzzle) Start() (games.MoveGenerator, error) {
+	game, err := games.NewGameAtPosition(p.GameType, p.GameOptions, p.Position)
+	if err != nil {
+		return nil, err
+	}
+	gen, ok := game.(games.MoveGenerator)
+	if !ok {
+		return nil, fmt.Errorf("puzzles are not available for %s", p.GameType)
+	}
+	if gen.IsGameOver() {
+		return nil, fmt.Errorf("puzzle position is already over")
+	}
+	return gen, nil
+}
+
+// Validate checks that every line of the solution can be played and ends
+// with a move by the solver before the game is over.
+func (p Puzzle) Validate() error {
+	game, err := p.Start()
+	if err != nil {
+		return err
+	}
+	if len(p.Solution) == 0 {
+		return fmt.Errorf("puzzle has no solution")
+	}
+	return validate(game, game.CurrentPlayer(), p.Solution, "")
+}
+
+func validate(game games.MoveGenerator, solverIndex int, tree Solution, line string) error {
+	mover := game.CurrentPlayer()
+	for _, key := range slices.Sorted(maps.Keys(tree)) {
+		next := game.Clone().(games.MoveGenerator)
+		if err := games.PlayMoveList(next, key); err != nil {
+			return fmt.Errorf("after %q: %w", line, err)
+		}
+		played := line + " " + key
+
+		subtree := tree[key]
+		if len(subtree) == 0 {
+			if mover != solverIndex {
+				return fmt.Errorf("line %q ends with the opponent's move", played)
+			}
+			continue
+		}
+		if next.IsGameOver() {
+			return fmt.Errorf("line %q goes on after the game is over", played)
+		}
+		if err := validate(next, solverIndex, subtree, played); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status is the progress of an attempt.
+type Status string
+
+const (
+	StatusPlaying Status = "playing"
+	StatusSolved  Status = "solved"
+	StatusFailed  Status = "failed"
+)
+
+// Attempt is one try at a puzzle. The opponent's replies are played as soon
+// as the solver's moves are, so the game is always either over or waiting
+// for the solver.
+type Attempt struct {
+	game   games.MoveGenerator
+	node   Solution
+	player int
+	status Status
+}
+
+func NewAttempt(p Puzzle) (*Attempt, error) {
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid puzzle: %w", err)
+	}
+	game, err := p.Start()
+	if err != nil {
+		return nil, err
+	}
+	return &Attempt{
+		game:   game,
+		node:   p.Solution,
+		player: game.CurrentPlayer(),
+		status: StatusPlaying,
+	}, nil
+}
+
+// Game returns the game being played. It must not be changed directly; use
+// Play instead.
+func (a *Attempt) Game() games.Game {
+	return a.game
+}
+
+// Clone returns a copy of the attempt that can be played on its own. The
+// solution tree is shared, as it is never changed.
+func (a *Attempt) Clone() *Attempt {
+	clone := *a
+	clone.game = a.game.Clone().(games.MoveGenerator)
+	return &clone
+}
+
+// Player returns the index of the player solving the puzzle.
+func (a *Attempt) Player() int {
+	return a.player
+}
+
+func (a *Attempt) Status() Status {
+	return a.status
+}
+
+// Play makes a move for the solver. A legal move outside the solution is
+// still played but fails the attempt. When the move keeps the attempt going
+// and the opponent is to move, its reply is played and returned, written in
+// the game's notation.
+func (a *Attempt) Play(move any) (reply string, err error) {
+	if a.status != StatusPlaying {
+		return "", fmt.Errorf("puzzle is already %s", a.status)
+	}
+
+	played := a.game.Clone().(games.MoveGenerator)
+	if err := played.HandleMove(a.player, move); err != nil {
+		return "", err
+	}
+
+	key, ok := a.match(played.PositionKey())
+	a.game = played
+	if !ok {
+		a.status = StatusFailed
+		return "", nil
+	}
+
+	a.node = a.node[key]
+	if len(a.node) == 0 {
+		a.status = StatusSolved
+		return "", nil
+	}
+	if a.game.CurrentPlayer() == a.player {
+		return "", nil
+	}
+
+	reply = a.chooseReply()
+	if err := games.PlayMoveList(a.game, reply); err != nil {
+		return "", fmt.Errorf("solution reply %q: %w", reply, err)
+	}
+	a.node = a.node[reply]
+	return reply, nil
+}
+
+// match finds the move of the current node that leads to the position
+// reached. Comparing positions rather than text accepts any way of writing
+// the same move.
+func (a *Attempt) match(position string) (string, bool) {
+	for key := range a.node {
+		next := a.game.Clone().(games.MoveGenerator)
+		if games.PlayMoveList(next, key) == nil && next.PositionKey() == position {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// chooseReply picks the opponent's reply among those of the current node:
+// the one the solver scores best for the opponent, or the first in sorted
+// order when the positions are too large to solve.
+func (a *Attempt) chooseReply() string {
+	replies := slices.Sorted(maps.Keys(a.node))
+	if len(replies) == 1 {
+		return replies[0]
+	}
+
+	s := solver.New(replyMaxPositions)
+	s.SetDeadline(time.Now().Add(replySolveTime))
+	best, bestScore := replies[0], 0
+	for i, reply := range replies {
+		next := a.game.Clone().(games.MoveGenerator)
+		if err := games.PlayMoveList(next, reply); err != nil {
+			continue
+		}
+		score, err := replyScore(s, next, 1-a.player)
+		if err != nil {
+			return replies[0]
+		}
+		if i == 0 || score > bestScore {
+			best, bestScore = reply, score
+		}
+	}
+	return best
+}
+
+// replyScore rates a position for the opponent: winning beats drawing beats
+// losing, a win is better the sooner it comes and a loss the later.
+func replyScore(s *solver.Solver, game games.MoveGenerator, opponent int) (int, error) {
+	if game.IsGameOver() {
+		switch game.GetWinner() {
+		case game.PlayerSymbol(opponent):
+			return int(solver.Win) * 1000, nil
+		case game.PlayerSymbol(1 - opponent):
+			return int(solver.Loss) * 1000, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	result, err := s.Solve(game)
+	if err != nil {
+		return 0, err
+	}
+	value := result.Value
+	if game.CurrentPlayer() != opponent {
+		value = -value
+	}
+	switch value {
+	case solver.Win:
+		return 1000 - result.Distance, nil
+	case solver.Loss:
+		return -1000 + result.Distance, nil
+	default:
+		return 0, nil
+	}
+}
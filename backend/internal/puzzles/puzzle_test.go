@@ -0,0 +1,179 @@
+package puzzles
+
+import (
+	"testing"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+func makeTicTacToeMovePayload(cellIndex int) map[string]any {
+	return map[string]any{"cellIndex": float64(cellIndex)}
+}
+
+// forkPuzzle has X find the fork on 9, 7 and 5 after O blocks on 7.
+func forkPuzzle() Puzzle {
+	return Puzzle{
+		GameType: "tic-tac-toe",
+		Position: "8 3",
+		Solution: Solution{"9": {"7": {"5": {
+			"1": {"2": {}},
+			"2": {"1": {}},
+			"4": {"1": {}, "2": {}},
+			"6": {"1": {}, "2": {}},
+		}}}},
+	}
+}
+
+func TestCurated_AreValid(t *testing.T) {
+	curated, err := Curated()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(curated) == 0 {
+		t.Fatal("Expected curated puzzles, but got none")
+	}
+
+	slugs := make(map[string]bool)
+	for _, puzzle := range curated {
+		if slugs[puzzle.Slug] {
+			t.Errorf("Expected unique slugs, but %s is repeated", puzzle.Slug)
+		}
+		slugs[puzzle.Slug] = true
+		if err := puzzle.Validate(); err != nil {
+			t.Errorf("Expected %s to be valid, but got %v", puzzle.Slug, err)
+		}
+	}
+}
+
+func TestValidate_RejectsBrokenSolutions(t *testing.T) {
+	tests := []struct {
+		name     string
+		solution Solution
+	}{
+		{"no solution", Solution{}},
+		{"illegal move", Solution{"8": {}}},
+		{"ends with the opponent", Solution{"9": {"7": {}}}},
+		{"goes on after the game", Solution{"9": {"7": {"5": {"1": {"2": {"4": {}}}}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			puzzle := forkPuzzle()
+			puzzle.Solution = tt.solution
+			if err := puzzle.Validate(); err == nil {
+				t.Error("Expected an error, but got nil")
+			}
+		})
+	}
+}
+
+func TestAttempt_Solve(t *testing.T) {
+	attempt, err := NewAttempt(forkPuzzle())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if attempt.Player() != 0 {
+		t.Fatalf("Expected X to solve the puzzle, but got player %d", attempt.Player())
+	}
+
+	reply, err := attempt.Play(makeTicTacToeMovePayload(8))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if reply != "7" {
+		t.Errorf("Expected O to block on 7, but got %q", reply)
+	}
+
+	// Every reply to the fork loses just as fast, so the opponent takes
+	// the first.
+	if reply, _ = attempt.Play(makeTicTacToeMovePayload(4)); reply != "1" {
+		t.Errorf("Expected O to reply on 1, but got %q", reply)
+	}
+	if attempt.Status() != StatusPlaying {
+		t.Errorf("Expected the attempt to go on, but got %s", attempt.Status())
+	}
+
+	if _, err := attempt.Play(makeTicTacToeMovePayload(1)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if attempt.Status() != StatusSolved {
+		t.Errorf("Expected the puzzle to be solved, but got %s", attempt.Status())
+	}
+	if !attempt.Game().IsGameOver() {
+		t.Error("Expected the winning move to end the game")
+	}
+	if _, err := attempt.Play(makeTicTacToeMovePayload(0)); err == nil {
+		t.Error("Expected an error playing on after the puzzle, but got nil")
+	}
+}
+
+func TestAttempt_WrongMoveFails(t *testing.T) {
+	attempt, err := NewAttempt(forkPuzzle())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if _, err := attempt.Play(makeTicTacToeMovePayload(7)); err == nil {
+		t.Fatal("Expected an error for an occupied cell, but got nil")
+	}
+	if attempt.Status() != StatusPlaying {
+		t.Errorf("Expected an illegal move not to count, but got %s", attempt.Status())
+	}
+
+	if _, err := attempt.Play(makeTicTacToeMovePayload(4)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if attempt.Status() != StatusFailed {
+		t.Errorf("Expected the attempt to fail, but got %s", attempt.Status())
+	}
+	if board := attempt.Game().(*games.TicTacToe).Board; board[4] != "X" {
+		t.Errorf("Expected the wrong move to be shown on the board, but got %v", board)
+	}
+}
+
+func TestAttempt_CloneIsIndependent(t *testing.T) {
+	attempt, err := NewAttempt(forkPuzzle())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	clone := attempt.Clone()
+	if _, err := clone.Play(makeTicTacToeMovePayload(4)); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if attempt.Status() != StatusPlaying {
+		t.Errorf("Expected the original attempt to go on, but got %s", attempt.Status())
+	}
+	if board := attempt.Game().(*games.TicTacToe).Board; board[4] != "" {
+		t.Errorf("Expected the original board to be unchanged, but got %v", board)
+	}
+}
+
+func TestAttempt_ChoosesTheStrongestReply(t *testing.T) {
+	puzzle := forkPuzzle()
+	// O blocking on 7 holds out; O on 1 loses at once to X on 7.
+	puzzle.Solution = Solution{"9": {
+		"1": {"7": {}},
+		"7": {"5": {"1": {"2": {}}, "2": {"1": {}}, "4": {"1": {}}, "6": {"1": {}}}},
+	}}
+	attempt, err := NewAttempt(puzzle)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if reply, _ := attempt.Play(makeTicTacToeMovePayload(8)); reply != "7" {
+		t.Errorf("Expected O to block on 7, but got %q", reply)
+	}
+}
+
+func TestRatingChange(t *testing.T) {
+	if change := RatingChange(DefaultRating, true); change != -16 {
+		t.Errorf("Expected a solved even puzzle to lose 16 points, but got %d", change)
+	}
+	if change := RatingChange(DefaultRating, false); change != 16 {
+		t.Errorf("Expected a failed even puzzle to gain 16 points, but got %d", change)
+	}
+	if hard, easy := RatingChange(2000, true), RatingChange(1000, true); hard >= easy {
+		t.Errorf("Expected solving a hard puzzle to cost it more, but got %d and %d", hard, easy)
+	}
+}
@@ -0,0 +1,21 @@
+package puzzles
+
+import "math"
+
+// Puzzles are rated like players in the Elo system, with every attempt a
+// game between the puzzle and a solver of the default rating: a failed
+// attempt is a win for the puzzle and raises its rating.
+const (
+	DefaultRating = 1500
+	ratingK       = 32
+)
+
+// RatingChange returns how much an attempt moves a puzzle's rating.
+func RatingChange(rating int, solved bool) int {
+	expected := 1 / (1 + math.Pow(10, float64(DefaultRating-rating)/400))
+	score := 1.0
+	if solved {
+		score = 0
+	}
+	return int(math.Round(ratingK * (score - expected)))
+}
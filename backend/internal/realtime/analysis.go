@@ -0,0 +1,89 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// analysisRequest covers the payloads of every analysis_* message. Node
+// defaults to the current position where it is optional. An analysis_move
+// may give Moves in the game's notation instead of a single Move.
+type analysisRequest struct {
+	Player    *int            `json:"player"`
+	Move      any             `json:"move"`
+	Node      *int            `json:"node"`
+	Direction string          `json:"direction"`
+	Glyph     string          `json:"glyph"`
+	Comment   string          `json:"comment"`
+	Position  json.RawMessage `json:"position"`
+	Moves     string          `json:"moves"`
+}
+
+// handleAnalysisMessage applies a change to the analysis tree of the room.
+// Either participant may move for both sides; spectators can only watch.
+func (r *Room) handleAnalysisMessage(client *Client, msgType string, payload json.RawMessage) {
+	if client.playerIndex() == -1 {
+		client.sendError("Spectators cannot change the analysis.")
+		return
+	}
+
+	var req analysisRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		client.sendError(fmt.Sprintf("Invalid payload for %s", msgType))
+		return
+	}
+
+	r.mu.Lock()
+	if r.Analysis == nil {
+		r.mu.Unlock()
+		client.sendError("This is not an analysis room.")
+		return
+	}
+
+	node := r.Analysis.Current
+	if req.Node != nil {
+		node = *req.Node
+	}
+
+	var err error
+	switch msgType {
+	case "analysis_move":
+		switch {
+		case req.Moves != "":
+			err = r.Analysis.PlayMoveList(req.Moves)
+		case req.Player == nil:
+			err = errors.New("analysis_move needs the player making the move")
+		default:
+			err = r.Analysis.Play(*req.Player, req.Move)
+		}
+	case "analysis_navigate":
+		switch req.Direction {
+		case "back":
+			err = r.Analysis.Back()
+		case "forward":
+			err = r.Analysis.Forward()
+		case "":
+			err = r.Analysis.Navigate(node)
+		default:
+			err = errors.New("direction must be 'back' or 'forward'")
+		}
+	case "analysis_annotate":
+		err = r.Analysis.Annotate(node, req.Glyph, req.Comment)
+	case "analysis_delete":
+		err = r.Analysis.Delete(node)
+	case "analysis_setup":
+		err = r.Analysis.SetPosition(req.Position)
+	}
+
+	// Setting up a position replaces the game instance.
+	r.Game = r.Analysis.Game()
+	r.mu.Unlock()
+
+	if err != nil {
+		client.sendError(err.Error())
+		return
+	}
+
+	r.broadcastRoomState()
+}
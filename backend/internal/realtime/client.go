@@ -3,6 +3,7 @@ package realtime
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,12 @@ type Client struct {
 	send        chan []byte
 	role        string
 	joinedAt    time.Time
+
+	// hintMu guards the hint bookkeeping, which the hint search updates
+	// from its own goroutine.
+	hintMu       sync.Mutex
+	hintInFlight bool
+	lastHintAt   time.Time
 }
 
 func (c *Client) readPump() {
@@ -65,6 +72,18 @@ func (c *Client) readPump() {
 			} else {
 				c.sendError("Not in a room.")
 			}
+		case "analysis_move", "analysis_navigate", "analysis_annotate", "analysis_delete", "analysis_setup":
+			if c.currentRoom != nil {
+				c.currentRoom.handleAnalysisMessage(c, msg.Type, msg.Payload)
+			} else {
+				c.sendError("Not in a room.")
+			}
+		case "request_hint":
+			if c.currentRoom != nil {
+				c.currentRoom.handleHintRequest(c)
+			} else {
+				c.sendError("Not in a room.")
+			}
 		case "update_display_name":
 			c.manager.handleUpdateDisplayName(c, msg.Payload)
 		case "leave_room":
@@ -103,6 +122,18 @@ func (c *Client) writePump() {
 	}
 }
 
+// playerIndex returns the seat of the client in its room, or -1 for spectators.
+func (c *Client) playerIndex() int {
+	switch c.role {
+	case "player_0":
+		return 0
+	case "player_1":
+		return 1
+	default:
+		return -1
+	}
+}
+
 func (c *Client) sendConnectionReady() {
 	c.sendMessage("connection_ready", map[string]string{"displayName": c.displayName})
 }
@@ -123,3 +154,27 @@ func (c *Client) sendMessage(msgType string, payload any) {
 func (c *Client) sendError(message string) {
 	c.sendMessage("error", ErrorPayload{Message: message})
 }
+
+// startHint claims the client's one hint in flight. It returns the reason
+// for refusing when a hint is still being worked out or the last one was
+// sent less than hintCooldown ago.
+func (c *Client) startHint() string {
+	c.hintMu.Lock()
+	defer c.hintMu.Unlock()
+	if c.hintInFlight {
+		return "A hint is already being worked out."
+	}
+	if time.Since(c.lastHintAt) < hintCooldown {
+		return "Please wait a moment before requesting another hint."
+	}
+	c.hintInFlight = true
+	return ""
+}
+
+// finishHint releases the hint claimed by startHint and starts the cooldown.
+func (c *Client) finishHint() {
+	c.hintMu.Lock()
+	defer c.hintMu.Unlock()
+	c.hintInFlight = false
+	c.lastHintAt = time.Now()
+}
@@ -14,4 +14,8 @@ const (
 
 	// Maximum message size allowed from peer.
 	maxMessageSize = 512
+
+	// Maximum message size allowed from peers in analysis rooms, where a
+	// whole position can be set up at once.
+	maxAnalysisMessageSize = 16 * 1024
 )
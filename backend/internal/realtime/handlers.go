@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/DCCXXV/twoplayers/backend/internal/games"
+	"github.com/DCCXXV/twoplayers/backend/internal/review"
+	"github.com/DCCXXV/twoplayers/backend/internal/service"
 	"github.com/google/uuid"
 )
 
@@ -57,22 +59,52 @@ func (m *Manager) handleJoinRoom(client *Client, payload json.RawMessage) {
 		}
 
 		var game GameInstance
-		game, err = games.NewGame(dbRoom.GameType)
+		var analysis *games.Analysis
+		var puzzle *roomPuzzle
+		switch dbRoom.Mode {
+		case service.RoomModePuzzle:
+			puzzle, err = m.loadPuzzle(uuid.UUID(dbRoom.PuzzleID.Bytes))
+			if err == nil {
+				game = puzzle.attempt.Game()
+			}
+		case service.RoomModeAnalysis:
+			analysis, err = games.NewAnalysis(dbRoom.GameType, dbRoom.GameOptions)
+			if err == nil && dbRoom.StartPosition != "" {
+				err = analysis.PlayMoveList(dbRoom.StartPosition)
+			}
+			if err == nil {
+				game = analysis.Game()
+			}
+		default:
+			game, err = games.NewGameAtPosition(dbRoom.GameType, dbRoom.GameOptions, dbRoom.StartPosition)
+		}
 		if err != nil {
 			m.mu.Unlock()
 			client.sendError(fmt.Sprintf("Error creating game instance: %v", err))
 			return
 		}
 
+		meta, _ := games.GameMetadata(dbRoom.GameType)
 		room = &Room{
 			ID:              uuid.UUID(dbRoom.ID.Bytes),
 			GameType:        dbRoom.GameType,
 			Clients:         make(map[uuid.UUID]*Client),
 			HostName:        dbRoom.HostDisplayName,
+			Mode:            dbRoom.Mode,
 			Game:            game,
+			Analysis:        analysis,
+			HintsEnabled:    dbRoom.HintsEnabled,
+			GameOptions:     dbRoom.GameOptions,
+			StartPosition:   dbRoom.StartPosition,
+			turnStartedAt:   time.Now(),
 			manager:         m,
-			MaxPlayers:      m.getMaxPlayersForGame(dbRoom.GameType),
+			MinPlayers:      meta.MinPlayers,
+			MaxPlayers:      meta.MaxPlayers,
 			rematchRequests: make(map[uuid.UUID]bool),
+			puzzle:          puzzle,
+		}
+		if puzzle != nil {
+			room.MinPlayers, room.MaxPlayers = 1, 1
 		}
 		m.rooms[room.ID] = room
 	}
@@ -80,17 +112,19 @@ func (m *Manager) handleJoinRoom(client *Client, payload json.RawMessage) {
 
 	room.addClient(client)
 
+	// Positions set up in an analysis need more room than any move.
+	if room.Analysis != nil {
+		client.conn.SetReadLimit(maxAnalysisMessageSize)
+	} else {
+		client.conn.SetReadLimit(maxMessageSize)
+	}
+
 	go m.broadcastRoomListUpdate(room.GameType)
 }
 
 func (c *Client) handleGameMove(payload json.RawMessage) {
-	var playerIndex int
-	switch c.role {
-	case "player_0":
-		playerIndex = 0
-	case "player_1":
-		playerIndex = 1
-	default:
+	playerIndex := c.playerIndex()
+	if playerIndex == -1 {
 		c.sendError("Spectators cannot make moves.")
 		return
 	}
@@ -101,17 +135,53 @@ func (c *Client) handleGameMove(payload json.RawMessage) {
 		return
 	}
 
-	if err := c.currentRoom.Game.HandleMove(playerIndex, move); err != nil {
+	room := c.currentRoom
+	if room.Analysis != nil {
+		c.sendError("Use analysis_move in analysis rooms.")
+		return
+	}
+	if room.puzzle != nil {
+		room.handlePuzzleMove(c, move)
+		return
+	}
+
+	room.mu.Lock()
+	err := room.Game.HandleMove(playerIndex, move)
+	var finished *finishedGame
+	if err == nil {
+		now := time.Now()
+		room.moves = append(room.moves, review.Move{Player: playerIndex, Move: move, Think: now.Sub(room.turnStartedAt)})
+		room.turnStartedAt = now
+		if room.Game.IsGameOver() {
+			finished = room.finishedGameInternal()
+		}
+	}
+	room.mu.Unlock()
+	if err != nil {
 		c.sendError(err.Error())
 		return
 	}
 
-	c.currentRoom.broadcastRoomState()
+	room.broadcastRoomState()
+	if finished != nil {
+		go room.recordGame(finished)
+	}
 }
 
 func (r *Room) handleRematch(client *Client) {
+	if r.puzzle != nil {
+		r.retryPuzzle(client)
+		return
+	}
+
 	r.mu.Lock()
 
+	if r.Analysis != nil {
+		client.sendError("Analysis rooms have no rematches.")
+		r.mu.Unlock()
+		return
+	}
+
 	if !r.Game.IsGameOver() {
 		client.sendError("The game is not over yet.")
 		r.mu.Unlock()
@@ -130,7 +200,7 @@ func (r *Room) handleRematch(client *Client) {
 
 	if allPlayersRequestedRematch {
 		r.mu.Lock()
-		r.Game.Reset()
+		r.restartGameInternal()
 
 		var player0, player1 *Client
 		for _, p := range r.getPlayersInternal() {
@@ -223,6 +293,7 @@ func (m *Manager) handleLeaveRoom(client *Client) {
 	roomID := room.ID
 
 	shouldDeleteRoom := room.removeClient(client)
+	client.conn.SetReadLimit(maxMessageSize)
 
 	m.mu.Lock()
 	if shouldDeleteRoom || len(room.Clients) == 0 {
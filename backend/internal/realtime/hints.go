@@ -0,0 +1,133 @@
+package realtime
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+	"github.com/DCCXXV/twoplayers/backend/internal/mcts"
+	"github.com/DCCXXV/twoplayers/backend/internal/solver"
+)
+
+// A hint solves the position exactly only in the games listed in
+// solvableGames, which are short enough for the solver to finish. It visits
+// at most hintMaxPositions positions, which keeps its table to about 30MB in
+// the largest of them. Every other game, and any position that is too large
+// or can repeat, gets an estimate from a Monte Carlo search on one goroutine
+// instead. Each client has at most one hint in flight and waits hintCooldown
+// after it before asking again.
+const (
+	hintMaxPositions = 50_000
+	hintSolveTime    = time.Second
+	hintSearchTime   = 2 * time.Second
+	hintCooldown     = 3 * time.Second
+)
+
+var solvableGames = map[string]bool{
+	"tic-tac-toe":    true,
+	"nim":            true,
+	"domineering":    true,
+	"dots-and-boxes": true,
+	"connect-four":   true,
+}
+
+// handleHintRequest finds a good move in the current position and sends it
+// to the requesting player only. A solved position comes with its value and
+// distance, an estimated one with the expected score of the move. The
+// search runs on a clone outside the room lock so that it never holds up
+// moves, and its result is dropped if the client has disconnected since.
+func (r *Room) handleHintRequest(client *Client) {
+	if client.playerIndex() == -1 {
+		client.sendError("Spectators cannot request hints.")
+		return
+	}
+
+	r.mu.RLock()
+	enabled := r.HintsEnabled
+	gameType := r.GameType
+	snapshot := r.Game.Clone()
+	r.mu.RUnlock()
+
+	if !enabled {
+		client.sendError("Hints are disabled in this room.")
+		return
+	}
+	game, ok := snapshot.(games.MoveGenerator)
+	if !ok {
+		client.sendError("Hints are not available for this game.")
+		return
+	}
+	if message := client.startHint(); message != "" {
+		client.sendError(message)
+		return
+	}
+
+	go func() {
+		defer client.finishHint()
+		defer func() {
+			if p := recover(); p != nil {
+				r.manager.logger.Error("Hint search panicked", "room_id", r.ID, "client_id", client.id, "panic", p)
+				r.manager.sendToRegistered(client, "error", ErrorPayload{Message: "Failed to find a hint."})
+			}
+		}()
+
+		hint, err := findHint(gameType, game)
+		if err != nil {
+			r.manager.sendToRegistered(client, "error", ErrorPayload{Message: err.Error()})
+			return
+		}
+		r.manager.sendToRegistered(client, "hint", withNimSum(hint, game))
+	}()
+}
+
+// findHint solves the position when the game allows it, or estimates a move
+// otherwise.
+func findHint(gameType string, game games.MoveGenerator) (map[string]any, error) {
+	if !solvableGames[gameType] {
+		return estimateHint(game)
+	}
+
+	s := solver.New(hintMaxPositions)
+	s.SetDeadline(time.Now().Add(hintSolveTime))
+	result, err := s.Solve(game)
+	if errors.Is(err, solver.ErrTooLarge) || errors.Is(err, solver.ErrRepetition) {
+		return estimateHint(game)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"player":   game.CurrentPlayer(),
+		"move":     result.Move,
+		"value":    result.Value,
+		"distance": result.Distance,
+	}, nil
+}
+
+func estimateHint(game games.MoveGenerator) (map[string]any, error) {
+	engine, err := mcts.New(mcts.Config{Duration: hintSearchTime, Workers: 1})
+	if err != nil {
+		return nil, err
+	}
+	result, err := engine.Search(game)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"player":   game.CurrentPlayer(),
+		"move":     result.Move,
+		"score":    result.Score,
+		"playouts": result.Playouts,
+	}, nil
+}
+
+// withNimSum adds the nim-sum to hints for Nim, where it explains the move.
+// It is kept out of the shared game state and only reaches players here.
+func withNimSum(hint map[string]any, game games.MoveGenerator) map[string]any {
+	if nim, ok := game.(*games.NimGame); ok {
+		hint["nimSum"] = nim.NimSum()
+	}
+	return hint
+}
@@ -26,6 +26,8 @@ type Manager struct {
 	connectionService service.ConnectionService
 	roomService       service.RoomService
 	playerService     service.PlayerService
+	puzzleService     service.PuzzleService
+	gameRecordService service.GameRecordService
 	upgrader          websocket.Upgrader
 	mu                sync.RWMutex
 	clients           map[uuid.UUID]*Client
@@ -36,13 +38,15 @@ type Manager struct {
 
 type GameInstance = games.Game
 
-func NewManager(cfg *config.Config, cs service.ConnectionService, rs service.RoomService, ps service.PlayerService) (*Manager, error) {
+func NewManager(cfg *config.Config, cs service.ConnectionService, rs service.RoomService, ps service.PlayerService, pzs service.PuzzleService, grs service.GameRecordService) (*Manager, error) {
 	allowedOriginsSlice := strings.Split(cfg.AllowedOrigins, ",")
 	m := &Manager{
 		config:            cfg,
 		connectionService: cs,
 		roomService:       rs,
 		playerService:     ps,
+		puzzleService:     pzs,
+		gameRecordService: grs,
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
@@ -191,6 +195,17 @@ func (m *Manager) unregisterClient(client *Client) {
 	m.broadcastConnections()
 }
 
+// sendToRegistered sends a message to a client only while it is still
+// connected. Holding the lock keeps unregisterClient from closing the send
+// channel in between, so goroutines that outlive a request can reply safely.
+func (m *Manager) sendToRegistered(client *Client, msgType string, payload any) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.clients[client.id] == client {
+		client.sendMessage(msgType, payload)
+	}
+}
+
 func (m *Manager) cleanupConnectionDB(displayName string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -235,15 +250,6 @@ func (m *Manager) cleanupStaleRooms() {
 	}
 }
 
-func (m *Manager) getMaxPlayersForGame(gameType string) int {
-	switch gameType {
-	case "tic-tac-toe":
-		return 2
-	default:
-		return 2
-	}
-}
-
 func generateAliceOrBobName() string {
 	namePrefixes := []string{"Alice", "Bob"}
 	prefix := namePrefixes[rand.Intn(len(namePrefixes))]
@@ -0,0 +1,135 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/puzzles"
+	"github.com/google/uuid"
+)
+
+// roomPuzzle is the puzzle of a puzzle room. Only the first attempt in a
+// room counts towards the puzzle's rating; retries are for practice.
+type roomPuzzle struct {
+	id      uuid.UUID
+	title   string
+	puzzle  puzzles.Puzzle
+	attempt *puzzles.Attempt
+	rated   bool
+}
+
+func (m *Manager) loadPuzzle(puzzleID uuid.UUID) (*roomPuzzle, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stored, err := m.puzzleService.GetPuzzleByID(ctx, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	puzzle := puzzles.Puzzle{
+		GameType:    stored.GameType,
+		GameOptions: stored.GameOptions,
+		Position:    stored.Position,
+	}
+	if err := json.Unmarshal(stored.Solution, &puzzle.Solution); err != nil {
+		return nil, fmt.Errorf("invalid puzzle solution: %w", err)
+	}
+	attempt, err := puzzles.NewAttempt(puzzle)
+	if err != nil {
+		return nil, err
+	}
+	return &roomPuzzle{id: puzzleID, title: stored.Title, puzzle: puzzle, attempt: attempt}, nil
+}
+
+// stateInternal describes the puzzle for the room state. The solution is
+// only shown once the attempt is over. The caller must hold the lock.
+func (p *roomPuzzle) stateInternal() map[string]any {
+	state := map[string]any{
+		"id":     p.id.String(),
+		"title":  p.title,
+		"player": p.attempt.Player(),
+		"status": p.attempt.Status(),
+	}
+	if p.attempt.Status() != puzzles.StatusPlaying {
+		state["solution"] = p.puzzle.Solution
+	}
+	return state
+}
+
+// handlePuzzleMove plays the solver's move and the scripted reply, and
+// records the result once the attempt is over. Choosing between several
+// replies can take the solver up to a second, so the move is played on a
+// copy of the attempt outside the lock. The copy only replaces the room's
+// attempt if nothing else changed it meanwhile.
+func (r *Room) handlePuzzleMove(client *Client, move any) {
+	r.mu.RLock()
+	current := r.puzzle.attempt
+	attempt := current.Clone()
+	r.mu.RUnlock()
+
+	reply, err := attempt.Play(move)
+	if err != nil {
+		client.sendError(err.Error())
+		return
+	}
+
+	r.mu.Lock()
+	if r.puzzle.attempt != current {
+		r.mu.Unlock()
+		client.sendError("The position changed while your move was being played.")
+		return
+	}
+	r.puzzle.attempt = attempt
+	r.Game = attempt.Game()
+	status := attempt.Status()
+	rate := status != puzzles.StatusPlaying && !r.puzzle.rated
+	if rate {
+		r.puzzle.rated = true
+	}
+	puzzleID := r.puzzle.id
+	r.mu.Unlock()
+
+	if reply != "" {
+		r.broadcastMessage("puzzle_reply", map[string]any{"move": reply})
+	}
+
+	if status != puzzles.StatusPlaying {
+		result := map[string]any{"status": status, "rated": rate}
+		if rate {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			stored, err := r.manager.puzzleService.RecordAttempt(ctx, puzzleID, status == puzzles.StatusSolved)
+			if err != nil {
+				r.manager.logger.Error("Failed to record puzzle attempt", "puzzle_id", puzzleID, "room_id", r.ID, "error", err)
+			} else {
+				result["rating"] = stored.Rating
+			}
+		}
+		r.broadcastMessage("puzzle_result", result)
+	}
+
+	r.broadcastRoomState()
+}
+
+// retryPuzzle starts a new attempt once the current one is over.
+func (r *Room) retryPuzzle(client *Client) {
+	r.mu.Lock()
+	if r.puzzle.attempt.Status() == puzzles.StatusPlaying {
+		r.mu.Unlock()
+		client.sendError("The puzzle is not over yet.")
+		return
+	}
+	attempt, err := puzzles.NewAttempt(r.puzzle.puzzle)
+	if err != nil {
+		r.mu.Unlock()
+		client.sendError(err.Error())
+		return
+	}
+	r.puzzle.attempt = attempt
+	r.Game = attempt.Game()
+	r.mu.Unlock()
+
+	r.broadcastRoomState()
+}
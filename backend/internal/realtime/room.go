@@ -2,10 +2,14 @@ package realtime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+	"github.com/DCCXXV/twoplayers/backend/internal/review"
 	"github.com/DCCXXV/twoplayers/backend/internal/service"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -16,10 +20,19 @@ type Room struct {
 	ID              uuid.UUID
 	GameType        string
 	HostName        string
+	Mode            string
 	Game            GameInstance
+	Analysis        *games.Analysis
+	HintsEnabled    bool
+	GameOptions     json.RawMessage
+	StartPosition   string
+	moves           []review.Move
+	turnStartedAt   time.Time
+	puzzle          *roomPuzzle
 	manager         *Manager
 	mu              sync.RWMutex
 	Clients         map[uuid.UUID]*Client
+	MinPlayers      int
 	MaxPlayers      int
 	rematchRequests map[uuid.UUID]bool
 }
@@ -102,10 +115,14 @@ func (r *Room) addClient(client *Client) {
 		client.role = "player_0"
 		playerOrder = 0
 		isPlayer = true
-	} else if playerCount == 1 {
+	} else if playerCount == 1 && r.MaxPlayers > 1 {
 		client.role = "player_1"
 		playerOrder = 1
 		isPlayer = true
+		// The first move is timed from when there is someone to play.
+		if len(r.moves) == 0 {
+			r.turnStartedAt = time.Now()
+		}
 	} else {
 		client.role = "spectator"
 	}
@@ -210,6 +227,41 @@ func (r *Room) removeClient(client *Client) bool {
 	}
 }
 
+// restartGameInternal sets up a new game from the room's starting position
+// for a rematch. The caller must hold the lock.
+func (r *Room) restartGameInternal() {
+	r.moves = nil
+	r.turnStartedAt = time.Now()
+	game, err := games.NewGameAtPosition(r.GameType, r.GameOptions, r.StartPosition)
+	if err != nil {
+		// The position was checked when the room was created, so this only
+		// happens if the rules have changed since.
+		r.manager.logger.Error("Failed to restart game from its starting position", "room_id", r.ID, "error", err)
+		r.Game.Reset()
+		return
+	}
+	r.Game = game
+}
+
+// positionInternal writes the game so far in its notation: the starting
+// position followed by the moves played in the room. It is empty for games
+// without a notation and for analysis rooms, whose moves live in the tree.
+// The caller must hold the lock.
+func (r *Room) positionInternal() string {
+	if r.Analysis != nil {
+		return ""
+	}
+	if _, ok := r.Game.(games.Notation); !ok {
+		return ""
+	}
+	played, err := games.FormatMoveList(r.Game, playedMoves(r.moves))
+	if err != nil {
+		r.manager.logger.Error("Failed to write moves in notation", "room_id", r.ID, "error", err)
+		return ""
+	}
+	return strings.TrimSpace(r.StartPosition + " " + played)
+}
+
 func (r *Room) broadcastRoomState() {
 	r.mu.RLock()
 	players := r.getPlayersInternal()
@@ -225,24 +277,74 @@ func (r *Room) broadcastRoomState() {
 		spectatorNames[i] = s.displayName
 	}
 
-	gameState := r.Game.GetGameState()
+	// Encode a private copy of the game so that serializing it later can
+	// never race with a move being applied to the live one.
+	snapshot := r.Game.Clone()
+	gameState := snapshot.GetGameState()
 	rematchCount := len(r.rematchRequests)
+	position := r.positionInternal()
+
+	var puzzleState map[string]any
+	if r.puzzle != nil {
+		puzzleState = r.puzzle.stateInternal()
+	}
+
+	var analysisState json.RawMessage
+	if r.Analysis != nil {
+		var err error
+		if analysisState, err = json.Marshal(r.Analysis); err != nil {
+			r.manager.logger.Error("Failed to marshal analysis", "room_id", r.ID, "error", err)
+		}
+	}
+
+	// Games with hidden information get a separate view per client. An
+	// analysis shows everything, since nothing in it is played for real.
+	var clientStates map[*Client]any
+	if hidden, ok := snapshot.(games.HiddenInformationGame); ok && r.Analysis == nil {
+		clientStates = make(map[*Client]any, len(r.Clients))
+		for _, client := range r.Clients {
+			clientStates[client] = hidden.GetPlayerState(client.playerIndex())
+		}
+	}
 	r.mu.RUnlock()
 
 	roomState := map[string]any{
 		"roomId":         r.ID.String(),
 		"gameType":       r.GameType,
+		"mode":           r.Mode,
+		"hintsEnabled":   r.HintsEnabled,
 		"players":        playerNames,
 		"spectators":     spectatorNames,
 		"playerCount":    len(players),
 		"spectatorCount": len(spectators),
+		"minPlayers":     r.MinPlayers,
 		"maxPlayers":     r.MaxPlayers,
-		"canStart":       len(players) == r.MaxPlayers,
+		"canStart":       len(players) >= r.MinPlayers,
 		"game":           gameState,
 		"rematchCount":   rematchCount,
+		"startPosition":  r.StartPosition,
+		"position":       position,
+	}
+	if analysisState != nil {
+		roomState["analysis"] = analysisState
+	}
+	if puzzleState != nil {
+		roomState["puzzle"] = puzzleState
+	}
+
+	if clientStates == nil {
+		r.broadcastMessage("game_state_update", roomState)
+		return
 	}
 
-	r.broadcastMessage("game_state_update", roomState)
+	for client, state := range clientStates {
+		clientRoomState := make(map[string]any, len(roomState))
+		for k, v := range roomState {
+			clientRoomState[k] = v
+		}
+		clientRoomState["game"] = state
+		client.sendMessage("game_state_update", clientRoomState)
+	}
 }
 
 func (r *Room) broadcastMessage(msgType string, payload any) {
@@ -0,0 +1,83 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+	"github.com/DCCXXV/twoplayers/backend/internal/review"
+	"github.com/DCCXXV/twoplayers/backend/internal/service"
+)
+
+// finishedGame is what the post-game summary needs from a room, copied when
+// the game ends so that a rematch can start while the summary is worked out.
+type finishedGame struct {
+	moves   []review.Move
+	players [2]string
+}
+
+// finishedGameInternal copies the game that has just ended. The caller must
+// hold the lock.
+func (r *Room) finishedGameInternal() *finishedGame {
+	finished := &finishedGame{moves: append([]review.Move(nil), r.moves...)}
+	for _, client := range r.getPlayersInternal() {
+		finished.players[client.playerIndex()] = client.displayName
+	}
+	return finished
+}
+
+// playedMoves strips the timings from the moves played in a room.
+func playedMoves(moves []review.Move) []any {
+	played := make([]any, len(moves))
+	for i, m := range moves {
+		played[i] = m.Move
+	}
+	return played
+}
+
+// recordGame works out the post-game summary, stores it with the game and
+// sends it to the room. Classifying the moves can take the solver a few
+// seconds, so it runs on its own goroutine without the room lock.
+func (r *Room) recordGame(finished *finishedGame) {
+	start, err := games.NewGameAtPosition(r.GameType, r.GameOptions, r.StartPosition)
+	if err != nil {
+		r.manager.logger.Error("Failed to set up game for its summary", "room_id", r.ID, "error", err)
+		return
+	}
+	summary, err := review.Summarize(start, finished.moves)
+	if err != nil {
+		r.manager.logger.Error("Failed to summarize game", "room_id", r.ID, "error", err)
+		return
+	}
+
+	var moves string
+	if _, ok := start.(games.Notation); ok {
+		if moves, err = games.FormatMoveList(start, playedMoves(finished.moves)); err != nil {
+			r.manager.logger.Error("Failed to write moves in notation", "room_id", r.ID, "error", err)
+		}
+	}
+
+	payload := map[string]any{
+		"players": finished.players,
+		"summary": summary,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	record, err := r.manager.gameRecordService.CreateGameRecord(ctx, service.CreateGameRecordParams{
+		RoomID:        r.ID,
+		GameType:      r.GameType,
+		GameOptions:   r.GameOptions,
+		StartPosition: r.StartPosition,
+		Moves:         moves,
+		Players:       finished.players,
+		Summary:       summary,
+	})
+	if err != nil {
+		r.manager.logger.Error("Failed to store game record", "room_id", r.ID, "error", err)
+	} else {
+		payload["recordId"] = record.ID.String()
+	}
+
+	r.broadcastMessage("game_summary", payload)
+}
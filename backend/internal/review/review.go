@@ -0,0 +1,205 @@
+// Package review summarises finished games: how many moves were played, how
+// long each player thought and, where the solver can settle the positions,
+// how good each move was.
+package review
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+	"github.com/DCCXXV/twoplayers/backend/internal/solver"
+)
+
+// The solver shares one table and one deadline across the whole game. It
+// starts from the end, where positions are smallest, so a long game still
+// gets its closing moves classified when the opening is out of reach.
+const (
+	reviewMaxPositions = 300_000
+	reviewSolveTime    = 5 * time.Second
+)
+
+// Quality classifies a move by what it did to the mover's value under
+// perfect play. It is empty when the position was too large to solve.
+type Quality string
+
+const (
+	// QualityBest keeps the value by the quickest win or the longest defence.
+	QualityBest Quality = "best"
+	// QualityGood keeps the value, but more slowly than it could.
+	QualityGood Quality = "good"
+	// QualityMistake gives away half a point: a win for a draw or a draw
+	// for a loss.
+	QualityMistake Quality = "mistake"
+	// QualityBlunder turns a win into a loss.
+	QualityBlunder Quality = "blunder"
+)
+
+// Move is a move as it was played, with the time the player took over it.
+type Move struct {
+	Player int
+	Move   any
+	Think  time.Duration
+}
+
+type MoveReview struct {
+	Player   int     `json:"player"`
+	Notation string  `json:"notation,omitempty"`
+	ThinkMs  int64   `json:"thinkMs"`
+	Quality  Quality `json:"quality,omitempty"`
+}
+
+// PlayerStats sums up one player's moves. Accuracy is the percentage of
+// classified moves that kept the value, and is left out when none were.
+type PlayerStats struct {
+	Moves          int      `json:"moves"`
+	TimeUsedMs     int64    `json:"timeUsedMs"`
+	LongestThinkMs int64    `json:"longestThinkMs"`
+	Accuracy       *float64 `json:"accuracy,omitempty"`
+	Best           int      `json:"best"`
+	Good           int      `json:"good"`
+	Mistakes       int      `json:"mistakes"`
+	Blunders       int      `json:"blunders"`
+}
+
+// Summary is the post-game summary. Evaluated reports whether the game has
+// an evaluator at all; individual moves may still be unclassified.
+type Summary struct {
+	MoveCount int            `json:"moveCount"`
+	Winner    string         `json:"winner"`
+	Evaluated bool           `json:"evaluated"`
+	Players   [2]PlayerStats `json:"players"`
+	Moves     []MoveReview   `json:"moves"`
+}
+
+// Summarize replays the moves from the starting position, which is left
+// untouched, and sums them up.
+func Summarize(start games.Game, moves []Move) (Summary, error) {
+	positions := make([]games.Game, 0, len(moves)+1)
+	game := start.Clone()
+	positions = append(positions, game.Clone())
+
+	notation, _ := start.(games.Notation)
+	summary := Summary{
+		MoveCount: len(moves),
+		Moves:     make([]MoveReview, len(moves)),
+	}
+	for i, m := range moves {
+		if m.Player < 0 || m.Player > 1 {
+			return Summary{}, fmt.Errorf("move %d: invalid player %d", i+1, m.Player)
+		}
+		if err := game.HandleMove(m.Player, m.Move); err != nil {
+			return Summary{}, fmt.Errorf("move %d: %w", i+1, err)
+		}
+		positions = append(positions, game.Clone())
+
+		think := m.Think.Milliseconds()
+		summary.Moves[i] = MoveReview{Player: m.Player, ThinkMs: think}
+		if notation != nil {
+			summary.Moves[i].Notation, _ = notation.FormatMove(m.Move)
+		}
+
+		stats := &summary.Players[m.Player]
+		stats.Moves++
+		stats.TimeUsedMs += think
+		stats.LongestThinkMs = max(stats.LongestThinkMs, think)
+	}
+	summary.Winner = game.GetWinner()
+
+	if _, ok := start.(games.MoveGenerator); ok {
+		summary.Evaluated = true
+		classify(&summary, positions)
+	}
+	return summary, nil
+}
+
+// classify fills in the quality of each move and the players' tallies.
+func classify(summary *Summary, positions []games.Game) {
+	s := solver.New(reviewMaxPositions)
+	s.SetDeadline(time.Now().Add(reviewSolveTime))
+
+	values := make([]*outcome, len(positions))
+	for i := len(positions) - 1; i >= 0; i-- {
+		values[i] = evaluate(s, positions[i].(games.MoveGenerator))
+	}
+
+	for i := range summary.Moves {
+		before, after := values[i], values[i+1]
+		if before == nil || after == nil {
+			continue
+		}
+		move := &summary.Moves[i]
+		move.Quality = quality(before.forPlayer(move.Player), after.forPlayer(move.Player))
+
+		stats := &summary.Players[move.Player]
+		switch move.Quality {
+		case QualityBest:
+			stats.Best++
+		case QualityGood:
+			stats.Good++
+		case QualityMistake:
+			stats.Mistakes++
+		case QualityBlunder:
+			stats.Blunders++
+		}
+	}
+
+	for i := range summary.Players {
+		stats := &summary.Players[i]
+		if classified := stats.Best + stats.Good + stats.Mistakes + stats.Blunders; classified > 0 {
+			accuracy := float64(stats.Best+stats.Good) * 100 / float64(classified)
+			stats.Accuracy = &accuracy
+		}
+	}
+}
+
+// outcome is the solved value of a position for the given player.
+type outcome struct {
+	player   int
+	value    solver.Value
+	distance int
+}
+
+func (o outcome) forPlayer(player int) outcome {
+	if player != o.player {
+		o.player, o.value = player, -o.value
+	}
+	return o
+}
+
+// evaluate solves a position, or returns nil when it is too large.
+func evaluate(s *solver.Solver, game games.MoveGenerator) *outcome {
+	if game.IsGameOver() {
+		value := solver.Loss
+		switch game.GetWinner() {
+		case "draw":
+			value = solver.Draw
+		case game.PlayerSymbol(0):
+			value = solver.Win
+		}
+		return &outcome{player: 0, value: value}
+	}
+
+	// Running out of room or time leaves the moves around the position
+	// unclassified rather than failing the summary.
+	result, err := s.Solve(game)
+	if err != nil {
+		return nil
+	}
+	return &outcome{player: game.CurrentPlayer(), value: result.Value, distance: result.Distance}
+}
+
+// quality compares the mover's value before and after a move.
+func quality(before, after outcome) Quality {
+	switch lost := before.value - after.value; {
+	case lost <= 0:
+		if before.value != solver.Draw && after.distance != before.distance-1 {
+			return QualityGood
+		}
+		return QualityBest
+	case lost == 1:
+		return QualityMistake
+	default:
+		return QualityBlunder
+	}
+}
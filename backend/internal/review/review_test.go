@@ -0,0 +1,116 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+// playedMoves turns a move list in the game's notation into moves by
+// alternating players, each thought over for the given number of seconds.
+func playedMoves(t *testing.T, game games.Game, text string, seconds ...int) []Move {
+	t.Helper()
+	parsed, err := games.ParseMoveList(game, text)
+	if err != nil {
+		t.Fatalf("Expected no error parsing %q, but got %v", text, err)
+	}
+	moves := make([]Move, len(parsed))
+	for i, move := range parsed {
+		moves[i] = Move{Player: i % 2, Move: move}
+		if i < len(seconds) {
+			moves[i].Think = time.Duration(seconds[i]) * time.Second
+		}
+	}
+	return moves
+}
+
+func TestSummarize_ClassifiesMoves(t *testing.T) {
+	game, _ := games.NewGame("tic-tac-toe", nil)
+
+	// O's edge reply lets X fork, and X then lets the win slip by ignoring
+	// O's two in a row.
+	summary, err := Summarize(game, playedMoves(t, game, "5 1 9 2 4 3"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if !summary.Evaluated {
+		t.Fatal("Expected tic-tac-toe moves to be evaluated")
+	}
+	if summary.MoveCount != 6 || summary.Winner != "O" {
+		t.Errorf("Expected 6 moves and a win for O, but got %d moves and winner %q", summary.MoveCount, summary.Winner)
+	}
+
+	want := []Quality{QualityBest, QualityBest, QualityBest, QualityMistake, QualityBlunder, QualityBest}
+	for i, move := range summary.Moves {
+		if move.Quality != want[i] {
+			t.Errorf("Expected move %d (%s) to be %s, but got %q", i+1, move.Notation, want[i], move.Quality)
+		}
+	}
+
+	x, o := summary.Players[0], summary.Players[1]
+	if x.Best != 2 || x.Blunders != 1 || o.Best != 2 || o.Mistakes != 1 {
+		t.Errorf("Expected X with 2 best and 1 blunder and O with 2 best and 1 mistake, but got %+v and %+v", x, o)
+	}
+	if x.Accuracy == nil || int(*x.Accuracy) != 66 {
+		t.Errorf("Expected X to be 66%% accurate, but got %v", x.Accuracy)
+	}
+}
+
+func TestSummarize_SlowWinIsGood(t *testing.T) {
+	game, _ := games.NewGame("tic-tac-toe", nil)
+
+	// X can win at once on 9, but forks on 7 instead and wins a move later.
+	summary, err := Summarize(game, playedMoves(t, game, "5 2 1 3 7 9 4"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if quality := summary.Moves[4].Quality; quality != QualityGood {
+		t.Errorf("Expected the slower win to be good, but got %q", quality)
+	}
+}
+
+func TestSummarize_ThinkTimes(t *testing.T) {
+	game, _ := games.NewGame("tic-tac-toe", nil)
+
+	summary, err := Summarize(game, playedMoves(t, game, "5 1 3 7", 2, 10, 7, 1))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	first, second := summary.Players[0], summary.Players[1]
+	if first.Moves != 2 || first.TimeUsedMs != 9000 || first.LongestThinkMs != 7000 {
+		t.Errorf("Expected 2 moves in 9s with the longest taking 7s, but got %+v", first)
+	}
+	if second.Moves != 2 || second.TimeUsedMs != 11000 || second.LongestThinkMs != 10000 {
+		t.Errorf("Expected 2 moves in 11s with the longest taking 10s, but got %+v", second)
+	}
+	if summary.Moves[2].Notation != "3" || summary.Moves[2].ThinkMs != 7000 {
+		t.Errorf("Expected the third move written as 3 after 7s, but got %+v", summary.Moves[2])
+	}
+}
+
+func TestSummarize_WithoutEvaluator(t *testing.T) {
+	game, _ := games.NewGame("battleship", nil)
+
+	summary, err := Summarize(game, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if summary.Evaluated || summary.Players[0].Accuracy != nil {
+		t.Errorf("Expected no evaluation for battleship, but got %+v", summary)
+	}
+}
+
+func TestSummarize_RejectsIllegalMoves(t *testing.T) {
+	game, _ := games.NewGame("tic-tac-toe", nil)
+	moves := playedMoves(t, game, "5 5")
+
+	if _, err := Summarize(game, moves); err == nil {
+		t.Error("Expected an error for playing an occupied cell, but got nil")
+	}
+	if board := game.(*games.TicTacToe).Board; board != [9]string{} {
+		t.Errorf("Expected the starting position to be left untouched, but got %v", board)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	db "github.com/DCCXXV/twoplayers/backend/db/sqlc"
+	"github.com/DCCXXV/twoplayers/backend/internal/review"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrGameRecordNotFound = errors.New("game record not found")
+
+type GameRecordService interface {
+	CreateGameRecord(ctx context.Context, params CreateGameRecordParams) (db.GameRecord, error)
+	GetGameRecordByID(ctx context.Context, recordID uuid.UUID) (db.GameRecord, error)
+	ListGameRecordsByRoomID(ctx context.Context, roomID uuid.UUID) ([]db.GameRecord, error)
+}
+
+// CreateGameRecordParams describes a finished game. Moves are written in
+// the game's notation, and are empty for games without one.
+type CreateGameRecordParams struct {
+	RoomID        uuid.UUID
+	GameType      string
+	GameOptions   []byte
+	StartPosition string
+	Moves         string
+	Players       [2]string
+	Summary       review.Summary
+}
+
+type gameRecordService struct {
+	queries db.Querier
+}
+
+func NewGameRecordService(queries db.Querier) GameRecordService {
+	return &gameRecordService{queries: queries}
+}
+
+func (s *gameRecordService) CreateGameRecord(ctx context.Context, params CreateGameRecordParams) (db.GameRecord, error) {
+	summary, err := json.Marshal(params.Summary)
+	if err != nil {
+		return db.GameRecord{}, err
+	}
+	return s.queries.CreateGameRecord(ctx, db.CreateGameRecordParams{
+		RoomID:        pgtype.UUID{Bytes: params.RoomID, Valid: true},
+		GameType:      params.GameType,
+		GameOptions:   params.GameOptions,
+		StartPosition: params.StartPosition,
+		Moves:         params.Moves,
+		Player0:       params.Players[0],
+		Player1:       params.Players[1],
+		Winner:        params.Summary.Winner,
+		Summary:       summary,
+	})
+}
+
+func (s *gameRecordService) GetGameRecordByID(ctx context.Context, recordID uuid.UUID) (db.GameRecord, error) {
+	record, err := s.queries.GetGameRecordByID(ctx, pgtype.UUID{Bytes: recordID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.GameRecord{}, ErrGameRecordNotFound
+		}
+		return db.GameRecord{}, err
+	}
+	return record, nil
+}
+
+func (s *gameRecordService) ListGameRecordsByRoomID(ctx context.Context, roomID uuid.UUID) ([]db.GameRecord, error) {
+	return s.queries.ListGameRecordsByRoomID(ctx, pgtype.UUID{Bytes: roomID, Valid: true})
+}
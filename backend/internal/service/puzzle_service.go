@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	db "github.com/DCCXXV/twoplayers/backend/db/sqlc"
+	"github.com/DCCXXV/twoplayers/backend/internal/puzzles"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrPuzzleNotFound = errors.New("puzzle not found")
+
+type PuzzleService interface {
+	SeedCuratedPuzzles(ctx context.Context) error
+	GetPuzzleByID(ctx context.Context, puzzleID uuid.UUID) (db.Puzzle, error)
+	ListPuzzles(ctx context.Context, gameType string, limit, offset int32) ([]db.Puzzle, error)
+	RecordAttempt(ctx context.Context, puzzleID uuid.UUID, solved bool) (db.Puzzle, error)
+}
+
+type puzzleService struct {
+	queries db.Querier
+}
+
+func NewPuzzleService(queries db.Querier) PuzzleService {
+	return &puzzleService{queries: queries}
+}
+
+// SeedCuratedPuzzles stores the puzzles shipped with the server, updating
+// any that were stored by an earlier version.
+func (s *puzzleService) SeedCuratedPuzzles(ctx context.Context) error {
+	curated, err := puzzles.Curated()
+	if err != nil {
+		return err
+	}
+	for _, puzzle := range curated {
+		if err := puzzle.Validate(); err != nil {
+			return fmt.Errorf("curated puzzle %s: %w", puzzle.Slug, err)
+		}
+		solution, err := json.Marshal(puzzle.Solution)
+		if err != nil {
+			return err
+		}
+		_, err = s.queries.UpsertPuzzle(ctx, db.UpsertPuzzleParams{
+			Slug:        puzzle.Slug,
+			Title:       puzzle.Title,
+			GameType:    puzzle.GameType,
+			GameOptions: puzzle.GameOptions,
+			Position:    puzzle.Position,
+			Solution:    solution,
+		})
+		if err != nil {
+			return fmt.Errorf("could not store puzzle %s: %w", puzzle.Slug, err)
+		}
+	}
+	return nil
+}
+
+func (s *puzzleService) GetPuzzleByID(ctx context.Context, puzzleID uuid.UUID) (db.Puzzle, error) {
+	puzzle, err := s.queries.GetPuzzleByID(ctx, pgtype.UUID{Bytes: puzzleID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Puzzle{}, ErrPuzzleNotFound
+		}
+		return db.Puzzle{}, err
+	}
+	return puzzle, nil
+}
+
+// ListPuzzles lists puzzles from the easiest up, of every game when
+// gameType is empty.
+func (s *puzzleService) ListPuzzles(ctx context.Context, gameType string, limit, offset int32) ([]db.Puzzle, error) {
+	if gameType == "" {
+		return s.queries.ListPuzzles(ctx, db.ListPuzzlesParams{Limit: limit, Offset: offset})
+	}
+	return s.queries.ListPuzzlesByGameType(ctx, db.ListPuzzlesByGameTypeParams{
+		GameType: gameType,
+		Limit:    limit,
+		Offset:   offset,
+	})
+}
+
+// RecordAttempt counts a finished attempt at a puzzle and updates its
+// rating.
+func (s *puzzleService) RecordAttempt(ctx context.Context, puzzleID uuid.UUID, solved bool) (db.Puzzle, error) {
+	puzzle, err := s.GetPuzzleByID(ctx, puzzleID)
+	if err != nil {
+		return db.Puzzle{}, err
+	}
+	return s.queries.RecordPuzzleAttempt(ctx, db.RecordPuzzleAttemptParams{
+		RatingChange: int32(puzzles.RatingChange(int(puzzle.Rating), solved)),
+		Solved:       solved,
+		ID:           puzzle.ID,
+	})
+}
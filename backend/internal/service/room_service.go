@@ -15,6 +15,15 @@ import (
 
 var ErrRoomNotFound = errors.New("room not found")
 
+// Rooms are either played for real or used to analyse positions, in which
+// case either participant may move for both sides. A puzzle room has a
+// single player, who plays against the replies of a puzzle's solution.
+const (
+	RoomModePlay     = "play"
+	RoomModeAnalysis = "analysis"
+	RoomModePuzzle   = "puzzle"
+)
+
 type JoinRoomInput struct {
 	RoomID      uuid.UUID
 	DisplayName string
@@ -41,6 +50,10 @@ type CreateRoomParams struct {
 	HostDisplayName string
 	IsPrivate       bool
 	GameOptions     []byte
+	Mode            string
+	HintsEnabled    bool
+	StartPosition   string
+	PuzzleID        uuid.UUID
 }
 
 type roomService struct {
@@ -62,6 +75,10 @@ func (s *roomService) CreateRoom(ctx context.Context, params CreateRoomParams) (
 		HostDisplayName: params.HostDisplayName,
 		IsPrivate:       params.IsPrivate,
 		GameOptions:     params.GameOptions,
+		Mode:            params.Mode,
+		HintsEnabled:    params.HintsEnabled,
+		StartPosition:   params.StartPosition,
+		PuzzleID:        pgtype.UUID{Bytes: params.PuzzleID, Valid: params.PuzzleID != uuid.Nil},
 	})
 }
 
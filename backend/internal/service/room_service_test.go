@@ -0,0 +1,45 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// modeCheck matches the check on the mode column in the migrations, either
+// inline with the column or as the rooms_mode_check constraint.
+var modeCheck = regexp.MustCompile(`CHECK \(mode IN \(([^)]*)\)\)`)
+
+// TestRoomModes_AllowedBySchema replays the up migrations in order and
+// checks that the last constraint on the mode column accepts every room
+// mode CreateRoom can insert.
+func TestRoomModes_AllowedBySchema(t *testing.T) {
+	migrations, err := filepath.Glob("../../db/migrate/*.up.sql")
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("Expected to find the migrations, but got %v", err)
+	}
+	slices.Sort(migrations)
+
+	var allowed []string
+	for _, path := range migrations {
+		sql, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected to read %s, but got %v", path, err)
+		}
+		for _, match := range modeCheck.FindAllStringSubmatch(string(sql), -1) {
+			allowed = nil
+			for _, value := range strings.Split(match[1], ",") {
+				allowed = append(allowed, strings.Trim(strings.TrimSpace(value), "'"))
+			}
+		}
+	}
+
+	for _, mode := range []string{RoomModePlay, RoomModeAnalysis, RoomModePuzzle} {
+		if !slices.Contains(allowed, mode) {
+			t.Errorf("Expected the rooms table to accept mode %q, but it allows only %v", mode, allowed)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Package solver computes game-theoretic values by exhaustive search. It
+// works with any game that implements games.MoveGenerator and is meant for
+// games small enough to search to the end, such as tic-tac-toe, small Nim
+// heaps and small Domineering boards. Positions are memoised in a
+// transposition table keyed by games.MoveGenerator.PositionKey.
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+// ErrTooLarge is returned when a search would need to visit more positions
+// than the solver was allowed, go deeper than maxDepth, or take more time
+// than it was given.
+var ErrTooLarge = errors.New("position is too large to solve")
+
+// ErrRepetition is returned when a line of play reaches a position that is
+// already on the path being searched. The value of such a position depends
+// on how it was reached, so it cannot be stored in the table.
+var ErrRepetition = errors.New("position can repeat and cannot be solved")
+
+// maxDepth bounds the recursion. The games the solver is meant for end well
+// before it.
+const maxDepth = 200
+
+// Value is the outcome of a position for the player to move under perfect
+// play by both sides.
+type Value int
+
+const (
+	Loss Value = -1
+	Draw Value = 0
+	Win  Value = 1
+)
+
+func (v Value) String() string {
+	switch v {
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	default:
+		return "draw"
+	}
+}
+
+func (v Value) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// Result is the solution of a position. Distance counts the plies until
+// the game ends: the winner takes the shortest route and the loser the
+// longest.
+type Result struct {
+	Value    Value `json:"value"`
+	Distance int   `json:"distance"`
+	Move     any   `json:"move"`
+}
+
+type entry struct {
+	value    Value
+	distance int
+	move     int
+}
+
+// Solver keeps its transposition table between calls, so solving several
+// positions of the same game reuses earlier work.
+type Solver struct {
+	maxPositions int
+	deadline     time.Time
+	table        map[string]entry
+	path         map[string]bool
+	visited      int
+}
+
+// New returns a solver that visits at most maxPositions positions in each
+// call to Solve, so the table never grows by more than that either.
+func New(maxPositions int) *Solver {
+	return &Solver{maxPositions: maxPositions, table: make(map[string]entry)}
+}
+
+// SetDeadline makes searches give up with ErrTooLarge once the deadline has
+// passed. Positions solved so far stay in the table.
+func (s *Solver) SetDeadline(deadline time.Time) {
+	s.deadline = deadline
+}
+
+// Positions returns the number of positions in the transposition table.
+func (s *Solver) Positions() int {
+	return len(s.table)
+}
+
+// Solve returns the value of the position for the player to move and a
+// move that achieves it. The game itself is never changed.
+func (s *Solver) Solve(game games.MoveGenerator) (Result, error) {
+	if game.IsGameOver() {
+		return Result{}, fmt.Errorf("game is already over")
+	}
+
+	s.visited = 0
+	s.path = make(map[string]bool)
+	best, err := s.search(game, 0)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Value:    best.value,
+		Distance: best.distance,
+		Move:     game.LegalMoves()[best.move],
+	}, nil
+}
+
+func (s *Solver) search(game games.MoveGenerator, depth int) (entry, error) {
+	key := game.PositionKey()
+	if cached, ok := s.table[key]; ok {
+		return cached, nil
+	}
+	if s.path[key] {
+		return entry{}, ErrRepetition
+	}
+	if s.visited >= s.maxPositions || depth >= maxDepth {
+		return entry{}, ErrTooLarge
+	}
+	if !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		return entry{}, ErrTooLarge
+	}
+
+	player := game.CurrentPlayer()
+	moves := game.LegalMoves()
+	if len(moves) == 0 {
+		return entry{}, fmt.Errorf("no legal moves in an unfinished game")
+	}
+	s.visited++
+	s.path[key] = true
+	defer delete(s.path, key)
+
+	best := entry{value: Loss - 1}
+	for i, move := range moves {
+		child := game.Clone().(games.MoveGenerator)
+		if err := child.HandleMove(player, move); err != nil {
+			return entry{}, fmt.Errorf("legal move was rejected: %w", err)
+		}
+
+		var outcome entry
+		if child.IsGameOver() {
+			outcome = entry{value: finalValue(child, player), distance: 1}
+		} else {
+			next, err := s.search(child, depth+1)
+			if err != nil {
+				return entry{}, err
+			}
+			outcome = entry{value: next.value, distance: next.distance + 1}
+			// Some games let a player move again, so the value only flips
+			// when the turn passes.
+			if child.CurrentPlayer() != player {
+				outcome.value = -outcome.value
+			}
+		}
+
+		if better(outcome, best) {
+			best = outcome
+			best.move = i
+		}
+		if best.value == Win && best.distance == 1 {
+			break
+		}
+	}
+
+	s.table[key] = best
+	return best, nil
+}
+
+func finalValue(game games.MoveGenerator, player int) Value {
+	switch game.GetWinner() {
+	case "draw":
+		return Draw
+	case game.PlayerSymbol(player):
+		return Win
+	default:
+		return Loss
+	}
+}
+
+// better prefers higher values, then quicker wins and slower losses.
+func better(a, b entry) bool {
+	if a.value != b.value {
+		return a.value > b.value
+	}
+	switch a.value {
+	case Win:
+		return a.distance < b.distance
+	case Loss:
+		return a.distance > b.distance
+	default:
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DCCXXV/twoplayers/backend/internal/games"
+)
+
+func newMoveGenerator(t *testing.T, gameType, options string) games.MoveGenerator {
+	t.Helper()
+	var raw json.RawMessage
+	if options != "" {
+		raw = json.RawMessage(options)
+	}
+	game, err := games.NewGame(gameType, raw)
+	if err != nil {
+		t.Fatalf("Expected no error creating %s, but got %v", gameType, err)
+	}
+	return game.(games.MoveGenerator)
+}
+
+func TestSolve_TicTacToeIsADraw(t *testing.T) {
+	game := newMoveGenerator(t, "tic-tac-toe", "")
+
+	result, err := New(100_000).Solve(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Value != Draw || result.Distance != 9 {
+		t.Errorf("Expected a draw after 9 plies, but got %s after %d", result.Value, result.Distance)
+	}
+	if game.PositionKey() != ".........0" {
+		t.Error("Expected solving to leave the game untouched")
+	}
+}
+
+func TestSolve_TicTacToeTakesTheQuickestWin(t *testing.T) {
+	game := newMoveGenerator(t, "tic-tac-toe", "")
+	position := `{"board": ["X", "X", "", "O", "O", "", "", "", ""], "currentTurn": 0}`
+	if err := games.SetPosition(game, json.RawMessage(position)); err != nil {
+		t.Fatalf("Expected no error setting up the position, but got %v", err)
+	}
+
+	result, err := New(100_000).Solve(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Value != Win || result.Distance != 1 {
+		t.Fatalf("Expected a win in 1, but got %s in %d", result.Value, result.Distance)
+	}
+	if cell := result.Move.(map[string]any)["cellIndex"]; cell != float64(2) {
+		t.Errorf("Expected X to complete the top row at cell 2, but got %v", cell)
+	}
+}
+
+func TestSolve_NimAgreesWithGrundyAnalysis(t *testing.T) {
+	tests := []string{
+		`{"heaps": [21]}`,
+		`{"heaps": [20]}`,
+		`{"heaps": [3, 4, 5], "subtraction": [], "misere": false}`,
+		`{"heaps": [1, 1, 1], "subtraction": [], "misere": true}`,
+		`{"heaps": [2, 5, 7], "subtraction": [1, 3, 4], "misere": true}`,
+	}
+
+	for _, options := range tests {
+		t.Run(options, func(t *testing.T) {
+			game := newMoveGenerator(t, "nim", options)
+			result, err := New(1_000_000).Solve(game)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			_, _, winning := game.(*games.NimGame).BestMove()
+			if (result.Value == Win) != winning {
+				t.Errorf("Expected the solver to agree with the analysis (winning %v), but got %s", winning, result.Value)
+			}
+		})
+	}
+}
+
+func TestSolve_SmallDomineering(t *testing.T) {
+	// On 2x2 the first horizontal domino leaves no room for a vertical one.
+	game := newMoveGenerator(t, "domineering", `{"rows": 2, "cols": 2}`)
+	result, err := New(1000).Solve(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Value != Win || result.Distance != 1 {
+		t.Errorf("Expected H to win at once on 2x2, but got %s in %d", result.Value, result.Distance)
+	}
+
+	// 4x4 Domineering is a first-player win.
+	game = newMoveGenerator(t, "domineering", `{"rows": 4, "cols": 4}`)
+	result, err = New(1_000_000).Solve(game)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if result.Value != Win {
+		t.Errorf("Expected the first player to win 4x4, but got %s", result.Value)
+	}
+	if err := game.HandleMove(0, result.Move); err != nil {
+		t.Errorf("Expected the best move to be legal, but got %v", err)
+	}
+}
+
+func TestSolve_StopsAtThePositionLimit(t *testing.T) {
+	game := newMoveGenerator(t, "domineering", "")
+
+	_, err := New(1000).Solve(game)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge for a full 8x8 board, but got %v", err)
+	}
+}
+
+func TestSolve_StopsAtTheDeadline(t *testing.T) {
+	game := newMoveGenerator(t, "domineering", "")
+
+	s := New(10_000_000)
+	s.SetDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, err := s.Solve(game); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge once the deadline passed, but got %v", err)
+	}
+}
+
+func TestSolve_StopsAtTheDepthLimit(t *testing.T) {
+	// Taking one counter at a time, the game lasts 300 plies.
+	game := newMoveGenerator(t, "nim", `{"heaps": [100, 100, 100], "subtraction": [1], "misere": false}`)
+
+	if _, err := New(10_000_000).Solve(game); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge past the depth limit, but got %v", err)
+	}
+}
+
+func TestSolve_CountsVisitedPositions(t *testing.T) {
+	game := newMoveGenerator(t, "tic-tac-toe", "")
+
+	s := New(100_000)
+	if _, err := s.Solve(game); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	// Solving again finds the answer in the table without visiting anything.
+	if _, err := s.Solve(game); err != nil {
+		t.Errorf("Expected the stored positions not to count again, but got %v", err)
+	}
+	if _, err := New(s.Positions() - 1).Solve(game); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge with fewer visits than positions, but got %v", err)
+	}
+}
+
+func TestSolve_DetectsRepetitions(t *testing.T) {
+	// Pawns can step back and forth, so the first line searched repeats.
+	game := newMoveGenerator(t, "quoridor", "")
+
+	s := New(10_000_000)
+	s.SetDeadline(time.Now().Add(time.Second))
+	if _, err := s.Solve(game); !errors.Is(err, ErrRepetition) {
+		t.Errorf("Expected the search to stop on a repetition, but got %v", err)
+	}
+}
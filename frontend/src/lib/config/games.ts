@@ -1,9 +1,9 @@
-export interface GameConfig {
-	id: string; // normalized lowercase with hyphens
-	displayName: string;
+import { error } from '@sveltejs/kit';
+
+// GameAssets is what this client adds to a game from the server's catalog:
+// the route it is played on and the media shown with it.
+export interface GameAssets {
 	path: string;
-	description: string;
-	rules: string[];
 	gameplayGif?: string;
 	sounds: {
 		move1: string;
@@ -13,118 +13,128 @@ export interface GameConfig {
 	playerSymbols: [string, string];
 }
 
-export const GAME_CONFIGS: Record<string, GameConfig> = {
+// GameConfig is a game from the server's catalog together with its assets.
+// Games without a board of their own in this client have hasBoard set to false
+// and are played on the generic board.
+export interface GameConfig extends GameAssets {
+	id: string; // the catalog's game type, lowercase with hyphens
+	displayName: string;
+	description: string;
+	rules: string[];
+	hasBoard: boolean;
+}
+
+// GameMetadata is a game as described by the server's catalog at /api/v1/games.
+export interface GameMetadata {
+	type: string;
+	displayName: string;
+	description: string;
+	minPlayers: number;
+	maxPlayers: number;
+	options: {
+		name: string;
+		label: string;
+		type: string;
+		default: unknown;
+	}[];
+	timeControl: {
+		initialSeconds: number;
+		incrementSeconds: number;
+	};
+	rules: string[];
+}
+
+const DEFAULT_SOUNDS = {
+	move1: '/sounds/move1.wav',
+	move2: '/sounds/move2.wav',
+	gameOver: '/sounds/gameOver.wav'
+};
+
+// GAME_ASSETS lists the games this client has a board for. Their names,
+// descriptions and rules come from the server's catalog.
+export const GAME_ASSETS: Record<string, GameAssets> = {
 	'tic-tac-toe': {
-		id: 'tic-tac-toe',
-		displayName: 'Tic-Tac-Toe',
 		path: 'tic-tac-toe',
-		description:
-			"The classic 3x3 grid game where players alternate placing X's and O's, aiming to get three in a row.",
-		rules: [
-			'You must place your symbol in an empty square',
-			'You must make a move on your turn',
-			'Three in a row wins (horizontal, vertical, or diagonal)',
-			'Game ends in a draw if the board is full with no winner'
-		],
 		gameplayGif: '/img/tic-tac-toe.gif',
-		sounds: {
-			move1: '/sounds/move1.wav',
-			move2: '/sounds/move2.wav',
-			gameOver: '/sounds/gameOver.wav'
-		},
+		sounds: DEFAULT_SOUNDS,
 		playerSymbols: ['X', 'O']
 	},
 	domineering: {
-		id: 'domineering',
-		displayName: 'Domineering',
 		path: 'domineering',
-		description:
-			'A strategic blocking game where one player places horizontal dominoes and the other vertical, competing for space on the grid. The player who can no longer make a valid move loses.',
-		rules: [
-			'Horizontal player can only place dominoes horizontally',
-			'Vertical player can only place dominoes vertically',
-			'Dominoes must occupy exactly 2 adjacent empty squares',
-			'You lose if you cannot make a valid move on your turn'
-		],
 		gameplayGif: '/img/domineering.gif',
-		sounds: {
-			move1: '/sounds/move1.wav',
-			move2: '/sounds/move2.wav',
-			gameOver: '/sounds/gameOver.wav'
-		},
+		sounds: DEFAULT_SOUNDS,
 		playerSymbols: ['H', 'V']
 	},
 	'dots-and-boxes': {
-		id: 'dots-and-boxes',
-		displayName: 'Dots & Boxes',
 		path: 'dots-and-boxes',
-		description:
-			'Connect dots to form boxes and claim them. The player who completes the most boxes wins!',
-		rules: [
-			'You must draw a line between two adjacent dots',
-			'You cannot draw a line that already exists',
-			'Completing a box earns you another turn',
-			'Most boxes claimed wins the game'
-		],
 		gameplayGif: '/img/dots-and-boxes.gif',
-		sounds: {
-			move1: '/sounds/move1.wav',
-			move2: '/sounds/move2.wav',
-			gameOver: '/sounds/gameOver.wav'
-		},
+		sounds: DEFAULT_SOUNDS,
 		playerSymbols: ['P1', 'P2']
 	},
 	nim: {
-		id: 'nim',
-		displayName: 'Nim',
 		path: 'nim',
-		description:
-			'A strategic game starting with 21 sticks. Players take turns removing 1, 2, or 3 sticks. Force your opponent to take the last stick and win!',
-		rules: [
-			'You must remove 1, 2, or 3 sticks on your turn',
-			'You cannot skip your turn',
-			'You cannot remove more than 3 sticks',
-			'You lose if you take the last stick'
-		],
 		gameplayGif: '/img/nim.gif',
-		sounds: {
-			move1: '/sounds/move1.wav',
-			move2: '/sounds/move2.wav',
-			gameOver: '/sounds/gameOver.wav'
-		},
+		sounds: DEFAULT_SOUNDS,
 		playerSymbols: ['P1', 'P2']
 	},
 	'connect-four': {
-		id: 'connect-four',
-		displayName: 'Connect Four',
 		path: 'connect-four',
-		description:
-			'Drop colored pieces into a 7x6 grid. Be the first to connect four of your discs in a row: horizontally, vertically, or diagonally!',
-		rules: [
-			'Drop a piece into any column that is not full',
-			'Pieces fall to the lowest available position',
-			'Connect four pieces in a row to win',
-			'Game ends in a draw if the board is full with no winner'
-		],
 		gameplayGif: 'https://placehold.co/400x400/18181b/71717a?text=No+Assets+Yet',
-		sounds: {
-			move1: '/sounds/move1.wav',
-			move2: '/sounds/move2.wav',
-			gameOver: '/sounds/gameOver.wav'
-		},
+		sounds: DEFAULT_SOUNDS,
 		playerSymbols: ['R', 'B']
 	}
 };
 
-export function getGameConfig(gameType: string): GameConfig {
-	const normalized = gameType.toLowerCase();
-	const config = GAME_CONFIGS[normalized];
-	if (!config) {
-		throw new Error(`Unknown game type: ${gameType}`);
+// toGameConfig adds the assets of a catalog game, or generic ones when this
+// client has no board for it.
+function toGameConfig(meta: GameMetadata): GameConfig {
+	const assets = GAME_ASSETS[meta.type];
+	return {
+		path: meta.type,
+		sounds: DEFAULT_SOUNDS,
+		playerSymbols: ['P1', 'P2'],
+		...assets,
+		id: meta.type,
+		displayName: meta.displayName,
+		description: meta.description,
+		rules: meta.rules,
+		hasBoard: assets !== undefined
+	};
+}
+
+async function fetchCatalog(fetch: typeof globalThis.fetch): Promise<GameMetadata[]> {
+	const response = await fetch(import.meta.env.VITE_SOCKET_URL + '/api/v1/games');
+	if (!response.ok) {
+		throw new Error(`HTTP error! status: ${response.status}`);
+	}
+	return response.json();
+}
+
+// loadGameConfigs lists every game in the server's catalog, in its order. The
+// list is empty when the catalog cannot be reached.
+export async function loadGameConfigs(fetch: typeof globalThis.fetch): Promise<GameConfig[]> {
+	try {
+		return (await fetchCatalog(fetch)).map(toGameConfig);
+	} catch (e) {
+		console.error('Error loading game catalog:', e);
+		return [];
 	}
-	return config;
 }
 
-export function getAllGameConfigs(): GameConfig[] {
-	return Object.values(GAME_CONFIGS);
+export async function loadGameConfig(
+	fetch: typeof globalThis.fetch,
+	gameType: string
+): Promise<GameConfig> {
+	let catalog: GameMetadata[];
+	try {
+		catalog = await fetchCatalog(fetch);
+	} catch (e) {
+		console.error('Error loading game catalog:', e);
+		error(503, 'The game catalog could not be loaded');
+	}
+	const meta = catalog.find((game) => game.type === gameType.toLowerCase());
+	if (!meta) {
+		error(404, `Unknown game type: ${gameType}`);
+	}
+	return toGameConfig(meta);
 }
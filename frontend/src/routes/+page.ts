@@ -0,0 +1,8 @@
+import { loadGameConfigs } from '$lib/config/games';
+import type { PageLoad } from './$types';
+
+export const load: PageLoad = async ({ fetch }) => {
+	return {
+		games: await loadGameConfigs(fetch)
+	};
+};
@@ -2,10 +2,20 @@ import { describe, test, expect } from 'vitest';
 import '@testing-library/jest-dom/vitest';
 import { render, screen } from '@testing-library/svelte';
 import Page from './+page.svelte';
+import { loadGameConfigs } from '$lib/config/games';
 
 describe('/+page.svelte', () => {
-	test('should render h1', () => {
-		render(Page);
+	test('should render h1', async () => {
+		const catalog = [{ type: 'nim', displayName: 'Nim', description: '', rules: [] }];
+		const fetch = async () => new Response(JSON.stringify(catalog));
+		render(Page, {
+			props: {
+				data: {
+					meta: { title: '', description: '', imageUrl: '', url: '' },
+					games: await loadGameConfigs(fetch as typeof globalThis.fetch)
+				}
+			}
+		});
 		expect(screen.getByRole('heading', { level: 1 })).toBeInTheDocument();
 	});
 });
@@ -1,10 +1,15 @@
+import { loadGameConfigs } from '$lib/config/games';
 import type { PageLoad } from './$types';
 
 export const load: PageLoad = async ({ fetch }) => {
-    const response = await fetch(import.meta.env.VITE_SOCKET_URL + '/api/v1/connections');
+    const [response, games] = await Promise.all([
+        fetch(import.meta.env.VITE_SOCKET_URL + '/api/v1/connections'),
+        loadGameConfigs(fetch)
+    ]);
     const connections = await response.json();
 
     return {
-        connections
+        connections,
+        games
     };
 };
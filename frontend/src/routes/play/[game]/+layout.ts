@@ -0,0 +1,9 @@
+import { loadGameConfig } from '$lib/config/games';
+import type { LayoutLoad } from './$types';
+
+// Games without a board of their own in this client are played here.
+export const load: LayoutLoad = async ({ fetch, params }) => {
+	return {
+		gameConfig: await loadGameConfig(fetch, params.game)
+	};
+};
@@ -0,0 +1,35 @@
+import type { PageLoad } from './$types';
+
+export const load: PageLoad = async ({ params, fetch, url, parent }) => {
+	const { gameConfig } = await parent();
+	const meta = (room: { name: string }) => ({
+		title: `Join ${room.name} - ${gameConfig.displayName} | Two Players`,
+		description: `Join "${room.name}" and play ${gameConfig.displayName}! ${gameConfig.description}`,
+		imageUrl: `${url.origin}/img/tic-tac-toe-preview.png`,
+		url: url.href
+	});
+
+	try {
+		const roomId = params.id;
+		const storedRoom = sessionStorage.getItem(`room_${roomId}`);
+
+		if (storedRoom) {
+			const room = JSON.parse(storedRoom);
+			return { room, error: null, meta: meta(room) };
+		}
+
+		const res = await fetch(import.meta.env.VITE_SOCKET_URL + `/api/v1/rooms/${roomId}`);
+
+		if (!res.ok) {
+			const errorData = await res
+				.json()
+				.catch(() => ({ message: `HTTP error! status: ${res.status}` }));
+			return { room: null, error: errorData.error || errorData.message };
+		}
+
+		const room = await res.json();
+		return { room, error: null, meta: meta(room) };
+	} catch (e) {
+		return { room: null, error: e instanceof Error ? e.message : 'An unknown error occurred' };
+	}
+};
@@ -0,0 +1,8 @@
+import { loadGameConfig } from '$lib/config/games';
+import type { LayoutLoad } from './$types';
+
+export const load: LayoutLoad = async ({ fetch }) => {
+	return {
+		gameConfig: await loadGameConfig(fetch, 'nim')
+	};
+};